@@ -30,6 +30,18 @@ import (
 	bootstrapsecretutil "k8s.io/cluster-bootstrap/util/secrets"
 )
 
+const (
+	// BootstrapTokenSingleUseKey is the key in the token Secret marking the token for
+	// deletion after its first successful join. This is a kubeadm specific extension of
+	// the bootstrap token Secret schema.
+	BootstrapTokenSingleUseKey = "single-use"
+
+	// BootstrapTokenAllowedCIDRsKey is the key in the token Secret recording the source
+	// networks the token may be used from. This is a kubeadm specific extension of the
+	// bootstrap token Secret schema.
+	BootstrapTokenAllowedCIDRsKey = "allowed-cidrs"
+)
+
 // ToSecret converts the given BootstrapToken object to its Secret representation that
 // may be submitted to the API Server in order to be stored.
 func (bt *BootstrapToken) ToSecret() *v1.Secret {
@@ -78,6 +90,14 @@ func encodeTokenSecretData(token *BootstrapToken, now time.Time) map[string][]by
 	if len(token.Groups) > 0 {
 		data[bootstrapapi.BootstrapTokenExtraGroupsKey] = []byte(strings.Join(token.Groups, ","))
 	}
+
+	if token.SingleUse {
+		data[BootstrapTokenSingleUseKey] = []byte("true")
+	}
+
+	if len(token.AllowedCIDRs) > 0 {
+		data[BootstrapTokenAllowedCIDRsKey] = []byte(strings.Join(token.AllowedCIDRs, ","))
+	}
 	return data
 }
 
@@ -149,11 +169,26 @@ func BootstrapTokenFromSecret(secret *v1.Secret) (*BootstrapToken, error) {
 		groups = g
 	}
 
+	// Get the single-use marker from the Secret
+	singleUse := bootstrapsecretutil.GetData(secret, BootstrapTokenSingleUseKey) == "true"
+
+	// Get the allowed CIDRs from the Secret
+	// It's done this way to make .AllowedCIDRs be nil in case there is no items, rather than an
+	// empty slice or an empty slice with a "" string only
+	var allowedCIDRs []string
+	allowedCIDRsString := bootstrapsecretutil.GetData(secret, BootstrapTokenAllowedCIDRsKey)
+	c := strings.Split(allowedCIDRsString, ",")
+	if len(c) > 0 && len(c[0]) > 0 {
+		allowedCIDRs = c
+	}
+
 	return &BootstrapToken{
-		Token:       bts,
-		Description: description,
-		Expires:     expires,
-		Usages:      usages,
-		Groups:      groups,
+		Token:        bts,
+		Description:  description,
+		Expires:      expires,
+		Usages:       usages,
+		Groups:       groups,
+		SingleUse:    singleUse,
+		AllowedCIDRs: allowedCIDRs,
 	}, nil
 }
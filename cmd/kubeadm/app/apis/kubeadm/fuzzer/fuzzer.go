@@ -43,6 +43,9 @@ func Funcs(codecs runtimeserializer.CodecFactory) []interface{} {
 func fuzzInitConfiguration(obj *kubeadm.InitConfiguration, c fuzz.Continue) {
 	c.FuzzNoCustom(obj)
 
+	// Pin values for fields that are not present in v1beta1
+	obj.Hooks = nil
+
 	// Pinning values for fields that get defaults if fuzz value is empty string or nil (thus making the round trip test fail)
 
 	// Since ClusterConfiguration never get serialized in the external variant of InitConfiguration,
@@ -86,6 +89,10 @@ func fuzzInitConfiguration(obj *kubeadm.InitConfiguration, c fuzz.Continue) {
 
 	// Pin values for fields that are not present in v1beta1
 	obj.CertificateKey = ""
+	for i := range obj.BootstrapTokens {
+		obj.BootstrapTokens[i].SingleUse = false
+		obj.BootstrapTokens[i].AllowedCIDRs = nil
+	}
 }
 
 func fuzzNodeRegistration(obj *kubeadm.NodeRegistrationOptions, c fuzz.Continue) {
@@ -93,6 +100,7 @@ func fuzzNodeRegistration(obj *kubeadm.NodeRegistrationOptions, c fuzz.Continue)
 
 	// Pinning values for fields that get defaults if fuzz value is empty string or nil (thus making the round trip test fail)
 	obj.IgnorePreflightErrors = nil
+	obj.NodeNamePolicy = "" // not present in v1beta1
 }
 
 func fuzzClusterConfiguration(obj *kubeadm.ClusterConfiguration, c fuzz.Continue) {
@@ -104,9 +112,45 @@ func fuzzClusterConfiguration(obj *kubeadm.ClusterConfiguration, c fuzz.Continue
 	obj.ClusterName = "bar"
 	obj.ImageRepository = "baz"
 	obj.KubernetesVersion = "qux"
+	obj.ExternallyManagedAddons = nil // not present in v1beta1
+	obj.ControlPlaneMarking = nil     // not present in v1beta1
 	obj.APIServer.TimeoutForControlPlane = &metav1.Duration{
 		Duration: constants.DefaultControlPlaneTimeout,
 	}
+
+	// Pin values for fields that are not present in v1beta1
+	obj.Proxy = nil
+	obj.PatchesConfigMap = ""
+	obj.PauseImage = nil
+	obj.ImagePull = nil
+	obj.ControlPlaneEndpointProvider = nil
+	obj.ExtraRBAC = nil
+	obj.Konnectivity = nil
+	obj.HTTPProxy = nil
+	obj.Timeouts = nil
+	obj.TLS = nil
+	obj.APIServer.ImageMeta = kubeadm.ImageMeta{}
+	obj.ControllerManager.ImageMeta = kubeadm.ImageMeta{}
+	obj.Scheduler.ImageMeta = kubeadm.ImageMeta{}
+	obj.APIServer.Resources = nil
+	obj.ControllerManager.Resources = nil
+	obj.Scheduler.Resources = nil
+	obj.APIServer.SecurityContext = nil
+	for i := range obj.APIServer.ExtraVolumes {
+		obj.APIServer.ExtraVolumes[i].Propagation = ""
+	}
+	for i := range obj.ControllerManager.ExtraVolumes {
+		obj.ControllerManager.ExtraVolumes[i].Propagation = ""
+	}
+	for i := range obj.Scheduler.ExtraVolumes {
+		obj.Scheduler.ExtraVolumes[i].Propagation = ""
+	}
+	obj.ControllerManager.SecurityContext = nil
+	obj.Scheduler.SecurityContext = nil
+	obj.CertificateKeyTTL = nil
+	obj.APIServer.EncryptionAtRest = nil
+	obj.APIServer.Admission = nil
+	obj.APIServer.Audit = nil
 }
 
 func fuzzDNS(obj *kubeadm.DNS, c fuzz.Continue) {
@@ -126,6 +170,13 @@ func fuzzLocalEtcd(obj *kubeadm.LocalEtcd, c fuzz.Continue) {
 
 	// Pinning values for fields that get defaults if fuzz value is empty string or nil (thus making the round trip test fail)
 	obj.DataDir = "foo"
+
+	// Pin values for fields that are not present in v1beta1
+	obj.QuotaBackendBytes = nil
+	obj.HeartbeatInterval = nil
+	obj.ElectionTimeout = nil
+	obj.AutoCompactionRetention = ""
+	obj.Resources = nil
 }
 
 func fuzzNetworking(obj *kubeadm.Networking, c fuzz.Continue) {
@@ -139,6 +190,9 @@ func fuzzNetworking(obj *kubeadm.Networking, c fuzz.Continue) {
 func fuzzJoinConfiguration(obj *kubeadm.JoinConfiguration, c fuzz.Continue) {
 	c.FuzzNoCustom(obj)
 
+	// Pin values for fields that are not present in v1beta1
+	obj.Hooks = nil
+
 	// Pinning values for fields that get defaults if fuzz value is empty string or nil (thus making the round trip test fail)
 	obj.CACertPath = "foo"
 	obj.Discovery = kubeadm.Discovery{
@@ -54,10 +54,41 @@ type InitConfiguration struct {
 	// CertificateKey sets the key with which certificates and keys are encrypted prior to being uploaded in
 	// a secret in the cluster during the uploadcerts init phase.
 	CertificateKey string
+
+	// Hooks declares local commands to run at defined points of the init workflow
+	Hooks []Hook
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// Hook declares a local command that runs at a defined point of the init or join
+// workflow, so provisioning glue scripts can move into the kubeadm managed flow
+type Hook struct {
+	// Phase is the point the hook runs at: "preInit", "postInit", "preJoin" or "postJoin"
+	Phase string
+
+	// Command holds the command and its arguments
+	Command []string
+
+	// Timeout bounds the execution of the command. Defaults to 5 minutes.
+	Timeout *metav1.Duration
+
+	// FailurePolicy decides what a failing hook does: "abort" (the default) stops the
+	// workflow, "ignore" logs and continues
+	FailurePolicy string
+}
+
+// ControlPlaneMarking configures the labels and taints kubeadm applies to
+// control-plane nodes, replacing the fixed mark-control-plane behavior
+type ControlPlaneMarking struct {
+	// Labels are added to every control-plane node in addition to the role label
+	Labels map[string]string
+
+	// AllowScheduling drops the control-plane NoSchedule taint, so regular workloads
+	// can be scheduled on control-plane nodes
+	AllowScheduling bool
+}
+
 // ClusterConfiguration contains cluster-wide configuration for a kubeadm cluster
 type ClusterConfiguration struct {
 	metav1.TypeMeta
@@ -99,9 +130,57 @@ type ClusterConfiguration struct {
 	// DNS defines the options for the DNS add-on installed in the cluster.
 	DNS DNS
 
+	// Proxy defines the options for the kube-proxy add-on installed in the cluster.
+	Proxy *Proxy
+
+	// PatchesConfigMap is the name of a ConfigMap in the kube-system namespace holding a
+	// patch set that every control-plane node and every upgrade downloads and applies, so
+	// identical patch directories don't have to be distributed to all nodes out of band.
+	PatchesConfigMap string
+
+	// PauseImage allows to customize the image used for the pause container
+	PauseImage *ImageMeta
+
+	// ExtraRBAC holds additional RBAC manifests (ClusterRoles, ClusterRoleBindings,
+	// Roles, RoleBindings as YAML documents) that kubeadm applies and reconciles
+	// whenever the cluster configuration is uploaded, so provisioning tools stop racing
+	// kubeadm to create them after init
+	ExtraRBAC []string
+
+	// ImagePull configures how kubeadm's own image pulls access registries, e.g. for
+	// air-gapped mirrors that would otherwise require manual runtime configuration
+	ImagePull *ImagePullConfiguration
+
+	// ControlPlaneEndpointProvider, when set, makes kubeadm deploy a virtual IP provider
+	// static pod for the controlPlaneEndpoint, so single-datacenter HA does not require
+	// an out-of-band load balancer before init
+	ControlPlaneEndpointProvider *ControlPlaneEndpointProvider
+
+	// Konnectivity, when set, makes kubeadm deploy the konnectivity-server as a static
+	// pod, the konnectivity-agent DaemonSet and the egress selector configuration of the
+	// API server, for clusters where the control plane cannot reach the node network
+	Konnectivity *Konnectivity
+
+	// HTTPProxy declares the proxy environment the control plane components run with,
+	// replacing the fragile reliance on ambient *_proxy environment variables
+	HTTPProxy *HTTPProxyConfiguration
+
+	// Timeouts makes the waits kubeadm performs configurable from the configuration
+	// file instead of being governed by hard-coded constants
+	Timeouts *Timeouts
+
+	// TLS applies a consistent TLS policy (minimum version, cipher suites) to the
+	// API server, controller-manager, scheduler, etcd and the generated kubelet
+	// configuration, instead of requiring separate extraArgs entries per component
+	TLS *TLSConfiguration
+
 	// CertificatesDir specifies where to store or look for all required certificates.
 	CertificatesDir string
 
+	// CertificateKeyTTL defines the time to live of the Secret holding the encrypted
+	// control-plane certificates uploaded by the upload-certs phase. Defaults to 2h.
+	CertificateKeyTTL *metav1.Duration
+
 	// ImageRepository sets the container registry to pull images from.
 	// If empty, `k8s.gcr.io` will be used by default; in case of kubernetes version is a CI build (kubernetes version starts with `ci/` or `ci-cross/`)
 	// `gcr.io/kubernetes-ci-images` will be used as a default for control plane components and for kube-proxy, while `k8s.gcr.io`
@@ -123,6 +202,14 @@ type ClusterConfiguration struct {
 
 	// The cluster name
 	ClusterName string
+
+	// ExternallyManagedAddons lists the addons ("coredns", "kube-proxy") another tool
+	// manages in this cluster; kubeadm leaves them alone during init and upgrades
+	ExternallyManagedAddons []string
+
+	// ControlPlaneMarking configures the labels and taints kubeadm applies to
+	// control-plane nodes and reconciles on upgrade
+	ControlPlaneMarking *ControlPlaneMarking
 }
 
 // ControlPlaneComponent holds settings common to control plane component of the cluster
@@ -134,6 +221,17 @@ type ControlPlaneComponent struct {
 
 	// ExtraVolumes is an extra set of host volumes, mounted to the control plane component.
 	ExtraVolumes []HostPathMount
+
+	// ImageMeta allows to customize the image used for this component
+	ImageMeta `json:",inline"`
+
+	// Resources overrides the compute resource requirements of the component's container.
+	// nil keeps the kubeadm defaults.
+	Resources *v1.ResourceRequirements
+
+	// SecurityContext overrides the security context of the component's container.
+	// nil keeps the kubeadm defaults.
+	SecurityContext *v1.SecurityContext
 }
 
 // APIServer holds settings necessary for API server deployments in the cluster
@@ -145,6 +243,55 @@ type APIServer struct {
 
 	// TimeoutForControlPlane controls the timeout that we use for API server to appear
 	TimeoutForControlPlane *metav1.Duration
+
+	// EncryptionAtRest enables encryption of API data at rest. When set, kubeadm generates
+	// an encryption configuration file for the configured provider, mounts it into the API
+	// server static pod and keeps it across upgrades.
+	EncryptionAtRest *EncryptionAtRest
+
+	// Admission, when set, makes kubeadm render an AdmissionConfiguration file for the
+	// declared plugins, mount it into the API server static pod and preserve it across
+	// upgrades - replacing fragile extraArgs plus extraVolumes combinations.
+	Admission *AdmissionConfiguration
+
+	// Audit, when set, wires audit logging into the API server: the policy file, the
+	// log or webhook backend and the needed mounts are managed by kubeadm and carried
+	// forward on upgrades.
+	Audit *AuditConfiguration
+}
+
+// AuditConfiguration describes the audit setup of the API server
+type AuditConfiguration struct {
+	// PolicyContent is the audit policy document kubeadm writes to disk and mounts.
+	// Mutually exclusive with PolicyFile.
+	PolicyContent string
+
+	// PolicyFile is the path of an audit policy that already exists on the host.
+	// Mutually exclusive with PolicyContent.
+	PolicyFile string
+
+	// LogDir, when set, enables the log backend: audit logs are written below this host
+	// directory, which is mounted read-write into the API server static pod
+	LogDir string
+
+	// LogMaxAge is the maximum number of days to retain old audit log files
+	LogMaxAge *int32
+
+	// WebhookConfigFile, when set, enables the webhook backend using the kubeconfig
+	// at this host path
+	WebhookConfigFile string
+}
+
+// AdmissionConfiguration describes the admission plugin configuration of the API server
+type AdmissionConfiguration struct {
+	// Plugins maps admission plugin names to their configuration as an inline YAML document
+	Plugins map[string]string
+}
+
+// EncryptionAtRest holds configuration for encrypting API data at rest
+type EncryptionAtRest struct {
+	// Provider is the encryption provider to use: "aescbc", "aesgcm" or "secretbox"
+	Provider string
 }
 
 // DNSAddOnType defines string identifying DNS add-on types
@@ -156,6 +303,9 @@ const (
 
 	// KubeDNS add-on type
 	KubeDNS DNSAddOnType = "kube-dns"
+
+	// ExternalDNS add-on type, set when the DNS add-on is managed outside of kubeadm
+	ExternalDNS DNSAddOnType = "External"
 )
 
 // DNS defines the DNS addon that should be used in the cluster
@@ -167,6 +317,88 @@ type DNS struct {
 	ImageMeta `json:",inline"`
 }
 
+// ControlPlaneEndpointProvider describes a virtual IP provider deployed by kubeadm for
+// the controlPlaneEndpoint
+type ControlPlaneEndpointProvider struct {
+	// Type selects the provider implementation; currently only "kube-vip" is supported
+	Type string
+
+	// Image is the provider image to deploy
+	Image string
+
+	// Interface is the network interface the virtual IP is announced on
+	Interface string
+}
+
+// TLSConfiguration is the TLS policy applied to all control plane components
+type TLSConfiguration struct {
+	// MinVersion is the minimum accepted TLS version, e.g. "VersionTLS12"
+	MinVersion string
+
+	// CipherSuites lists the allowed cipher suites, e.g. "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"
+	CipherSuites []string
+}
+
+// Timeouts holds the timeouts of the waits kubeadm performs
+type Timeouts struct {
+	// ControlPlaneComponentHealthCheck bounds waiting for the control plane components
+	// to become healthy, e.g. after init, join and upgrade manifest rollouts.
+	// Defaults to the TimeoutForControlPlane value.
+	ControlPlaneComponentHealthCheck *metav1.Duration
+
+	// KubeletHealthCheck bounds waiting for the kubelet /healthz endpoint
+	KubeletHealthCheck *metav1.Duration
+
+	// EtcdAPICall bounds a single request to the etcd cluster
+	EtcdAPICall *metav1.Duration
+}
+
+// HTTPProxyConfiguration declares the proxy environment of the control plane
+type HTTPProxyConfiguration struct {
+	// HTTPProxy is the proxy for plain HTTP requests
+	HTTPProxy string
+
+	// HTTPSProxy is the proxy for HTTPS requests
+	HTTPSProxy string
+
+	// NoProxy lists hosts, domains and CIDRs that are reached directly; it should
+	// include the service and pod subnets and the node addresses
+	NoProxy []string
+}
+
+// Konnectivity describes the konnectivity deployment managed by kubeadm
+type Konnectivity struct {
+	// ServerImage is the konnectivity-server image to deploy
+	ServerImage string
+
+	// AgentImage is the konnectivity-agent image to deploy
+	AgentImage string
+}
+
+// ImagePullConfiguration configures how kubeadm's own image pulls access registries
+type ImagePullConfiguration struct {
+	// Mirrors maps a registry host to the mirror host kubeadm pulls from instead
+	Mirrors map[string]string
+
+	// CredentialsFile is the path to a file of the form "username:password" with the
+	// credentials kubeadm passes to the container runtime for its image pulls
+	CredentialsFile string
+
+	// InsecureRegistries lists registry hosts that are accessed without TLS verification
+	InsecureRegistries []string
+}
+
+// Proxy defines the proxy addon that should be used in the cluster
+type Proxy struct {
+	// Disabled specifies that the kube-proxy add-on should not be deployed or managed by kubeadm.
+	// The value is persisted to the cluster and respected by join and upgrade, for clusters that
+	// run a kube-proxy replacement deployed out of band.
+	Disabled bool
+
+	// ImageMeta allows to customize the image used for the kube-proxy add-on
+	ImageMeta `json:",inline"`
+}
+
 // ImageMeta allows to customize the image used for components that are not
 // originated from the Kubernetes/Kubernetes release process
 type ImageMeta struct {
@@ -226,6 +458,12 @@ type NodeRegistrationOptions struct {
 
 	// IgnorePreflightErrors provides a slice of pre-flight errors to be ignored when the current node is registered.
 	IgnorePreflightErrors []string
+
+	// NodeNamePolicy decides how the node name is derived when Name is not set
+	// explicitly: "hostname" (the default), "fqdn", "cloud-metadata" or
+	// "template:<go-template>" rendered with .Hostname and .FQDN. The resolved name is
+	// used consistently for certificate SANs, node registration and etcd member naming.
+	NodeNamePolicy string
 }
 
 // Networking contains elements describing cluster's networking configuration.
@@ -260,6 +498,14 @@ type BootstrapToken struct {
 	// Groups specifies the extra groups that this token will authenticate as when/if
 	// used for authentication
 	Groups []string
+	// SingleUse marks the token as intended for a single join. The field
+	// is only stored in the token Secret as an advisory marker for an external
+	// controller to act on - kubeadm itself does not enforce it.
+	SingleUse bool
+	// AllowedCIDRs records from which source networks this token is meant to be used for
+	// joining. The field is stored in the token Secret so controllers enforcing it can
+	// pick it up.
+	AllowedCIDRs []string
 }
 
 // Etcd contains elements describing Etcd configuration.
@@ -291,6 +537,26 @@ type LocalEtcd struct {
 	ServerCertSANs []string
 	// PeerCertSANs sets extra Subject Alternative Names for the etcd peer signing cert.
 	PeerCertSANs []string
+
+	// QuotaBackendBytes raises the size limit of the etcd backend database in bytes.
+	// nil means the etcd default is used.
+	QuotaBackendBytes *int64
+
+	// HeartbeatInterval is the frequency with which the etcd leader notifies followers.
+	// nil means the etcd default is used.
+	HeartbeatInterval *metav1.Duration
+
+	// ElectionTimeout is how long etcd followers wait before starting a leader election.
+	// nil means the etcd default is used.
+	ElectionTimeout *metav1.Duration
+
+	// AutoCompactionRetention configures etcd auto compaction, e.g. "5" for hours or a
+	// duration like "30m". An empty value leaves auto compaction disabled.
+	AutoCompactionRetention string
+
+	// Resources sets the compute resource requirements of the etcd static pod.
+	// nil keeps the kubeadm defaults.
+	Resources *v1.ResourceRequirements
 }
 
 // ExternalEtcd describes an external etcd cluster
@@ -327,6 +593,8 @@ type JoinConfiguration struct {
 	// ControlPlane defines the additional control plane instance to be deployed on the joining node.
 	// If nil, no additional control plane instance will be deployed.
 	ControlPlane *JoinControlPlane
+	// Hooks declares local commands to run at defined points of the join workflow
+	Hooks []Hook
 }
 
 // JoinControlPlane contains elements describing an additional control plane instance to be deployed on the joining node.
@@ -389,6 +657,17 @@ type FileDiscovery struct {
 	KubeConfigPath string
 }
 
+// AddonIsExternallyManaged returns true when the named addon is listed as managed by
+// another tool, in which case the addon phases must leave it alone
+func (cfg *ClusterConfiguration) AddonIsExternallyManaged(name string) bool {
+	for _, addon := range cfg.ExternallyManagedAddons {
+		if addon == name {
+			return true
+		}
+	}
+	return false
+}
+
 // GetControlPlaneImageRepository returns name of image repository
 // for control plane images (API,Controller Manager,Scheduler and Proxy)
 // It will override location with CI registry name in case user requests special
@@ -415,6 +694,11 @@ type HostPathMount struct {
 	ReadOnly bool
 	// PathType is the type of the HostPath.
 	PathType v1.HostPathType
+	// Propagation determines how mounts below the volume are propagated between the
+	// host and the container (None, HostToContainer or Bidirectional). An empty value
+	// keeps the kubelet default. Note that hostPath volumes of static pods cannot be
+	// relabeled for SELinux per volume; use the component securityContext instead.
+	Propagation v1.MountPropagationMode
 }
 
 // DocumentMap is a convenient way to describe a map between a YAML document and its GVK type
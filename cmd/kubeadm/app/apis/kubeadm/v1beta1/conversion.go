@@ -22,6 +22,109 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 )
 
+func Convert_kubeadm_ClusterConfiguration_To_v1beta1_ClusterConfiguration(in *kubeadm.ClusterConfiguration, out *ClusterConfiguration, s conversion.Scope) error {
+	if err := autoConvert_kubeadm_ClusterConfiguration_To_v1beta1_ClusterConfiguration(in, out, s); err != nil {
+		return err
+	}
+
+	if in.Proxy != nil && in.Proxy.Disabled {
+		return errors.New("proxy field is not supported by v1beta1 config format")
+	}
+
+	if in.PauseImage != nil {
+		return errors.New("pauseImage field is not supported by v1beta1 config format")
+	}
+
+	if len(in.ExternallyManagedAddons) != 0 {
+		return errors.New("externallyManagedAddons field is not supported by v1beta1 config format")
+	}
+
+	if in.ControlPlaneMarking != nil {
+		return errors.New("controlPlaneMarking field is not supported by v1beta1 config format")
+	}
+
+	if len(in.ExtraRBAC) != 0 {
+		return errors.New("extraRBAC field is not supported by v1beta1 config format")
+	}
+
+	if in.ImagePull != nil {
+		return errors.New("imagePull field is not supported by v1beta1 config format")
+	}
+
+	if in.ControlPlaneEndpointProvider != nil {
+		return errors.New("controlPlaneEndpointProvider field is not supported by v1beta1 config format")
+	}
+
+	if in.Konnectivity != nil {
+		return errors.New("konnectivity field is not supported by v1beta1 config format")
+	}
+
+	if in.HTTPProxy != nil {
+		return errors.New("httpProxy field is not supported by v1beta1 config format")
+	}
+
+	if in.Timeouts != nil {
+		return errors.New("timeouts field is not supported by v1beta1 config format")
+	}
+
+	if in.TLS != nil {
+		return errors.New("tls field is not supported by v1beta1 config format")
+	}
+
+	if in.PatchesConfigMap != "" {
+		return errors.New("patchesConfigMap field is not supported by v1beta1 config format")
+	}
+
+	if in.CertificateKeyTTL != nil {
+		return errors.New("certificateKeyTTL field is not supported by v1beta1 config format")
+	}
+
+	if in.APIServer.EncryptionAtRest != nil {
+		return errors.New("apiServer.encryptionAtRest field is not supported by v1beta1 config format")
+	}
+
+	if in.APIServer.Admission != nil {
+		return errors.New("apiServer.admission field is not supported by v1beta1 config format")
+	}
+
+	if in.APIServer.Audit != nil {
+		return errors.New("apiServer.audit field is not supported by v1beta1 config format")
+	}
+
+	for _, component := range []struct {
+		name string
+		cpc  *kubeadm.ControlPlaneComponent
+	}{
+		{"apiServer", &in.APIServer.ControlPlaneComponent},
+		{"controllerManager", &in.ControllerManager},
+		{"scheduler", &in.Scheduler},
+	} {
+		if component.cpc.ImageMeta.ImageRepository != "" || component.cpc.ImageMeta.ImageTag != "" {
+			return errors.Errorf("%s imageRepository/imageTag fields are not supported by v1beta1 config format", component.name)
+		}
+		if component.cpc.Resources != nil {
+			return errors.Errorf("%s.resources field is not supported by v1beta1 config format", component.name)
+		}
+		if component.cpc.SecurityContext != nil {
+			return errors.Errorf("%s.securityContext field is not supported by v1beta1 config format", component.name)
+		}
+		for _, volume := range component.cpc.ExtraVolumes {
+			if volume.Propagation != "" {
+				return errors.Errorf("%s extraVolumes propagation field is not supported by v1beta1 config format", component.name)
+			}
+		}
+	}
+
+	if local := in.Etcd.Local; local != nil {
+		if local.QuotaBackendBytes != nil || local.HeartbeatInterval != nil || local.ElectionTimeout != nil ||
+			local.AutoCompactionRetention != "" || local.Resources != nil {
+			return errors.New("etcd.local tuning fields are not supported by v1beta1 config format")
+		}
+	}
+
+	return nil
+}
+
 func Convert_kubeadm_InitConfiguration_To_v1beta1_InitConfiguration(in *kubeadm.InitConfiguration, out *InitConfiguration, s conversion.Scope) error {
 	if err := autoConvert_kubeadm_InitConfiguration_To_v1beta1_InitConfiguration(in, out, s); err != nil {
 		return err
@@ -31,6 +134,31 @@ func Convert_kubeadm_InitConfiguration_To_v1beta1_InitConfiguration(in *kubeadm.
 		return errors.New("certificateKey field is not supported by v1beta1 config format")
 	}
 
+	if len(in.Hooks) != 0 {
+		return errors.New("hooks field is not supported by v1beta1 config format")
+	}
+
+	for _, token := range in.BootstrapTokens {
+		if token.SingleUse {
+			return errors.New("bootstrapTokens singleUse field is not supported by v1beta1 config format")
+		}
+		if len(token.AllowedCIDRs) != 0 {
+			return errors.New("bootstrapTokens allowedCIDRs field is not supported by v1beta1 config format")
+		}
+	}
+
+	return nil
+}
+
+func Convert_kubeadm_JoinConfiguration_To_v1beta1_JoinConfiguration(in *kubeadm.JoinConfiguration, out *JoinConfiguration, s conversion.Scope) error {
+	if err := autoConvert_kubeadm_JoinConfiguration_To_v1beta1_JoinConfiguration(in, out, s); err != nil {
+		return err
+	}
+
+	if len(in.Hooks) != 0 {
+		return errors.New("hooks field is not supported by v1beta1 config format")
+	}
+
 	return nil
 }
 
@@ -55,5 +183,9 @@ func Convert_kubeadm_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(
 		return errors.New("ignorePreflightErrors field is not supported by v1beta1 config format")
 	}
 
+	if in.NodeNamePolicy != "" {
+		return errors.New("nodeNamePolicy field is not supported by v1beta1 config format")
+	}
+
 	return nil
 }
@@ -50,10 +50,45 @@ type InitConfiguration struct {
 	// CertificateKey sets the key with which certificates and keys are encrypted prior to being uploaded in
 	// a secret in the cluster during the uploadcerts init phase.
 	CertificateKey string `json:"certificateKey,omitempty"`
+	// Hooks declares local commands to run at defined points of the init workflow
+	// +optional
+	Hooks []Hook `json:"hooks,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// Hook declares a local command that runs at a defined point of the init or join
+// workflow, so provisioning glue scripts can move into the kubeadm managed flow
+type Hook struct {
+	// Phase is the point the hook runs at: "preInit", "postInit", "preJoin" or "postJoin"
+	Phase string `json:"phase"`
+
+	// Command holds the command and its arguments
+	Command []string `json:"command"`
+
+	// Timeout bounds the execution of the command. Defaults to 5 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailurePolicy decides what a failing hook does: "abort" (the default) stops the
+	// workflow, "ignore" logs and continues
+	// +optional
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+// ControlPlaneMarking configures the labels and taints kubeadm applies to
+// control-plane nodes, replacing the fixed mark-control-plane behavior
+type ControlPlaneMarking struct {
+	// Labels are added to every control-plane node in addition to the role label
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// AllowScheduling drops the control-plane NoSchedule taint, so regular workloads
+	// can be scheduled on control-plane nodes
+	// +optional
+	AllowScheduling bool `json:"allowScheduling,omitempty"`
+}
+
 // ClusterConfiguration contains cluster-wide configuration for a kubeadm cluster
 type ClusterConfiguration struct {
 	metav1.TypeMeta `json:",inline"`
@@ -92,9 +127,57 @@ type ClusterConfiguration struct {
 	// DNS defines the options for the DNS add-on installed in the cluster.
 	DNS DNS `json:"dns,omitempty"`
 
+	// Proxy defines the options for the kube-proxy add-on installed in the cluster.
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// PatchesConfigMap is the name of a ConfigMap in the kube-system namespace holding a
+	// patch set that every control-plane node and every upgrade downloads and applies, so
+	// identical patch directories don't have to be distributed to all nodes out of band.
+	PatchesConfigMap string `json:"patchesConfigMap,omitempty"`
+
+	// PauseImage allows to customize the image used for the pause container
+	PauseImage *ImageMeta `json:"pauseImage,omitempty"`
+
+	// ExtraRBAC holds additional RBAC manifests (ClusterRoles, ClusterRoleBindings,
+	// Roles, RoleBindings as YAML documents) that kubeadm applies and reconciles
+	// whenever the cluster configuration is uploaded, so provisioning tools stop racing
+	// kubeadm to create them after init
+	ExtraRBAC []string `json:"extraRBAC,omitempty"`
+
+	// ImagePull configures how kubeadm's own image pulls access registries, e.g. for
+	// air-gapped mirrors that would otherwise require manual runtime configuration
+	ImagePull *ImagePullConfiguration `json:"imagePull,omitempty"`
+
+	// ControlPlaneEndpointProvider, when set, makes kubeadm deploy a virtual IP provider
+	// static pod for the controlPlaneEndpoint, so single-datacenter HA does not require
+	// an out-of-band load balancer before init
+	ControlPlaneEndpointProvider *ControlPlaneEndpointProvider `json:"controlPlaneEndpointProvider,omitempty"`
+
+	// Konnectivity, when set, makes kubeadm deploy the konnectivity-server as a static
+	// pod, the konnectivity-agent DaemonSet and the egress selector configuration of the
+	// API server, for clusters where the control plane cannot reach the node network
+	Konnectivity *Konnectivity `json:"konnectivity,omitempty"`
+
+	// HTTPProxy declares the proxy environment the control plane components run with,
+	// replacing the fragile reliance on ambient *_proxy environment variables
+	HTTPProxy *HTTPProxyConfiguration `json:"httpProxy,omitempty"`
+
+	// Timeouts makes the waits kubeadm performs configurable from the configuration
+	// file instead of being governed by hard-coded constants
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+
+	// TLS applies a consistent TLS policy (minimum version, cipher suites) to the
+	// API server, controller-manager, scheduler, etcd and the generated kubelet
+	// configuration, instead of requiring separate extraArgs entries per component
+	TLS *TLSConfiguration `json:"tls,omitempty"`
+
 	// CertificatesDir specifies where to store or look for all required certificates.
 	CertificatesDir string `json:"certificatesDir,omitempty"`
 
+	// CertificateKeyTTL defines the time to live of the Secret holding the encrypted
+	// control-plane certificates uploaded by the upload-certs phase. Defaults to 2h.
+	CertificateKeyTTL *metav1.Duration `json:"certificateKeyTTL,omitempty"`
+
 	// ImageRepository sets the container registry to pull images from.
 	// If empty, `k8s.gcr.io` will be used by default; in case of kubernetes version is a CI build (kubernetes version starts with `ci/` or `ci-cross/`)
 	// `gcr.io/kubernetes-ci-images` will be used as a default for control plane components and for kube-proxy, while `k8s.gcr.io`
@@ -111,6 +194,16 @@ type ClusterConfiguration struct {
 
 	// The cluster name
 	ClusterName string `json:"clusterName,omitempty"`
+
+	// ExternallyManagedAddons lists the addons ("coredns", "kube-proxy") another tool
+	// manages in this cluster; kubeadm leaves them alone during init and upgrades
+	// +optional
+	ExternallyManagedAddons []string `json:"externallyManagedAddons,omitempty"`
+
+	// ControlPlaneMarking configures the labels and taints kubeadm applies to
+	// control-plane nodes and reconciles on upgrade
+	// +optional
+	ControlPlaneMarking *ControlPlaneMarking `json:"controlPlaneMarking,omitempty"`
 }
 
 // ControlPlaneComponent holds settings common to control plane component of the cluster
@@ -122,6 +215,17 @@ type ControlPlaneComponent struct {
 
 	// ExtraVolumes is an extra set of host volumes, mounted to the control plane component.
 	ExtraVolumes []HostPathMount `json:"extraVolumes,omitempty"`
+
+	// ImageMeta allows to customize the image used for this component
+	ImageMeta `json:",inline"`
+
+	// Resources overrides the compute resource requirements of the component's container.
+	// nil keeps the kubeadm defaults.
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// SecurityContext overrides the security context of the component's container.
+	// nil keeps the kubeadm defaults.
+	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
 }
 
 // APIServer holds settings necessary for API server deployments in the cluster
@@ -133,6 +237,55 @@ type APIServer struct {
 
 	// TimeoutForControlPlane controls the timeout that we use for API server to appear
 	TimeoutForControlPlane *metav1.Duration `json:"timeoutForControlPlane,omitempty"`
+
+	// EncryptionAtRest enables encryption of API data at rest. When set, kubeadm generates
+	// an encryption configuration file for the configured provider, mounts it into the API
+	// server static pod and keeps it across upgrades.
+	EncryptionAtRest *EncryptionAtRest `json:"encryptionAtRest,omitempty"`
+
+	// Admission, when set, makes kubeadm render an AdmissionConfiguration file for the
+	// declared plugins, mount it into the API server static pod and preserve it across
+	// upgrades - replacing fragile extraArgs plus extraVolumes combinations.
+	Admission *AdmissionConfiguration `json:"admission,omitempty"`
+
+	// Audit, when set, wires audit logging into the API server: the policy file, the
+	// log or webhook backend and the needed mounts are managed by kubeadm and carried
+	// forward on upgrades.
+	Audit *AuditConfiguration `json:"audit,omitempty"`
+}
+
+// AuditConfiguration describes the audit setup of the API server
+type AuditConfiguration struct {
+	// PolicyContent is the audit policy document kubeadm writes to disk and mounts.
+	// Mutually exclusive with PolicyFile.
+	PolicyContent string `json:"policyContent,omitempty"`
+
+	// PolicyFile is the path of an audit policy that already exists on the host.
+	// Mutually exclusive with PolicyContent.
+	PolicyFile string `json:"policyFile,omitempty"`
+
+	// LogDir, when set, enables the log backend: audit logs are written below this host
+	// directory, which is mounted read-write into the API server static pod
+	LogDir string `json:"logDir,omitempty"`
+
+	// LogMaxAge is the maximum number of days to retain old audit log files
+	LogMaxAge *int32 `json:"logMaxAge,omitempty"`
+
+	// WebhookConfigFile, when set, enables the webhook backend using the kubeconfig
+	// at this host path
+	WebhookConfigFile string `json:"webhookConfigFile,omitempty"`
+}
+
+// AdmissionConfiguration describes the admission plugin configuration of the API server
+type AdmissionConfiguration struct {
+	// Plugins maps admission plugin names to their configuration as an inline YAML document
+	Plugins map[string]string `json:"plugins,omitempty"`
+}
+
+// EncryptionAtRest holds configuration for encrypting API data at rest
+type EncryptionAtRest struct {
+	// Provider is the encryption provider to use: "aescbc", "aesgcm" or "secretbox"
+	Provider string `json:"provider"`
 }
 
 // DNSAddOnType defines string identifying DNS add-on types
@@ -144,6 +297,9 @@ const (
 
 	// KubeDNS add-on type
 	KubeDNS DNSAddOnType = "kube-dns"
+
+	// ExternalDNS add-on type, set when the DNS add-on is managed outside of kubeadm
+	ExternalDNS DNSAddOnType = "External"
 )
 
 // DNS defines the DNS addon that should be used in the cluster
@@ -155,6 +311,88 @@ type DNS struct {
 	ImageMeta `json:",inline"`
 }
 
+// ControlPlaneEndpointProvider describes a virtual IP provider deployed by kubeadm for
+// the controlPlaneEndpoint
+type ControlPlaneEndpointProvider struct {
+	// Type selects the provider implementation; currently only "kube-vip" is supported
+	Type string `json:"type"`
+
+	// Image is the provider image to deploy
+	Image string `json:"image,omitempty"`
+
+	// Interface is the network interface the virtual IP is announced on
+	Interface string `json:"interface,omitempty"`
+}
+
+// TLSConfiguration is the TLS policy applied to all control plane components
+type TLSConfiguration struct {
+	// MinVersion is the minimum accepted TLS version, e.g. "VersionTLS12"
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// CipherSuites lists the allowed cipher suites, e.g. "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// Timeouts holds the timeouts of the waits kubeadm performs
+type Timeouts struct {
+	// ControlPlaneComponentHealthCheck bounds waiting for the control plane components
+	// to become healthy, e.g. after init, join and upgrade manifest rollouts.
+	// Defaults to the TimeoutForControlPlane value.
+	ControlPlaneComponentHealthCheck *metav1.Duration `json:"controlPlaneComponentHealthCheck,omitempty"`
+
+	// KubeletHealthCheck bounds waiting for the kubelet /healthz endpoint
+	KubeletHealthCheck *metav1.Duration `json:"kubeletHealthCheck,omitempty"`
+
+	// EtcdAPICall bounds a single request to the etcd cluster
+	EtcdAPICall *metav1.Duration `json:"etcdAPICall,omitempty"`
+}
+
+// HTTPProxyConfiguration declares the proxy environment of the control plane
+type HTTPProxyConfiguration struct {
+	// HTTPProxy is the proxy for plain HTTP requests
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the proxy for HTTPS requests
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy lists hosts, domains and CIDRs that are reached directly; it should
+	// include the service and pod subnets and the node addresses
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// Konnectivity describes the konnectivity deployment managed by kubeadm
+type Konnectivity struct {
+	// ServerImage is the konnectivity-server image to deploy
+	ServerImage string `json:"serverImage,omitempty"`
+
+	// AgentImage is the konnectivity-agent image to deploy
+	AgentImage string `json:"agentImage,omitempty"`
+}
+
+// ImagePullConfiguration configures how kubeadm's own image pulls access registries
+type ImagePullConfiguration struct {
+	// Mirrors maps a registry host to the mirror host kubeadm pulls from instead
+	Mirrors map[string]string `json:"mirrors,omitempty"`
+
+	// CredentialsFile is the path to a file of the form "username:password" with the
+	// credentials kubeadm passes to the container runtime for its image pulls
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+
+	// InsecureRegistries lists registry hosts that are accessed without TLS verification
+	InsecureRegistries []string `json:"insecureRegistries,omitempty"`
+}
+
+// Proxy defines the proxy addon that should be used in the cluster
+type Proxy struct {
+	// Disabled specifies that the kube-proxy add-on should not be deployed or managed by kubeadm.
+	// The value is persisted to the cluster and respected by join and upgrade, for clusters that
+	// run a kube-proxy replacement deployed out of band.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// ImageMeta allows to customize the image used for the kube-proxy add-on
+	ImageMeta `json:",inline"`
+}
+
 // ImageMeta allows to customize the image used for components that are not
 // originated from the Kubernetes/Kubernetes release process
 type ImageMeta struct {
@@ -214,6 +452,13 @@ type NodeRegistrationOptions struct {
 
 	// IgnorePreflightErrors provides a slice of pre-flight errors to be ignored when the current node is registered.
 	IgnorePreflightErrors []string `json:"ignorePreflightErrors,omitempty"`
+
+	// NodeNamePolicy decides how the node name is derived when Name is not set
+	// explicitly: "hostname" (the default), "fqdn", "cloud-metadata" or
+	// "template:<go-template>" rendered with .Hostname and .FQDN. The resolved name is
+	// used consistently for certificate SANs, node registration and etcd member naming.
+	// +optional
+	NodeNamePolicy string `json:"nodeNamePolicy,omitempty"`
 }
 
 // Networking contains elements describing cluster's networking configuration
@@ -246,6 +491,14 @@ type BootstrapToken struct {
 	// Groups specifies the extra groups that this token will authenticate as when/if
 	// used for authentication
 	Groups []string `json:"groups,omitempty"`
+	// SingleUse marks the token as intended for a single join. The field
+	// is only stored in the token Secret as an advisory marker for an external
+	// controller to act on - kubeadm itself does not enforce it.
+	SingleUse bool `json:"singleUse,omitempty"`
+	// AllowedCIDRs records from which source networks this token is meant to be used for
+	// joining. The field is stored in the token Secret so controllers enforcing it can
+	// pick it up.
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
 }
 
 // Etcd contains elements describing Etcd configuration.
@@ -277,6 +530,26 @@ type LocalEtcd struct {
 	ServerCertSANs []string `json:"serverCertSANs,omitempty"`
 	// PeerCertSANs sets extra Subject Alternative Names for the etcd peer signing cert.
 	PeerCertSANs []string `json:"peerCertSANs,omitempty"`
+
+	// QuotaBackendBytes raises the size limit of the etcd backend database in bytes.
+	// nil means the etcd default is used.
+	QuotaBackendBytes *int64 `json:"quotaBackendBytes,omitempty"`
+
+	// HeartbeatInterval is the frequency with which the etcd leader notifies followers.
+	// nil means the etcd default is used.
+	HeartbeatInterval *metav1.Duration `json:"heartbeatInterval,omitempty"`
+
+	// ElectionTimeout is how long etcd followers wait before starting a leader election.
+	// nil means the etcd default is used.
+	ElectionTimeout *metav1.Duration `json:"electionTimeout,omitempty"`
+
+	// AutoCompactionRetention configures etcd auto compaction, e.g. "5" for hours or a
+	// duration like "30m". An empty value leaves auto compaction disabled.
+	AutoCompactionRetention string `json:"autoCompactionRetention,omitempty"`
+
+	// Resources sets the compute resource requirements of the etcd static pod.
+	// nil keeps the kubeadm defaults.
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // ExternalEtcd describes an external etcd cluster.
@@ -318,6 +591,9 @@ type JoinConfiguration struct {
 	// ControlPlane defines the additional control plane instance to be deployed on the joining node.
 	// If nil, no additional control plane instance will be deployed.
 	ControlPlane *JoinControlPlane `json:"controlPlane,omitempty"`
+	// Hooks declares local commands to run at defined points of the join workflow
+	// +optional
+	Hooks []Hook `json:"hooks,omitempty"`
 }
 
 // JoinControlPlane contains elements describing an additional control plane instance to be deployed on the joining node.
@@ -394,4 +670,9 @@ type HostPathMount struct {
 	ReadOnly bool `json:"readOnly,omitempty"`
 	// PathType is the type of the HostPath.
 	PathType v1.HostPathType `json:"pathType,omitempty"`
+	// Propagation determines how mounts below the volume are propagated between the
+	// host and the container (None, HostToContainer or Bidirectional). An empty value
+	// keeps the kubelet default. Note that hostPath volumes of static pods cannot be
+	// relabeled for SELinux per volume; use the component securityContext instead.
+	Propagation v1.MountPropagationMode `json:"propagation,omitempty"`
 }
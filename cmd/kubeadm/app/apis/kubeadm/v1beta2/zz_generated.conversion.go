@@ -167,6 +167,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Hook)(nil), (*kubeadm.Hook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_Hook_To_kubeadm_Hook(a.(*Hook), b.(*kubeadm.Hook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeadm.Hook)(nil), (*Hook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeadm_Hook_To_v1beta2_Hook(a.(*kubeadm.Hook), b.(*Hook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HostPathMount)(nil), (*kubeadm.HostPathMount)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_HostPathMount_To_kubeadm_HostPathMount(a.(*HostPathMount), b.(*kubeadm.HostPathMount), scope)
 	}); err != nil {
@@ -237,6 +247,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Proxy)(nil), (*kubeadm.Proxy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_Proxy_To_kubeadm_Proxy(a.(*Proxy), b.(*kubeadm.Proxy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeadm.Proxy)(nil), (*Proxy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeadm_Proxy_To_v1beta2_Proxy(a.(*kubeadm.Proxy), b.(*Proxy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*kubeadm.InitConfiguration)(nil), (*InitConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_kubeadm_InitConfiguration_To_v1beta2_InitConfiguration(a.(*kubeadm.InitConfiguration), b.(*InitConfiguration), scope)
 	}); err != nil {
@@ -278,6 +298,9 @@ func autoConvert_v1beta2_APIServer_To_kubeadm_APIServer(in *APIServer, out *kube
 	}
 	out.CertSANs = *(*[]string)(unsafe.Pointer(&in.CertSANs))
 	out.TimeoutForControlPlane = (*v1.Duration)(unsafe.Pointer(in.TimeoutForControlPlane))
+	out.EncryptionAtRest = (*kubeadm.EncryptionAtRest)(unsafe.Pointer(in.EncryptionAtRest))
+	out.Admission = (*kubeadm.AdmissionConfiguration)(unsafe.Pointer(in.Admission))
+	out.Audit = (*kubeadm.AuditConfiguration)(unsafe.Pointer(in.Audit))
 	return nil
 }
 
@@ -292,6 +315,9 @@ func autoConvert_kubeadm_APIServer_To_v1beta2_APIServer(in *kubeadm.APIServer, o
 	}
 	out.CertSANs = *(*[]string)(unsafe.Pointer(&in.CertSANs))
 	out.TimeoutForControlPlane = (*v1.Duration)(unsafe.Pointer(in.TimeoutForControlPlane))
+	out.EncryptionAtRest = (*EncryptionAtRest)(unsafe.Pointer(in.EncryptionAtRest))
+	out.Admission = (*AdmissionConfiguration)(unsafe.Pointer(in.Admission))
+	out.Audit = (*AuditConfiguration)(unsafe.Pointer(in.Audit))
 	return nil
 }
 
@@ -307,6 +333,8 @@ func autoConvert_v1beta2_BootstrapToken_To_kubeadm_BootstrapToken(in *BootstrapT
 	out.Expires = (*v1.Time)(unsafe.Pointer(in.Expires))
 	out.Usages = *(*[]string)(unsafe.Pointer(&in.Usages))
 	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	out.SingleUse = in.SingleUse
+	out.AllowedCIDRs = *(*[]string)(unsafe.Pointer(&in.AllowedCIDRs))
 	return nil
 }
 
@@ -322,6 +350,8 @@ func autoConvert_kubeadm_BootstrapToken_To_v1beta2_BootstrapToken(in *kubeadm.Bo
 	out.Expires = (*v1.Time)(unsafe.Pointer(in.Expires))
 	out.Usages = *(*[]string)(unsafe.Pointer(&in.Usages))
 	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	out.SingleUse = in.SingleUse
+	out.AllowedCIDRs = *(*[]string)(unsafe.Pointer(&in.AllowedCIDRs))
 	return nil
 }
 
@@ -399,11 +429,24 @@ func autoConvert_v1beta2_ClusterConfiguration_To_kubeadm_ClusterConfiguration(in
 	if err := Convert_v1beta2_DNS_To_kubeadm_DNS(&in.DNS, &out.DNS, s); err != nil {
 		return err
 	}
+	out.Proxy = (*kubeadm.Proxy)(unsafe.Pointer(in.Proxy))
+	out.PauseImage = (*kubeadm.ImageMeta)(unsafe.Pointer(in.PauseImage))
+	out.ExtraRBAC = *(*[]string)(unsafe.Pointer(&in.ExtraRBAC))
+	out.ImagePull = (*kubeadm.ImagePullConfiguration)(unsafe.Pointer(in.ImagePull))
+	out.ControlPlaneEndpointProvider = (*kubeadm.ControlPlaneEndpointProvider)(unsafe.Pointer(in.ControlPlaneEndpointProvider))
+	out.Konnectivity = (*kubeadm.Konnectivity)(unsafe.Pointer(in.Konnectivity))
+	out.HTTPProxy = (*kubeadm.HTTPProxyConfiguration)(unsafe.Pointer(in.HTTPProxy))
+	out.TLS = (*kubeadm.TLSConfiguration)(unsafe.Pointer(in.TLS))
+	out.PatchesConfigMap = in.PatchesConfigMap
+	out.CertificateKeyTTL = (*v1.Duration)(unsafe.Pointer(in.CertificateKeyTTL))
 	out.CertificatesDir = in.CertificatesDir
 	out.ImageRepository = in.ImageRepository
 	out.UseHyperKubeImage = in.UseHyperKubeImage
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	out.ClusterName = in.ClusterName
+	out.ExternallyManagedAddons = *(*[]string)(unsafe.Pointer(&in.ExternallyManagedAddons))
+	out.ControlPlaneMarking = (*kubeadm.ControlPlaneMarking)(unsafe.Pointer(in.ControlPlaneMarking))
+	out.Timeouts = (*kubeadm.Timeouts)(unsafe.Pointer(in.Timeouts))
 	return nil
 }
 
@@ -434,12 +477,25 @@ func autoConvert_kubeadm_ClusterConfiguration_To_v1beta2_ClusterConfiguration(in
 	if err := Convert_kubeadm_DNS_To_v1beta2_DNS(&in.DNS, &out.DNS, s); err != nil {
 		return err
 	}
+	out.Proxy = (*Proxy)(unsafe.Pointer(in.Proxy))
+	out.PauseImage = (*ImageMeta)(unsafe.Pointer(in.PauseImage))
+	out.ExtraRBAC = *(*[]string)(unsafe.Pointer(&in.ExtraRBAC))
+	out.ImagePull = (*ImagePullConfiguration)(unsafe.Pointer(in.ImagePull))
+	out.ControlPlaneEndpointProvider = (*ControlPlaneEndpointProvider)(unsafe.Pointer(in.ControlPlaneEndpointProvider))
+	out.Konnectivity = (*Konnectivity)(unsafe.Pointer(in.Konnectivity))
+	out.HTTPProxy = (*HTTPProxyConfiguration)(unsafe.Pointer(in.HTTPProxy))
+	out.TLS = (*TLSConfiguration)(unsafe.Pointer(in.TLS))
+	out.PatchesConfigMap = in.PatchesConfigMap
+	out.CertificateKeyTTL = (*v1.Duration)(unsafe.Pointer(in.CertificateKeyTTL))
 	out.CertificatesDir = in.CertificatesDir
 	out.ImageRepository = in.ImageRepository
 	// INFO: in.CIImageRepository opted out of conversion generation
 	out.UseHyperKubeImage = in.UseHyperKubeImage
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	out.ClusterName = in.ClusterName
+	out.ExternallyManagedAddons = *(*[]string)(unsafe.Pointer(&in.ExternallyManagedAddons))
+	out.ControlPlaneMarking = (*ControlPlaneMarking)(unsafe.Pointer(in.ControlPlaneMarking))
+	out.Timeouts = (*Timeouts)(unsafe.Pointer(in.Timeouts))
 	return nil
 }
 
@@ -471,6 +527,11 @@ func Convert_kubeadm_ClusterStatus_To_v1beta2_ClusterStatus(in *kubeadm.ClusterS
 func autoConvert_v1beta2_ControlPlaneComponent_To_kubeadm_ControlPlaneComponent(in *ControlPlaneComponent, out *kubeadm.ControlPlaneComponent, s conversion.Scope) error {
 	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
 	out.ExtraVolumes = *(*[]kubeadm.HostPathMount)(unsafe.Pointer(&in.ExtraVolumes))
+	if err := Convert_v1beta2_ImageMeta_To_kubeadm_ImageMeta(&in.ImageMeta, &out.ImageMeta, s); err != nil {
+		return err
+	}
+	out.Resources = (*corev1.ResourceRequirements)(unsafe.Pointer(in.Resources))
+	out.SecurityContext = (*corev1.SecurityContext)(unsafe.Pointer(in.SecurityContext))
 	return nil
 }
 
@@ -482,6 +543,11 @@ func Convert_v1beta2_ControlPlaneComponent_To_kubeadm_ControlPlaneComponent(in *
 func autoConvert_kubeadm_ControlPlaneComponent_To_v1beta2_ControlPlaneComponent(in *kubeadm.ControlPlaneComponent, out *ControlPlaneComponent, s conversion.Scope) error {
 	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
 	out.ExtraVolumes = *(*[]HostPathMount)(unsafe.Pointer(&in.ExtraVolumes))
+	if err := Convert_kubeadm_ImageMeta_To_v1beta2_ImageMeta(&in.ImageMeta, &out.ImageMeta, s); err != nil {
+		return err
+	}
+	out.Resources = (*corev1.ResourceRequirements)(unsafe.Pointer(in.Resources))
+	out.SecurityContext = (*corev1.SecurityContext)(unsafe.Pointer(in.SecurityContext))
 	return nil
 }
 
@@ -616,6 +682,7 @@ func autoConvert_v1beta2_HostPathMount_To_kubeadm_HostPathMount(in *HostPathMoun
 	out.MountPath = in.MountPath
 	out.ReadOnly = in.ReadOnly
 	out.PathType = corev1.HostPathType(in.PathType)
+	out.Propagation = corev1.MountPropagationMode(in.Propagation)
 	return nil
 }
 
@@ -630,6 +697,7 @@ func autoConvert_kubeadm_HostPathMount_To_v1beta2_HostPathMount(in *kubeadm.Host
 	out.MountPath = in.MountPath
 	out.ReadOnly = in.ReadOnly
 	out.PathType = corev1.HostPathType(in.PathType)
+	out.Propagation = corev1.MountPropagationMode(in.Propagation)
 	return nil
 }
 
@@ -660,6 +728,32 @@ func Convert_kubeadm_ImageMeta_To_v1beta2_ImageMeta(in *kubeadm.ImageMeta, out *
 	return autoConvert_kubeadm_ImageMeta_To_v1beta2_ImageMeta(in, out, s)
 }
 
+func autoConvert_v1beta2_Hook_To_kubeadm_Hook(in *Hook, out *kubeadm.Hook, s conversion.Scope) error {
+	out.Phase = in.Phase
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.FailurePolicy = in.FailurePolicy
+	return nil
+}
+
+// Convert_v1beta2_Hook_To_kubeadm_Hook is an autogenerated conversion function.
+func Convert_v1beta2_Hook_To_kubeadm_Hook(in *Hook, out *kubeadm.Hook, s conversion.Scope) error {
+	return autoConvert_v1beta2_Hook_To_kubeadm_Hook(in, out, s)
+}
+
+func autoConvert_kubeadm_Hook_To_v1beta2_Hook(in *kubeadm.Hook, out *Hook, s conversion.Scope) error {
+	out.Phase = in.Phase
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.FailurePolicy = in.FailurePolicy
+	return nil
+}
+
+// Convert_kubeadm_Hook_To_v1beta2_Hook is an autogenerated conversion function.
+func Convert_kubeadm_Hook_To_v1beta2_Hook(in *kubeadm.Hook, out *Hook, s conversion.Scope) error {
+	return autoConvert_kubeadm_Hook_To_v1beta2_Hook(in, out, s)
+}
+
 func autoConvert_v1beta2_InitConfiguration_To_kubeadm_InitConfiguration(in *InitConfiguration, out *kubeadm.InitConfiguration, s conversion.Scope) error {
 	out.BootstrapTokens = *(*[]kubeadm.BootstrapToken)(unsafe.Pointer(&in.BootstrapTokens))
 	if err := Convert_v1beta2_NodeRegistrationOptions_To_kubeadm_NodeRegistrationOptions(&in.NodeRegistration, &out.NodeRegistration, s); err != nil {
@@ -669,6 +763,7 @@ func autoConvert_v1beta2_InitConfiguration_To_kubeadm_InitConfiguration(in *Init
 		return err
 	}
 	out.CertificateKey = in.CertificateKey
+	out.Hooks = *(*[]kubeadm.Hook)(unsafe.Pointer(&in.Hooks))
 	return nil
 }
 
@@ -682,6 +777,7 @@ func autoConvert_kubeadm_InitConfiguration_To_v1beta2_InitConfiguration(in *kube
 		return err
 	}
 	out.CertificateKey = in.CertificateKey
+	out.Hooks = *(*[]Hook)(unsafe.Pointer(&in.Hooks))
 	return nil
 }
 
@@ -694,6 +790,7 @@ func autoConvert_v1beta2_JoinConfiguration_To_kubeadm_JoinConfiguration(in *Join
 		return err
 	}
 	out.ControlPlane = (*kubeadm.JoinControlPlane)(unsafe.Pointer(in.ControlPlane))
+	out.Hooks = *(*[]kubeadm.Hook)(unsafe.Pointer(&in.Hooks))
 	return nil
 }
 
@@ -711,6 +808,7 @@ func autoConvert_kubeadm_JoinConfiguration_To_v1beta2_JoinConfiguration(in *kube
 		return err
 	}
 	out.ControlPlane = (*JoinControlPlane)(unsafe.Pointer(in.ControlPlane))
+	out.Hooks = *(*[]Hook)(unsafe.Pointer(&in.Hooks))
 	return nil
 }
 
@@ -753,6 +851,11 @@ func autoConvert_v1beta2_LocalEtcd_To_kubeadm_LocalEtcd(in *LocalEtcd, out *kube
 	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
 	out.ServerCertSANs = *(*[]string)(unsafe.Pointer(&in.ServerCertSANs))
 	out.PeerCertSANs = *(*[]string)(unsafe.Pointer(&in.PeerCertSANs))
+	out.QuotaBackendBytes = (*int64)(unsafe.Pointer(in.QuotaBackendBytes))
+	out.HeartbeatInterval = (*v1.Duration)(unsafe.Pointer(in.HeartbeatInterval))
+	out.ElectionTimeout = (*v1.Duration)(unsafe.Pointer(in.ElectionTimeout))
+	out.AutoCompactionRetention = in.AutoCompactionRetention
+	out.Resources = (*corev1.ResourceRequirements)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -769,6 +872,11 @@ func autoConvert_kubeadm_LocalEtcd_To_v1beta2_LocalEtcd(in *kubeadm.LocalEtcd, o
 	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
 	out.ServerCertSANs = *(*[]string)(unsafe.Pointer(&in.ServerCertSANs))
 	out.PeerCertSANs = *(*[]string)(unsafe.Pointer(&in.PeerCertSANs))
+	out.QuotaBackendBytes = (*int64)(unsafe.Pointer(in.QuotaBackendBytes))
+	out.HeartbeatInterval = (*v1.Duration)(unsafe.Pointer(in.HeartbeatInterval))
+	out.ElectionTimeout = (*v1.Duration)(unsafe.Pointer(in.ElectionTimeout))
+	out.AutoCompactionRetention = in.AutoCompactionRetention
+	out.Resources = (*corev1.ResourceRequirements)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -807,6 +915,7 @@ func autoConvert_v1beta2_NodeRegistrationOptions_To_kubeadm_NodeRegistrationOpti
 	out.Taints = *(*[]corev1.Taint)(unsafe.Pointer(&in.Taints))
 	out.KubeletExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.KubeletExtraArgs))
 	out.IgnorePreflightErrors = *(*[]string)(unsafe.Pointer(&in.IgnorePreflightErrors))
+	out.NodeNamePolicy = in.NodeNamePolicy
 	return nil
 }
 
@@ -821,6 +930,7 @@ func autoConvert_kubeadm_NodeRegistrationOptions_To_v1beta2_NodeRegistrationOpti
 	out.Taints = *(*[]corev1.Taint)(unsafe.Pointer(&in.Taints))
 	out.KubeletExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.KubeletExtraArgs))
 	out.IgnorePreflightErrors = *(*[]string)(unsafe.Pointer(&in.IgnorePreflightErrors))
+	out.NodeNamePolicy = in.NodeNamePolicy
 	return nil
 }
 
@@ -828,3 +938,29 @@ func autoConvert_kubeadm_NodeRegistrationOptions_To_v1beta2_NodeRegistrationOpti
 func Convert_kubeadm_NodeRegistrationOptions_To_v1beta2_NodeRegistrationOptions(in *kubeadm.NodeRegistrationOptions, out *NodeRegistrationOptions, s conversion.Scope) error {
 	return autoConvert_kubeadm_NodeRegistrationOptions_To_v1beta2_NodeRegistrationOptions(in, out, s)
 }
+
+func autoConvert_v1beta2_Proxy_To_kubeadm_Proxy(in *Proxy, out *kubeadm.Proxy, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	if err := Convert_v1beta2_ImageMeta_To_kubeadm_ImageMeta(&in.ImageMeta, &out.ImageMeta, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta2_Proxy_To_kubeadm_Proxy is an autogenerated conversion function.
+func Convert_v1beta2_Proxy_To_kubeadm_Proxy(in *Proxy, out *kubeadm.Proxy, s conversion.Scope) error {
+	return autoConvert_v1beta2_Proxy_To_kubeadm_Proxy(in, out, s)
+}
+
+func autoConvert_kubeadm_Proxy_To_v1beta2_Proxy(in *kubeadm.Proxy, out *Proxy, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	if err := Convert_kubeadm_ImageMeta_To_v1beta2_ImageMeta(&in.ImageMeta, &out.ImageMeta, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_kubeadm_Proxy_To_v1beta2_Proxy is an autogenerated conversion function.
+func Convert_kubeadm_Proxy_To_v1beta2_Proxy(in *kubeadm.Proxy, out *Proxy, s conversion.Scope) error {
+	return autoConvert_kubeadm_Proxy_To_v1beta2_Proxy(in, out, s)
+}
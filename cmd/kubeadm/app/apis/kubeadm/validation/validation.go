@@ -27,6 +27,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	unversionedvalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -38,7 +40,11 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/componentconfigs"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/features"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/admission"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/extrarbac"
+	hooksphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/hooks"
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/flagvalidation"
 	utilnet "k8s.io/utils/net"
 )
 
@@ -48,8 +54,9 @@ func ValidateInitConfiguration(c *kubeadm.InitConfiguration) field.ErrorList {
 	allErrs = append(allErrs, ValidateNodeRegistrationOptions(&c.NodeRegistration, field.NewPath("nodeRegistration"))...)
 	allErrs = append(allErrs, ValidateBootstrapTokens(c.BootstrapTokens, field.NewPath("bootstrapTokens"))...)
 	allErrs = append(allErrs, ValidateClusterConfiguration(&c.ClusterConfiguration)...)
-	// TODO(Arvinderpal): update advertiseAddress validation for dual-stack once it's implemented.
 	allErrs = append(allErrs, ValidateAPIEndpoint(&c.LocalAPIEndpoint, field.NewPath("localAPIEndpoint"))...)
+	allErrs = append(allErrs, ValidateAdvertiseAddressFamily(&c.LocalAPIEndpoint, &c.ClusterConfiguration.Networking, field.NewPath("localAPIEndpoint"))...)
+	allErrs = append(allErrs, ValidateHooks(c.Hooks, []string{hooksphase.PreInit, hooksphase.PostInit}, field.NewPath("hooks"))...)
 	// TODO: Maybe validate that .CertificateKey is a valid hex encoded AES key
 	return allErrs
 }
@@ -63,7 +70,104 @@ func ValidateClusterConfiguration(c *kubeadm.ClusterConfiguration) field.ErrorLi
 	allErrs = append(allErrs, ValidateFeatureGates(c.FeatureGates, field.NewPath("featureGates"))...)
 	allErrs = append(allErrs, ValidateHostPort(c.ControlPlaneEndpoint, field.NewPath("controlPlaneEndpoint"))...)
 	allErrs = append(allErrs, ValidateEtcd(&c.Etcd, field.NewPath("etcd"))...)
+	if c.TLS != nil {
+		validVersions := map[string]bool{"": true, "VersionTLS10": true, "VersionTLS11": true, "VersionTLS12": true, "VersionTLS13": true}
+		if !validVersions[c.TLS.MinVersion] {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("tls", "minVersion"), c.TLS.MinVersion, "must be one of VersionTLS10, VersionTLS11, VersionTLS12 or VersionTLS13"))
+		}
+		for i, suite := range c.TLS.CipherSuites {
+			if !strings.HasPrefix(suite, "TLS_") {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("tls", "cipherSuites").Index(i), suite, "cipher suites must use their IANA names, e.g. TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"))
+			}
+		}
+	}
+	if c.HTTPProxy != nil {
+		for _, subnet := range []string{c.Networking.ServiceSubnet, c.Networking.PodSubnet} {
+			if subnet == "" {
+				continue
+			}
+			found := false
+			for _, entry := range c.HTTPProxy.NoProxy {
+				if entry == subnet {
+					found = true
+					break
+				}
+			}
+			if !found {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("httpProxy", "noProxy"), strings.Join(c.HTTPProxy.NoProxy, ","),
+					fmt.Sprintf("the subnet %q must be part of noProxy, or in-cluster traffic would be sent through the proxy", subnet)))
+			}
+		}
+	}
+	if c.Konnectivity != nil {
+		if len(c.Konnectivity.ServerImage) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("konnectivity", "serverImage"), "the konnectivity-server image is required"))
+		}
+		if len(c.Konnectivity.AgentImage) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("konnectivity", "agentImage"), "the konnectivity-agent image is required"))
+		}
+	}
+	if c.ControlPlaneEndpointProvider != nil {
+		if c.ControlPlaneEndpointProvider.Type != "kube-vip" {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("controlPlaneEndpointProvider", "type"), c.ControlPlaneEndpointProvider.Type, "the only supported provider type is \"kube-vip\""))
+		}
+		if len(c.ControlPlaneEndpoint) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("controlPlaneEndpoint"), "a control plane endpoint provider requires controlPlaneEndpoint to be set"))
+		}
+		if len(c.ControlPlaneEndpointProvider.Interface) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("controlPlaneEndpointProvider", "interface"), "the network interface to announce the virtual IP on is required"))
+		}
+	}
 	allErrs = append(allErrs, componentconfigs.Validate(c)...)
+	if c.ControlPlaneMarking != nil {
+		allErrs = append(allErrs, unversionedvalidation.ValidateLabels(c.ControlPlaneMarking.Labels, field.NewPath("controlPlaneMarking", "labels"))...)
+	}
+	for i, addon := range c.ExternallyManagedAddons {
+		if addon != constants.CoreDNSDeploymentName && addon != constants.KubeProxy {
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("externallyManagedAddons").Index(i), addon, []string{constants.CoreDNSDeploymentName, constants.KubeProxy}))
+		}
+	}
+	if err := extrarbac.ValidateExtraRBAC(c.ExtraRBAC); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("extraRBAC"), "", err.Error()))
+	}
+	for _, component := range []struct {
+		name      string
+		fldPath   *field.Path
+		extraArgs map[string]string
+	}{
+		{constants.KubeAPIServer, field.NewPath("apiServer", "extraArgs"), c.APIServer.ExtraArgs},
+		{constants.KubeControllerManager, field.NewPath("controllerManager", "extraArgs"), c.ControllerManager.ExtraArgs},
+		{constants.KubeScheduler, field.NewPath("scheduler", "extraArgs"), c.Scheduler.ExtraArgs},
+	} {
+		for _, err := range flagvalidation.ValidateExtraArgs(component.name, component.extraArgs) {
+			allErrs = append(allErrs, field.Invalid(component.fldPath, "", err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// protectedMountPaths are paths the control plane components depend on; extra volumes
+// must not shadow them
+var protectedMountPaths = []string{"/etc/kubernetes", "/etc/kubernetes/pki"}
+
+// ValidateExtraVolumes validates the extra host path mounts of a control plane component
+func ValidateExtraVolumes(volumes []kubeadm.HostPathMount, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, volume := range volumes {
+		volPath := fldPath.Index(i)
+		allErrs = append(allErrs, ValidateAbsolutePath(volume.HostPath, volPath.Child("hostPath"))...)
+		allErrs = append(allErrs, ValidateAbsolutePath(volume.MountPath, volPath.Child("mountPath"))...)
+		switch volume.Propagation {
+		case "", corev1.MountPropagationNone, corev1.MountPropagationHostToContainer, corev1.MountPropagationBidirectional:
+		default:
+			allErrs = append(allErrs, field.Invalid(volPath.Child("propagation"), volume.Propagation, "must be one of None, HostToContainer or Bidirectional"))
+		}
+		for _, protected := range protectedMountPaths {
+			if volume.MountPath == protected {
+				allErrs = append(allErrs, field.Invalid(volPath.Child("mountPath"), volume.MountPath, "the mount would shadow a path required by the control plane"))
+			}
+		}
+	}
 	return allErrs
 }
 
@@ -71,6 +175,34 @@ func ValidateClusterConfiguration(c *kubeadm.ClusterConfiguration) field.ErrorLi
 func ValidateAPIServer(a *kubeadm.APIServer, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, ValidateCertSANs(a.CertSANs, fldPath.Child("certSANs"))...)
+	allErrs = append(allErrs, ValidateExtraVolumes(a.ExtraVolumes, fldPath.Child("extraVolumes"))...)
+	if err := admission.ValidatePlugins(a.Admission); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("admission"), "", err.Error()))
+	}
+	if a.Audit != nil {
+		if a.Audit.PolicyContent != "" && a.Audit.PolicyFile != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("audit"), "", "policyContent and policyFile are mutually exclusive"))
+		}
+		if a.Audit.PolicyContent == "" && a.Audit.PolicyFile == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("audit"), "either policyContent or policyFile must be set"))
+		}
+		if a.Audit.LogDir == "" && a.Audit.WebhookConfigFile == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("audit"), "at least one backend (logDir or webhookConfigFile) must be set"))
+		}
+	}
+	if a.EncryptionAtRest != nil {
+		validProviders := []string{"aescbc", "aesgcm", "secretbox"}
+		valid := false
+		for _, provider := range validProviders {
+			if a.EncryptionAtRest.Provider == provider {
+				valid = true
+			}
+		}
+		if !valid {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("encryptionAtRest", "provider"), a.EncryptionAtRest.Provider,
+				fmt.Sprintf("the encryption provider must be one of %v", validProviders)))
+		}
+	}
 	return allErrs
 }
 
@@ -81,12 +213,45 @@ func ValidateJoinConfiguration(c *kubeadm.JoinConfiguration) field.ErrorList {
 	allErrs = append(allErrs, ValidateNodeRegistrationOptions(&c.NodeRegistration, field.NewPath("nodeRegistration"))...)
 	allErrs = append(allErrs, ValidateJoinControlPlane(c.ControlPlane, field.NewPath("controlPlane"))...)
 
+	allErrs = append(allErrs, ValidateHooks(c.Hooks, []string{hooksphase.PreJoin, hooksphase.PostJoin}, field.NewPath("hooks"))...)
+
 	if !filepath.IsAbs(c.CACertPath) || !strings.HasSuffix(c.CACertPath, ".crt") {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("caCertPath"), c.CACertPath, "the ca certificate path must be an absolute path"))
 	}
 	return allErrs
 }
 
+// ValidateHooks validates the declared hooks and that they bind to a phase of the
+// workflow they are part of
+func ValidateHooks(hooks []kubeadm.Hook, allowedPhases []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, hook := range hooks {
+		idxPath := fldPath.Index(i)
+		allowed := false
+		for _, phase := range allowedPhases {
+			if hook.Phase == phase {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("phase"), hook.Phase, allowedPhases))
+		}
+		if len(hook.Command) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("command"), "a hook must declare the command to run"))
+		}
+		switch hook.FailurePolicy {
+		case "", hooksphase.FailurePolicyAbort, hooksphase.FailurePolicyIgnore:
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("failurePolicy"), hook.FailurePolicy, []string{hooksphase.FailurePolicyAbort, hooksphase.FailurePolicyIgnore}))
+		}
+		if hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("timeout"), hook.Timeout.Duration.String(), "the timeout must be positive"))
+		}
+	}
+	return allErrs
+}
+
 // ValidateJoinControlPlane validates joining control plane configuration and collects all encountered errors
 func ValidateJoinControlPlane(c *kubeadm.JoinControlPlane, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -100,6 +265,9 @@ func ValidateJoinControlPlane(c *kubeadm.JoinControlPlane, fldPath *field.Path)
 // ValidateNodeRegistrationOptions validates the NodeRegistrationOptions object
 func ValidateNodeRegistrationOptions(nro *kubeadm.NodeRegistrationOptions, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
+	if !kubeadmutil.ValidNodeNamePolicy(nro.NodeNamePolicy) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeNamePolicy"), nro.NodeNamePolicy, "must be \"hostname\", \"fqdn\", \"cloud-metadata\" or a valid \"template:<go-template>\""))
+	}
 	if len(nro.Name) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath, "--node-name or .nodeRegistration.name in the config file is a required value. It seems like this value couldn't be automatically detected in your environment, please specify the desired value using the CLI or config file."))
 	} else {
@@ -205,6 +373,10 @@ func ValidateBootstrapTokens(bts []kubeadm.BootstrapToken, fldPath *field.Path)
 	allErrs := field.ErrorList{}
 	for i, bt := range bts {
 		btPath := fldPath.Child(fmt.Sprintf("%d", i))
+		if bt.Token == nil {
+			allErrs = append(allErrs, field.Required(btPath.Child(kubeadmcmdoptions.TokenStr), "token is required"))
+			continue
+		}
 		allErrs = append(allErrs, ValidateToken(bt.Token.String(), btPath.Child(kubeadmcmdoptions.TokenStr))...)
 		allErrs = append(allErrs, ValidateTokenUsages(bt.Usages, btPath.Child(kubeadmcmdoptions.TokenUsages))...)
 		allErrs = append(allErrs, ValidateTokenGroups(bt.Usages, bt.Groups, btPath.Child(kubeadmcmdoptions.TokenGroups))...)
@@ -212,6 +384,12 @@ func ValidateBootstrapTokens(bts []kubeadm.BootstrapToken, fldPath *field.Path)
 		if bt.Expires != nil && bt.TTL != nil {
 			allErrs = append(allErrs, field.Invalid(btPath, "", "the BootstrapToken .TTL and .Expires fields are mutually exclusive"))
 		}
+
+		for _, cidr := range bt.AllowedCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				allErrs = append(allErrs, field.Invalid(btPath.Child("allowedCIDRs"), cidr, "couldn't parse subnet"))
+			}
+		}
 	}
 	return allErrs
 }
@@ -278,6 +456,18 @@ func ValidateEtcd(e *kubeadm.Etcd, fldPath *field.Path) field.ErrorList {
 		allErrs = append(allErrs, ValidateAbsolutePath(e.Local.DataDir, localPath.Child("dataDir"))...)
 		allErrs = append(allErrs, ValidateCertSANs(e.Local.ServerCertSANs, localPath.Child("serverCertSANs"))...)
 		allErrs = append(allErrs, ValidateCertSANs(e.Local.PeerCertSANs, localPath.Child("peerCertSANs"))...)
+		if e.Local.QuotaBackendBytes != nil && *e.Local.QuotaBackendBytes < 0 {
+			allErrs = append(allErrs, field.Invalid(localPath.Child("quotaBackendBytes"), *e.Local.QuotaBackendBytes, "the storage quota must not be negative"))
+		}
+		if e.Local.HeartbeatInterval != nil && e.Local.HeartbeatInterval.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(localPath.Child("heartbeatInterval"), e.Local.HeartbeatInterval.Duration.String(), "the heartbeat interval must be positive"))
+		}
+		if e.Local.ElectionTimeout != nil && e.Local.ElectionTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(localPath.Child("electionTimeout"), e.Local.ElectionTimeout.Duration.String(), "the election timeout must be positive"))
+		}
+		if e.Local.HeartbeatInterval != nil && e.Local.ElectionTimeout != nil && e.Local.ElectionTimeout.Duration <= e.Local.HeartbeatInterval.Duration {
+			allErrs = append(allErrs, field.Invalid(localPath.Child("electionTimeout"), e.Local.ElectionTimeout.Duration.String(), "the election timeout must be larger than the heartbeat interval"))
+		}
 	}
 	if e.External != nil {
 		requireHTTPS := true
@@ -420,6 +610,37 @@ func ValidateNetworking(c *kubeadm.ClusterConfiguration, fldPath *field.Path) fi
 	if len(c.Networking.PodSubnet) != 0 {
 		allErrs = append(allErrs, ValidateIPNetFromString(c.Networking.PodSubnet, constants.MinimumAddressesInServiceSubnet, isDualStack, field.NewPath("podSubnet"))...)
 	}
+	// only check family consistency if the subnets parsed so far; otherwise the
+	// errors above are authoritative and this would only add noise
+	if len(allErrs) == 0 && !isDualStack && len(c.Networking.ServiceSubnet) != 0 && len(c.Networking.PodSubnet) != 0 {
+		if utilnet.IsIPv6CIDRString(c.Networking.ServiceSubnet) != utilnet.IsIPv6CIDRString(c.Networking.PodSubnet) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("podSubnet"), c.Networking.PodSubnet,
+				"the pod subnet and the service subnet must be of the same IP family, unless the IPv6DualStack feature gate is enabled"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateAdvertiseAddressFamily validates that the advertise address of an API endpoint
+// belongs to one of the IP families configured in the networking service subnet
+func ValidateAdvertiseAddressFamily(e *kubeadm.APIEndpoint, n *kubeadm.Networking, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	ip := net.ParseIP(e.AdvertiseAddress)
+	// an unparsable or empty advertise address is reported by ValidateAPIEndpoint
+	if ip == nil || len(n.ServiceSubnet) == 0 {
+		return allErrs
+	}
+	for _, subnet := range strings.Split(n.ServiceSubnet, ",") {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			// an invalid service subnet is reported by ValidateNetworking
+			return allErrs
+		}
+		if utilnet.IsIPv6(ip) == utilnet.IsIPv6CIDRString(subnet) {
+			return allErrs
+		}
+	}
+	allErrs = append(allErrs, field.Invalid(fldPath.Child("advertiseAddress"), e.AdvertiseAddress,
+		"the advertise address must match the IP family of one of the configured service subnets"))
 	return allErrs
 }
 
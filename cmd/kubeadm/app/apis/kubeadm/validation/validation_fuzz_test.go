@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+// TestValidationDoesNotPanic feeds randomized configurations into the validators: no
+// input, however malformed, may ever panic validation - it must produce errors instead
+func TestValidationDoesNotPanic(t *testing.T) {
+	fuzzer := fuzz.NewWithSeed(5).NilChance(0.2).NumElements(0, 3).Funcs(
+		// component configs hold interface values gofuzz cannot generate
+		func(obj *kubeadm.ComponentConfigMap, c fuzz.Continue) {},
+	)
+
+	validate := func(name string, seed int, fn func()) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("%s panicked on fuzzed input (iteration %d): %v", name, seed, r)
+			}
+		}()
+		fn()
+	}
+
+	for i := 0; i < 500; i++ {
+		initCfg := &kubeadm.InitConfiguration{}
+		fuzzer.Fuzz(initCfg)
+		validate(fmt.Sprintf("ValidateInitConfiguration"), i, func() {
+			ValidateInitConfiguration(initCfg)
+		})
+
+		joinCfg := &kubeadm.JoinConfiguration{}
+		fuzzer.Fuzz(joinCfg)
+		validate(fmt.Sprintf("ValidateJoinConfiguration"), i, func() {
+			ValidateJoinConfiguration(joinCfg)
+		})
+
+		clusterCfg := &kubeadm.ClusterConfiguration{}
+		fuzzer.Fuzz(clusterCfg)
+		validate(fmt.Sprintf("ValidateClusterConfiguration"), i, func() {
+			ValidateClusterConfiguration(clusterCfg)
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/features"
 )
 
 func TestValidateToken(t *testing.T) {
@@ -362,6 +363,118 @@ func TestValidateAPIEndpoint(t *testing.T) {
 	}
 }
 
+func TestValidateAdvertiseAddressFamily(t *testing.T) {
+	var tests = []struct {
+		name     string
+		e        *kubeadm.APIEndpoint
+		n        *kubeadm.Networking
+		expected bool
+	}{
+		{
+			name:     "IPv4 address and IPv4 service subnet",
+			e:        &kubeadm.APIEndpoint{AdvertiseAddress: "4.5.6.7"},
+			n:        &kubeadm.Networking{ServiceSubnet: "10.96.0.0/12"},
+			expected: true,
+		},
+		{
+			name:     "IPv6 address and IPv6 service subnet",
+			e:        &kubeadm.APIEndpoint{AdvertiseAddress: "2001:db7::2"},
+			n:        &kubeadm.Networking{ServiceSubnet: "2001:db8::/112"},
+			expected: true,
+		},
+		{
+			name:     "IPv4 address and IPv6 service subnet",
+			e:        &kubeadm.APIEndpoint{AdvertiseAddress: "4.5.6.7"},
+			n:        &kubeadm.Networking{ServiceSubnet: "2001:db8::/112"},
+			expected: false,
+		},
+		{
+			name:     "IPv6 address and IPv4 service subnet",
+			e:        &kubeadm.APIEndpoint{AdvertiseAddress: "2001:db7::2"},
+			n:        &kubeadm.Networking{ServiceSubnet: "10.96.0.0/12"},
+			expected: false,
+		},
+		{
+			name:     "IPv6 address and dual-stack service subnet",
+			e:        &kubeadm.APIEndpoint{AdvertiseAddress: "2001:db7::2"},
+			n:        &kubeadm.Networking{ServiceSubnet: "10.96.0.0/12,2001:db8::/112"},
+			expected: true,
+		},
+		{
+			name:     "empty advertise address is not checked here",
+			e:        &kubeadm.APIEndpoint{},
+			n:        &kubeadm.Networking{ServiceSubnet: "10.96.0.0/12"},
+			expected: true,
+		},
+		{
+			name:     "empty service subnet is not checked here",
+			e:        &kubeadm.APIEndpoint{AdvertiseAddress: "2001:db7::2"},
+			n:        &kubeadm.Networking{},
+			expected: true,
+		},
+	}
+	for _, rt := range tests {
+		actual := ValidateAdvertiseAddressFamily(rt.e, rt.n, nil)
+		if (len(actual) == 0) != rt.expected {
+			t.Errorf(
+				"%s test case failed:\n\texpected: %t\n\t  actual: %t",
+				rt.name,
+				rt.expected,
+				(len(actual) == 0),
+			)
+		}
+	}
+}
+
+func TestValidateNetworking(t *testing.T) {
+	var tests = []struct {
+		name     string
+		c        *kubeadm.ClusterConfiguration
+		expected bool
+	}{
+		{
+			name: "both subnets IPv4",
+			c: &kubeadm.ClusterConfiguration{
+				Networking: kubeadm.Networking{DNSDomain: "cluster.local", ServiceSubnet: "10.96.0.0/12", PodSubnet: "10.244.0.0/16"},
+			},
+			expected: true,
+		},
+		{
+			name: "both subnets IPv6",
+			c: &kubeadm.ClusterConfiguration{
+				Networking: kubeadm.Networking{DNSDomain: "cluster.local", ServiceSubnet: "2001:db8::/112", PodSubnet: "2001:db9::/64"},
+			},
+			expected: true,
+		},
+		{
+			name: "mixed families without the dual-stack feature gate",
+			c: &kubeadm.ClusterConfiguration{
+				Networking: kubeadm.Networking{DNSDomain: "cluster.local", ServiceSubnet: "10.96.0.0/12", PodSubnet: "2001:db9::/64"},
+			},
+			expected: false,
+		},
+		{
+			name: "mixed families with the dual-stack feature gate",
+			c: &kubeadm.ClusterConfiguration{
+				FeatureGates: map[string]bool{features.IPv6DualStack: true},
+				Networking:   kubeadm.Networking{DNSDomain: "cluster.local", ServiceSubnet: "10.96.0.0/12,2001:db8::/112", PodSubnet: "10.244.0.0/16,2001:db9::/64"},
+			},
+			expected: true,
+		},
+	}
+	for _, rt := range tests {
+		actual := ValidateNetworking(rt.c, nil)
+		if (len(actual) == 0) != rt.expected {
+			t.Errorf(
+				"%s test case failed:\n\texpected: %t\n\t  actual: %t",
+				rt.name,
+				rt.expected,
+				(len(actual) == 0),
+			)
+		}
+	}
+}
+
 //TODO: Create a separated test for ValidateClusterConfiguration
 func TestValidateInitConfiguration(t *testing.T) {
 	nodename := "valid-nodename"
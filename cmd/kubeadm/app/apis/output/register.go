@@ -47,6 +47,8 @@ func Resource(resource string) schema.GroupResource {
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&BootstrapToken{},
+		&Images{},
+		&JoinCommand{},
 	)
 	return nil
 }
@@ -31,3 +31,37 @@ type BootstrapToken struct {
 
 	kubeadmapiv1beta2.BootstrapToken
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JoinCommand represents the join parameters output produced by
+// 'kubeadm token create --print-join-command'
+type JoinCommand struct {
+	metav1.TypeMeta
+
+	// Token is the bootstrap token used for node authentication
+	Token string
+
+	// APIServerEndpoint is the address of the API server the joining node connects to
+	APIServerEndpoint string
+
+	// CACertHashes holds the public key pins used to verify the cluster CA
+	CACertHashes []string
+
+	// ControlPlane indicates whether the parameters join a control-plane node
+	ControlPlane bool
+
+	// CertificateKey decrypts the uploaded control-plane certificates, set only for
+	// control-plane joins
+	CertificateKey string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Images holds the list of images kubeadm would use, as printed by 'kubeadm config images list'
+type Images struct {
+	metav1.TypeMeta
+
+	// Images holds the image names
+	Images []string
+}
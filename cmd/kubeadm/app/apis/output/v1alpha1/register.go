@@ -59,6 +59,8 @@ func Resource(resource string) schema.GroupResource {
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&BootstrapToken{},
+		&Images{},
+		&JoinCommand{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
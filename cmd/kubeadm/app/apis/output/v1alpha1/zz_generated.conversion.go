@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	unsafe "unsafe"
+
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	output "k8s.io/kubernetes/cmd/kubeadm/app/apis/output"
@@ -43,6 +45,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Images)(nil), (*output.Images)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Images_To_output_Images(a.(*Images), b.(*output.Images), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*output.Images)(nil), (*Images)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_output_Images_To_v1alpha1_Images(a.(*output.Images), b.(*Images), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*JoinCommand)(nil), (*output.JoinCommand)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_JoinCommand_To_output_JoinCommand(a.(*JoinCommand), b.(*output.JoinCommand), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*output.JoinCommand)(nil), (*JoinCommand)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_output_JoinCommand_To_v1alpha1_JoinCommand(a.(*output.JoinCommand), b.(*JoinCommand), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -65,3 +87,51 @@ func autoConvert_output_BootstrapToken_To_v1alpha1_BootstrapToken(in *output.Boo
 func Convert_output_BootstrapToken_To_v1alpha1_BootstrapToken(in *output.BootstrapToken, out *BootstrapToken, s conversion.Scope) error {
 	return autoConvert_output_BootstrapToken_To_v1alpha1_BootstrapToken(in, out, s)
 }
+
+func autoConvert_v1alpha1_JoinCommand_To_output_JoinCommand(in *JoinCommand, out *output.JoinCommand, s conversion.Scope) error {
+	out.Token = in.Token
+	out.APIServerEndpoint = in.APIServerEndpoint
+	out.CACertHashes = *(*[]string)(unsafe.Pointer(&in.CACertHashes))
+	out.ControlPlane = in.ControlPlane
+	out.CertificateKey = in.CertificateKey
+	return nil
+}
+
+// Convert_v1alpha1_JoinCommand_To_output_JoinCommand is an autogenerated conversion function.
+func Convert_v1alpha1_JoinCommand_To_output_JoinCommand(in *JoinCommand, out *output.JoinCommand, s conversion.Scope) error {
+	return autoConvert_v1alpha1_JoinCommand_To_output_JoinCommand(in, out, s)
+}
+
+func autoConvert_output_JoinCommand_To_v1alpha1_JoinCommand(in *output.JoinCommand, out *JoinCommand, s conversion.Scope) error {
+	out.Token = in.Token
+	out.APIServerEndpoint = in.APIServerEndpoint
+	out.CACertHashes = *(*[]string)(unsafe.Pointer(&in.CACertHashes))
+	out.ControlPlane = in.ControlPlane
+	out.CertificateKey = in.CertificateKey
+	return nil
+}
+
+// Convert_output_JoinCommand_To_v1alpha1_JoinCommand is an autogenerated conversion function.
+func Convert_output_JoinCommand_To_v1alpha1_JoinCommand(in *output.JoinCommand, out *JoinCommand, s conversion.Scope) error {
+	return autoConvert_output_JoinCommand_To_v1alpha1_JoinCommand(in, out, s)
+}
+
+func autoConvert_v1alpha1_Images_To_output_Images(in *Images, out *output.Images, s conversion.Scope) error {
+	out.Images = *(*[]string)(unsafe.Pointer(&in.Images))
+	return nil
+}
+
+// Convert_v1alpha1_Images_To_output_Images is an autogenerated conversion function.
+func Convert_v1alpha1_Images_To_output_Images(in *Images, out *output.Images, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Images_To_output_Images(in, out, s)
+}
+
+func autoConvert_output_Images_To_v1alpha1_Images(in *output.Images, out *Images, s conversion.Scope) error {
+	out.Images = *(*[]string)(unsafe.Pointer(&in.Images))
+	return nil
+}
+
+// Convert_output_Images_To_v1alpha1_Images is an autogenerated conversion function.
+func Convert_output_Images_To_v1alpha1_Images(in *output.Images, out *Images, s conversion.Scope) error {
+	return autoConvert_output_Images_To_v1alpha1_Images(in, out, s)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	dnsaddon "k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/dns"
+	proxyaddon "k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/proxy"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+var (
+	addonReconcileLongDesc = cmdutil.LongDesc(`
+		Re-render the kubeadm-managed addons (CoreDNS and kube-proxy) from the configuration
+		stored in the cluster and apply the differences, reverting accidental manual edits
+		to the addon manifests without performing a full upgrade.
+		` + cmdutil.AlphaDisclaimer)
+
+	addonReconcileExample = cmdutil.Examples(`
+		# Reconcile the kubeadm-managed addons from the stored cluster configuration.
+		kubeadm alpha addon reconcile
+
+		# Show what would be changed without applying anything.
+		kubeadm alpha addon reconcile --dry-run
+		`)
+)
+
+// newCmdAddonUtility returns command for `kubeadm alpha addon`
+func newCmdAddonUtility(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "addon",
+		Short: "Commands related to handling the kubeadm-managed addons",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdAddonReconcile(out))
+	return cmd
+}
+
+// newCmdAddonReconcile returns command for `kubeadm alpha addon reconcile`
+func newCmdAddonReconcile(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:     "reconcile",
+		Short:   "Reconcile the kubeadm-managed addons from the configuration stored in the cluster",
+		Long:    addonReconcileLongDesc,
+		Example: addonReconcileExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getAddonClient(kubeconfigFile, dryRun, out)
+			if err != nil {
+				return err
+			}
+			return runAddonReconcile(client, out)
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().BoolVar(&dryRun, options.DryRun, dryRun, "Don't apply any changes; just output the differences that would be applied.")
+	return cmd
+}
+
+// getAddonClient returns a client for talking to the cluster, or a dry-run client
+// that prints the write operations instead of performing them
+func getAddonClient(file string, dryRun bool, out io.Writer) (clientset.Interface, error) {
+	if dryRun {
+		dryRunGetter, err := apiclient.NewClientBackedDryRunGetterFromKubeconfig(file)
+		if err != nil {
+			return nil, err
+		}
+		return apiclient.NewDryRunClient(dryRunGetter, out), nil
+	}
+	return kubeconfigutil.ClientSetFromFile(file)
+}
+
+// runAddonReconcile re-renders the addon manifests from the stored cluster configuration and applies them
+func runAddonReconcile(client clientset.Interface, out io.Writer) error {
+	cfg, err := configutil.FetchInitConfigurationFromCluster(client, out, "addon", false)
+	if err != nil {
+		return err
+	}
+
+	if err := dnsaddon.EnsureDNSAddon(&cfg.ClusterConfiguration, client, ""); err != nil {
+		return err
+	}
+
+	return proxyaddon.EnsureProxyAddon(&cfg.ClusterConfiguration, &cfg.LocalAPIEndpoint, client, "")
+}
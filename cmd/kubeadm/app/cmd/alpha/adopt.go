@@ -0,0 +1,150 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmscheme "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	dnsaddon "k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/dns"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/uploadconfig"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// newCmdAdopt returns the command synthesizing a kubeadm configuration for an existing cluster
+func newCmdAdopt(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var apply bool
+
+	cmd := &cobra.Command{
+		Use:   "adopt",
+		Short: "Inspect a cluster not created by kubeadm and synthesize a kubeadm configuration for it",
+		Long: cmdutil.LongDesc(`
+			Inspect an existing control plane, synthesize a best-effort ClusterConfiguration
+			for it and print a compatibility report of what could be detected and what was
+			defaulted. With --apply the configuration is uploaded as the kubeadm-config
+			ConfigMap, so 'kubeadm upgrade' and 'kubeadm certs' commands can manage the
+			cluster going forward. Review the report carefully before applying.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			return runAdopt(out, client, apply)
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().BoolVar(&apply, "apply", false, "Upload the synthesized configuration as the kubeadm-config ConfigMap.")
+	return cmd
+}
+
+// runAdopt inspects the cluster and synthesizes the configuration
+func runAdopt(out io.Writer, client clientset.Interface, apply bool) error {
+	report := []string{}
+	detected := func(field, value, source string) {
+		report = append(report, fmt.Sprintf("%s: %q (detected from %s)", field, value, source))
+	}
+	defaulted := func(field, value string) {
+		report = append(report, fmt.Sprintf("%s: %q (defaulted; verify manually)", field, value))
+	}
+
+	externalCfg := &kubeadmapiv1beta2.ClusterConfiguration{}
+	kubeadmscheme.Scheme.Default(externalCfg)
+	cfg := &kubeadmapi.InitConfiguration{}
+	if err := kubeadmscheme.Scheme.Convert(externalCfg, &cfg.ClusterConfiguration, nil); err != nil {
+		return err
+	}
+
+	// Kubernetes version from the API server
+	if serverVersion, err := client.Discovery().ServerVersion(); err == nil {
+		cfg.KubernetesVersion = serverVersion.GitVersion
+		detected("kubernetesVersion", cfg.KubernetesVersion, "the API server")
+	} else {
+		defaulted("kubernetesVersion", cfg.KubernetesVersion)
+	}
+
+	// service subnet from the apiserver arguments, when the control plane runs as pods
+	if pods, err := client.CoreV1().Pods(metav1.NamespaceSystem).List(metav1.ListOptions{
+		LabelSelector: "component=kube-apiserver",
+	}); err == nil && len(pods.Items) > 0 {
+		for _, arg := range pods.Items[0].Spec.Containers[0].Command {
+			if strings.HasPrefix(arg, "--service-cluster-ip-range=") {
+				cfg.Networking.ServiceSubnet = strings.TrimPrefix(arg, "--service-cluster-ip-range=")
+				detected("networking.serviceSubnet", cfg.Networking.ServiceSubnet, "the kube-apiserver arguments")
+			}
+		}
+	} else {
+		defaulted("networking.serviceSubnet", cfg.Networking.ServiceSubnet)
+	}
+
+	// pod subnet from the node spec
+	if nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{}); err == nil {
+		for _, node := range nodes.Items {
+			if node.Spec.PodCIDR != "" {
+				cfg.Networking.PodSubnet = node.Spec.PodCIDR
+				detected("networking.podSubnet", cfg.Networking.PodSubnet, "the node podCIDR (first node)")
+				break
+			}
+		}
+	}
+
+	// DNS add-on type from the deployed workloads
+	if dnsType, dnsVersion, err := dnsaddon.DeployedDNSAddon(client); err == nil && dnsType != "" {
+		cfg.DNS.Type = dnsType
+		detected("dns.type", string(dnsType)+" "+dnsVersion, "the deployed DNS workload")
+	} else {
+		defaulted("dns.type", string(cfg.DNS.Type))
+	}
+
+	// assume external etcd management for adopted clusters; a stacked etcd not created
+	// by kubeadm cannot be safely managed without its peer certificates
+	report = append(report, "etcd: managed externally assumed; configure etcd.external endpoints before using etcd dependent commands")
+
+	fmt.Fprintln(out, "[adopt] Compatibility report:")
+	for _, line := range report {
+		fmt.Fprintf(out, "[adopt]   - %s\n", line)
+	}
+
+	b, err := configutil.MarshalKubeadmConfigObject(&cfg.ClusterConfiguration)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\n%s", b)
+
+	if !apply {
+		fmt.Fprintln(out, "[adopt] Re-run with --apply to upload this configuration as the kubeadm-config ConfigMap")
+		return nil
+	}
+
+	if err := uploadconfig.UploadConfiguration(cfg, client); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "[adopt] Uploaded the configuration; kubeadm upgrade and certs commands can now manage this cluster")
+	return nil
+}
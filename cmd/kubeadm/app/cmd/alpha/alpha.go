@@ -30,7 +30,19 @@ func NewCmdAlpha(in io.Reader, out io.Writer) *cobra.Command {
 		Short: "Kubeadm experimental sub-commands",
 	}
 
+	cmd.AddCommand(newCmdAddonUtility(out))
+	cmd.AddCommand(newCmdAdopt(out))
 	cmd.AddCommand(newCmdCertsUtility(out))
+	cmd.AddCommand(newCmdClusterInfoUtility(out))
+	cmd.AddCommand(newCmdEncryptionUtility(out))
+	cmd.AddCommand(newCmdEtcdUtility(out))
+	cmd.AddCommand(newCmdFeatureGatesUtility(out))
+	cmd.AddCommand(newCmdHardeningUtility(out))
+	cmd.AddCommand(newCmdManifestsUtility(out))
+	cmd.AddCommand(newCmdPatchesUtility(out))
+	cmd.AddCommand(newCmdRemoveControlPlaneNode(out))
+	cmd.AddCommand(newCmdDoctor(out))
+	cmd.AddCommand(newCmdTroubleshoot(out))
 	cmd.AddCommand(newCmdKubeletUtility())
 	cmd.AddCommand(newCmdKubeConfigUtility(out))
 	cmd.AddCommand(NewCmdSelfhosting(in))
@@ -17,9 +17,12 @@ limitations under the License.
 package alpha
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"text/tabwriter"
+	"time"
 
 	"github.com/lithammer/dedent"
 	"github.com/pkg/errors"
@@ -35,6 +38,7 @@ import (
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/certs/renewal"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/copycerts"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/nodepreauth"
 	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
 	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
 )
@@ -82,6 +86,200 @@ func newCmdCertsUtility(out io.Writer) *cobra.Command {
 	cmd.AddCommand(newCmdCertsRenewal(out))
 	cmd.AddCommand(newCmdCertsExpiration(out, constants.KubernetesDir))
 	cmd.AddCommand(NewCmdCertificateKey())
+	cmd.AddCommand(newCmdPreauthorizeNode(out))
+	cmd.AddCommand(newCmdApproveNodeCSRs(out))
+	cmd.AddCommand(newCmdUploadedCerts(out))
+	cmd.AddCommand(newCmdCertsReconcileSANs(out))
+	cmd.AddCommand(newCmdApproveKubeletServingCSRs(out))
+	cmd.AddCommand(newCmdRenewKubeletClientCert(out))
+	return cmd
+}
+
+// newCmdUploadedCerts returns the command group for managing the uploaded certificates Secret
+func newCmdUploadedCerts(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uploaded",
+		Short: "Commands related to the certificates uploaded by 'kubeadm init phase upload-certs'",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdUploadedCertsStatus(out))
+	cmd.AddCommand(newCmdUploadedCertsRotate(out))
+	cmd.AddCommand(newCmdUploadedCertsRevoke(out))
+	return cmd
+}
+
+// newCmdUploadedCertsStatus returns the command showing the state of the uploaded certificates
+func newCmdUploadedCertsStatus(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show when the uploaded certificates were stored and when they expire",
+		Long: cmdutil.LongDesc(`
+			Show the creation and expiration time of the Secret holding the uploaded
+			control-plane certificates.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			info, err := copycerts.GetUploadedCertsInfo(client)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				b, err := json.MarshalIndent(info, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(b))
+				return nil
+			}
+
+			fmt.Fprintf(out, "created: %s\n", info.Created.Format(time.RFC3339))
+			if info.Expires != nil {
+				fmt.Fprintf(out, "expires: %s\n", info.Expires.Format(time.RFC3339))
+			} else {
+				fmt.Fprintln(out, "expires: <unknown>")
+			}
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format. One of: text|json.")
+	return cmd
+}
+
+// newCmdUploadedCertsRotate returns the command rotating the uploaded certificates key
+func newCmdUploadedCertsRotate(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Re-upload the control-plane certificates with a freshly generated certificate key",
+		Long: cmdutil.LongDesc(`
+			Revoke the currently uploaded control-plane certificates and upload them again,
+			encrypted with a freshly generated certificate key. The new key is printed and all
+			previously distributed keys stop working immediately.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			cfg, err := configutil.FetchInitConfigurationFromCluster(client, out, "upload-certs", false)
+			if err != nil {
+				return err
+			}
+			key, err := copycerts.RotateCerts(client, cfg)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "[upload-certs] Using certificate key:\n%s\n", key)
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	return cmd
+}
+
+// newCmdUploadedCertsRevoke returns the command revoking the uploaded certificates
+func newCmdUploadedCertsRevoke(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Delete the uploaded control-plane certificates, invalidating all certificate keys",
+		Long: cmdutil.LongDesc(`
+			Delete the Secret holding the uploaded control-plane certificates and the bootstrap
+			token managing its time to live, instead of waiting for the Secret to expire.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			return copycerts.RevokeCerts(client)
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	return cmd
+}
+
+// newCmdPreauthorizeNode returns the command for recording a pre-authorized node identity
+func newCmdPreauthorizeNode(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var nodeName string
+	var publicKeyPath string
+
+	cmd := &cobra.Command{
+		Use:   "preauthorize-node",
+		Short: "Record the identity of a future node, so its kubelet client CSR can be approved without a bootstrap token",
+		Long: cmdutil.LongDesc(`
+			Record the name and public key fingerprint of a future node in the cluster. When the
+			node later joins by submitting a kubelet client CSR signed with the matching key, the
+			CSR can be approved with 'kubeadm alpha certs approve-node-csrs' - no shared bootstrap
+			token needs to be distributed.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if nodeName == "" {
+				return errors.New("--node-name is required")
+			}
+			if publicKeyPath == "" {
+				return errors.New("--public-key is required")
+			}
+			publicKeyPEM, err := ioutil.ReadFile(publicKeyPath)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read the public key file %q", publicKeyPath)
+			}
+
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			return nodepreauth.PreauthorizeNode(client, nodeName, publicKeyPEM)
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&nodeName, options.NodeName, nodeName, "The name the node will register with.")
+	cmd.Flags().StringVar(&publicKeyPath, "public-key", publicKeyPath, "Path to the PEM encoded public key of the node.")
+	return cmd
+}
+
+// newCmdApproveNodeCSRs returns the command for approving CSRs of pre-authorized nodes
+func newCmdApproveNodeCSRs(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+
+	cmd := &cobra.Command{
+		Use:   "approve-node-csrs",
+		Short: "Approve all pending kubelet client CSRs that match a pre-authorized node identity",
+		Long: cmdutil.LongDesc(`
+			Approve all pending kubelet client CSRs whose node name and public key fingerprint
+			match an identity recorded with 'kubeadm alpha certs preauthorize-node'.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			approved, err := nodepreauth.ApproveNodeCSRs(client)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "approved %d certificate signing request(s)\n", approved)
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
 	return cmd
 }
 
@@ -390,3 +588,34 @@ func addExpirationFlags(cmd *cobra.Command, flags *expirationFlags) {
 	options.AddCertificateDirFlag(cmd.Flags(), &flags.cfg.CertificatesDir)
 	options.AddKubeConfigFlag(cmd.Flags(), &flags.kubeconfigPath)
 }
+
+// newCmdApproveKubeletServingCSRs returns the command approving pending kubelet serving CSRs
+func newCmdApproveKubeletServingCSRs(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+
+	cmd := &cobra.Command{
+		Use:   "approve-kubelet-serving-csrs",
+		Short: "Approve all pending kubelet serving certificate CSRs",
+		Long: cmdutil.LongDesc(`
+			Approve the kubelet serving certificate CSRs that accumulate when
+			serverTLSBootstrap is enabled without an approver deployed. Inspect the pending
+			CSRs before running this; every pending serving request from a node user is
+			approved.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			approved, err := nodepreauth.ApproveKubeletServingCSRs(client)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "approved %d kubelet serving certificate signing request(s)\n", approved)
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	return cmd
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstraputil "k8s.io/cluster-bootstrap/token/util"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	clusterinfophase "k8s.io/kubernetes/cmd/kubeadm/app/phases/bootstraptoken/clusterinfo"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// newCmdClusterInfoUtility returns the command group for managing the cluster-info publication
+func newCmdClusterInfoUtility(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster-info",
+		Short: "Commands related to the cluster-info ConfigMap and its signatures",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdClusterInfoRefresh())
+	cmd.AddCommand(newCmdClusterInfoVerify())
+	cmd.AddCommand(newCmdClusterInfoRotateTokens())
+	cmd.AddCommand(newCmdClusterStatus(out))
+	return cmd
+}
+
+// newCmdClusterInfoRefresh returns the command re-publishing the cluster-info ConfigMap
+func newCmdClusterInfoRefresh() *cobra.Command {
+	var kubeconfigFile string
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Re-publish the cluster-info ConfigMap from the admin kubeconfig",
+		Long: cmdutil.LongDesc(`
+			Re-publish the cluster-info ConfigMap in the kube-public namespace from the admin
+			kubeconfig, refreshing the embedded CA bundle and API server endpoint after a CA
+			rotation or an endpoint change. The bootstrap-signer controller re-signs the
+			updated content with the current signing tokens.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeconfigFile = cmdutil.GetKubeConfigPath(kubeconfigFile)
+			client, err := kubeconfigutil.ClientSetFromFile(kubeconfigFile)
+			if err != nil {
+				return err
+			}
+			return clusterinfophase.RefreshBootstrapConfigMap(client, filepath.Join(constants.KubernetesDir, constants.AdminKubeConfigFileName))
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	return cmd
+}
+
+// newCmdClusterInfoRotateTokens returns the command rotating the bootstrap tokens and
+// the cluster-info signatures in one step
+func newCmdClusterInfoRotateTokens() *cobra.Command {
+	var kubeconfigFile string
+
+	cmd := &cobra.Command{
+		Use:   "rotate-tokens",
+		Short: "Invalidate all bootstrap tokens and re-sign the cluster-info ConfigMap with a fresh one",
+		Long: cmdutil.LongDesc(`
+			Rotate the mechanism protecting the cluster-info ConfigMap signatures after a
+			token leakage incident: delete every existing bootstrap token, strip the stale
+			JWS signatures from the published ConfigMap and sign it with a freshly created
+			token, which is printed so it can be handed to nodes that still need to join.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeconfigFile = cmdutil.GetKubeConfigPath(kubeconfigFile)
+			client, err := kubeconfigutil.ClientSetFromFile(kubeconfigFile)
+			if err != nil {
+				return err
+			}
+
+			tokenStr, err := bootstraputil.GenerateBootstrapToken()
+			if err != nil {
+				return errors.Wrap(err, "couldn't generate a new bootstrap token")
+			}
+			bts, err := kubeadmapiv1beta2.NewBootstrapTokenString(tokenStr)
+			if err != nil {
+				return err
+			}
+			newToken := &kubeadmapi.BootstrapToken{
+				Token:  &kubeadmapi.BootstrapTokenString{ID: bts.ID, Secret: bts.Secret},
+				TTL:    &metav1.Duration{Duration: constants.DefaultTokenDuration},
+				Usages: constants.DefaultTokenUsages,
+				Groups: constants.DefaultTokenGroups,
+			}
+
+			if err := clusterinfophase.RotateBootstrapTokenSigning(client, newToken); err != nil {
+				return err
+			}
+			fmt.Printf("[cluster-info] The new bootstrap token is: %s\n", tokenStr)
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	return cmd
+}
+
+// newCmdClusterInfoVerify returns the command verifying the cluster-info signatures
+func newCmdClusterInfoVerify() *cobra.Command {
+	var kubeconfigFile string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the JWS signatures of the cluster-info ConfigMap against the current bootstrap tokens",
+		Long: cmdutil.LongDesc(`
+			Verify that the cluster-info ConfigMap in the kube-public namespace carries a valid
+			JWS signature for every bootstrap token with the signing usage, detecting stale or
+			tampered publications before nodes fail to join.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeconfigFile = cmdutil.GetKubeConfigPath(kubeconfigFile)
+			client, err := kubeconfigutil.ClientSetFromFile(kubeconfigFile)
+			if err != nil {
+				return err
+			}
+			return clusterinfophase.VerifyBootstrapConfigMapSignatures(client)
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	return cmd
+}
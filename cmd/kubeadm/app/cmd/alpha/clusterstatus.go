@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// controlPlaneNodeStatus describes a control-plane node in the cluster status report
+type controlPlaneNodeStatus struct {
+	// Name is the node name
+	Name string `json:"name"`
+
+	// APIEndpoint is the advertise address and port of the node's API server, as
+	// recorded in the kubeadm ClusterStatus
+	APIEndpoint string `json:"apiEndpoint,omitempty"`
+
+	// KubeletVersion is the version the node's kubelet reports
+	KubeletVersion string `json:"kubeletVersion"`
+
+	// Ready reports the node's Ready condition
+	Ready bool `json:"ready"`
+}
+
+// clusterStatus is the machine readable report of 'kubeadm alpha cluster-info status'
+type clusterStatus struct {
+	// APIServerVersion is the version the contacted API server reports
+	APIServerVersion string `json:"apiServerVersion"`
+
+	// ControlPlaneNodes lists the control-plane nodes of the cluster
+	ControlPlaneNodes []controlPlaneNodeStatus `json:"controlPlaneNodes"`
+
+	// EtcdMembers lists the members of the etcd cluster, when reachable
+	EtcdMembers []etcdutil.MemberInfo `json:"etcdMembers,omitempty"`
+
+	// EtcdError describes why the etcd cluster could not be inspected, if so
+	EtcdError string `json:"etcdError,omitempty"`
+}
+
+// newCmdClusterStatus returns the command reporting the HA topology of the cluster
+func newCmdClusterStatus(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var certificatesDir string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the control-plane topology of the cluster: nodes, API endpoints and etcd members",
+		Long: cmdutil.LongDesc(`
+			Assemble a status report of the cluster's control plane from the cluster itself:
+			the control-plane nodes with their API endpoints, readiness and kubelet versions,
+			the API server version and the members of the etcd cluster, with -o json for
+			automation.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+
+			status, err := buildClusterStatus(client, certificatesDir)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				b, err := json.MarshalIndent(status, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(b))
+				return nil
+			}
+
+			fmt.Fprintf(out, "API server version: %s\n\n", status.APIServerVersion)
+			tabw := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+			fmt.Fprintln(tabw, "NODE\tAPI ENDPOINT\tKUBELET\tREADY")
+			for _, node := range status.ControlPlaneNodes {
+				fmt.Fprintf(tabw, "%s\t%s\t%s\t%t\n", node.Name, node.APIEndpoint, node.KubeletVersion, node.Ready)
+			}
+			tabw.Flush()
+
+			if status.EtcdError != "" {
+				fmt.Fprintf(out, "\netcd: %s\n", status.EtcdError)
+				return nil
+			}
+			fmt.Fprintln(out)
+			fmt.Fprintln(tabw, "ETCD MEMBER\tID\tPEER URLS\tLEARNER")
+			for _, member := range status.EtcdMembers {
+				fmt.Fprintf(tabw, "%s\t%x\t%v\t%t\n", member.Name, member.ID, member.PeerURLs, member.IsLearner)
+			}
+			return tabw.Flush()
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the etcd certificates are stored.")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format. One of: text|json.")
+	return cmd
+}
+
+// buildClusterStatus assembles the cluster status report from the cluster
+func buildClusterStatus(client clientset.Interface, certificatesDir string) (*clusterStatus, error) {
+	status := &clusterStatus{}
+
+	serverVersion, err := client.Discovery().ServerVersion()
+	if err == nil {
+		status.APIServerVersion = serverVersion.GitVersion
+	}
+
+	// API endpoints of the control-plane nodes, as recorded by kubeadm
+	apiEndpoints := map[string]string{}
+	if kubeadmClusterStatus, err := configutil.GetClusterStatus(client); err == nil {
+		for nodeName, endpoint := range kubeadmClusterStatus.APIEndpoints {
+			apiEndpoints[nodeName] = fmt.Sprintf("%s:%d", endpoint.AdvertiseAddress, endpoint.BindPort)
+		}
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: constants.LabelNodeRoleMaster,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		nodeStatus := controlPlaneNodeStatus{
+			Name:           node.Name,
+			APIEndpoint:    apiEndpoints[node.Name],
+			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == v1.NodeReady {
+				nodeStatus.Ready = condition.Status == v1.ConditionTrue
+			}
+		}
+		status.ControlPlaneNodes = append(status.ControlPlaneNodes, nodeStatus)
+	}
+
+	// etcd membership, best-effort: requires the etcd client certificates on this node
+	etcdClient, err := etcdutil.NewFromCluster(client, certificatesDir)
+	if err != nil {
+		status.EtcdError = err.Error()
+		return status, nil
+	}
+	members, err := etcdClient.ListMemberInfos()
+	if err != nil {
+		status.EtcdError = err.Error()
+		return status, nil
+	}
+	status.EtcdMembers = members
+
+	return status, nil
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/initsystem"
+	utilruntime "k8s.io/kubernetes/cmd/kubeadm/app/util/runtime"
+	staticpodutil "k8s.io/kubernetes/cmd/kubeadm/app/util/staticpod"
+	utilsexec "k8s.io/utils/exec"
+)
+
+// doctorFinding is the structured result of a single doctor check
+type doctorFinding struct {
+	// Check names the verified aspect
+	Check string `json:"check"`
+
+	// Status is "ok", "failed" or "skipped"
+	Status string `json:"status"`
+
+	// Detail explains the finding
+	Detail string `json:"detail,omitempty"`
+
+	// Remediation suggests how to fix the finding
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// newCmdDoctor returns the command diagnosing a misbehaving node
+func newCmdDoctor(out io.Writer) *cobra.Command {
+	var outputFormat string
+	var criSocket string
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Run a decision tree of checks against this node and print likely root causes",
+		Long: cmdutil.LongDesc(`
+			Run a decision tree of diagnostic checks against a misbehaving node: is the
+			kubelet running, is the container runtime responsive, do the static pod manifests
+			parse, are the certificates valid, is the API server reachable? Likely root
+			causes and remediation steps are printed, with -o json for automation.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			findings := runDoctor(criSocket)
+
+			if outputFormat == "json" {
+				b, err := json.MarshalIndent(findings, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(b))
+			} else {
+				for _, finding := range findings {
+					fmt.Fprintf(out, "[doctor] %s: %s", finding.Check, finding.Status)
+					if finding.Detail != "" {
+						fmt.Fprintf(out, " (%s)", finding.Detail)
+					}
+					fmt.Fprintln(out)
+					if finding.Remediation != "" {
+						fmt.Fprintf(out, "[doctor]   remediation: %s\n", finding.Remediation)
+					}
+				}
+			}
+
+			for _, finding := range findings {
+				if finding.Status == "failed" {
+					return errors.New("the node has problems; see the findings above")
+				}
+			}
+			fmt.Fprintln(out, "[doctor] No problems found")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format. One of: text|json.")
+	cmdutil.AddCRISocketFlag(cmd.Flags(), &criSocket)
+	return cmd
+}
+
+// runDoctor executes the decision tree of checks
+func runDoctor(criSocket string) []doctorFinding {
+	findings := []doctorFinding{}
+
+	// 1. is the kubelet service running?
+	kubeletFinding := doctorFinding{Check: "kubelet-running", Status: "ok"}
+	if initSystem, err := initsystem.GetInitSystem(); err != nil {
+		kubeletFinding.Status = "skipped"
+		kubeletFinding.Detail = err.Error()
+	} else if !initSystem.ServiceIsActive("kubelet") {
+		kubeletFinding.Status = "failed"
+		kubeletFinding.Detail = "the kubelet service is not active"
+		kubeletFinding.Remediation = "start the kubelet and inspect its logs, e.g. 'systemctl start kubelet' and 'journalctl -xeu kubelet'"
+	}
+	findings = append(findings, kubeletFinding)
+
+	// 2. is the container runtime responsive?
+	criFinding := doctorFinding{Check: "container-runtime", Status: "ok"}
+	if criSocket == "" {
+		criSocket = constants.DefaultDockerCRISocket
+	}
+	if containerRuntime, err := utilruntime.NewContainerRuntime(utilsexec.New(), criSocket); err != nil {
+		criFinding.Status = "failed"
+		criFinding.Detail = err.Error()
+		criFinding.Remediation = "install the container runtime CLI tooling (crictl or docker)"
+	} else if err := containerRuntime.IsRunning(); err != nil {
+		criFinding.Status = "failed"
+		criFinding.Detail = err.Error()
+		criFinding.Remediation = "make sure the container runtime is running and its socket is accessible"
+	}
+	findings = append(findings, criFinding)
+
+	// 3. do the static pod manifests parse?
+	manifestDir := constants.GetStaticPodDirectory()
+	manifests, err := ioutil.ReadDir(manifestDir)
+	if err != nil {
+		findings = append(findings, doctorFinding{
+			Check:  "static-pod-manifests",
+			Status: "skipped",
+			Detail: fmt.Sprintf("cannot read %q; this does not look like a control-plane node", manifestDir),
+		})
+	} else {
+		for _, manifest := range manifests {
+			if manifest.IsDir() || !strings.HasSuffix(manifest.Name(), ".yaml") {
+				continue
+			}
+			manifestFinding := doctorFinding{Check: "manifest-" + strings.TrimSuffix(manifest.Name(), ".yaml"), Status: "ok"}
+			if _, err := staticpodutil.ReadStaticPodFromDisk(filepath.Join(manifestDir, manifest.Name())); err != nil {
+				manifestFinding.Status = "failed"
+				manifestFinding.Detail = err.Error()
+				manifestFinding.Remediation = "the manifest does not parse; restore it from a backup or regenerate it with 'kubeadm init phase control-plane'"
+			}
+			findings = append(findings, manifestFinding)
+		}
+	}
+
+	// 4. are the certificates valid?
+	findings = append(findings, checkCertificates(kubeadmapiv1beta2.DefaultCertificatesDir))
+
+	// 5. is the API server reachable?
+	findings = append(findings, checkAPIServer())
+
+	return findings
+}
+
+// checkCertificates verifies no certificate below the PKI directory is expired
+func checkCertificates(pkiDir string) doctorFinding {
+	finding := doctorFinding{Check: "certificates", Status: "ok"}
+
+	expired := []string{}
+	err := filepath.Walk(pkiDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".crt") {
+			return nil
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		block, _ := pem.Decode(contents)
+		if block == nil {
+			return nil
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil
+		}
+		if time.Now().After(cert.NotAfter) {
+			expired = append(expired, strings.TrimPrefix(path, pkiDir+"/"))
+		}
+		return nil
+	})
+	if err != nil {
+		finding.Status = "skipped"
+		finding.Detail = err.Error()
+		return finding
+	}
+
+	if len(expired) > 0 {
+		finding.Status = "failed"
+		finding.Detail = fmt.Sprintf("expired certificates: %s", strings.Join(expired, ", "))
+		finding.Remediation = "renew the certificates with 'kubeadm alpha certs renew all' and restart the control plane"
+	}
+	return finding
+}
+
+// checkAPIServer probes the local API server health endpoint
+func checkAPIServer() doctorFinding {
+	finding := doctorFinding{Check: "apiserver-reachable", Status: "ok"}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	url := fmt.Sprintf("https://127.0.0.1:%d/healthz", kubeadmapiv1beta2.DefaultAPIBindPort)
+	resp, err := client.Get(url)
+	if err != nil {
+		finding.Status = "failed"
+		finding.Detail = err.Error()
+		finding.Remediation = "the API server does not answer on this node; check the kubelet and the kube-apiserver container logs"
+		return finding
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		finding.Status = "failed"
+		finding.Detail = fmt.Sprintf("%s returned status %d", url, resp.StatusCode)
+		finding.Remediation = "the API server is unhealthy; check its logs and the health of etcd"
+	}
+	return finding
+}
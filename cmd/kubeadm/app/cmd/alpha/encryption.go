@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/encryption"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// newCmdEncryptionUtility returns the command group for managing encryption of API data at rest
+func newCmdEncryptionUtility(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encryption",
+		Short: "Commands related to encryption of API data at rest",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdEncryptionRotateKey())
+	cmd.AddCommand(newCmdEncryptionReEncrypt())
+	return cmd
+}
+
+// newCmdEncryptionRotateKey returns the command rotating the encryption key
+func newCmdEncryptionRotateKey() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Prepend a fresh key to the encryption configuration of the kube-apiserver",
+		Long: cmdutil.LongDesc(`
+			Generate a fresh encryption key and prepend it to the key list in the encryption
+			configuration, so new data is encrypted with the new key while old keys remain
+			available for decryption. Restart the kube-apiserver and run
+			'kubeadm alpha encryption reencrypt' afterwards to complete the rotation.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return encryption.RotateEncryptionKey()
+		},
+	}
+}
+
+// newCmdEncryptionReEncrypt returns the command re-encrypting the stored Secrets
+func newCmdEncryptionReEncrypt() *cobra.Command {
+	var kubeconfigFile string
+
+	cmd := &cobra.Command{
+		Use:   "reencrypt",
+		Short: "Rewrite all Secrets in the cluster so they are encrypted with the current key",
+		Long: cmdutil.LongDesc(`
+			Rewrite every Secret stored in the cluster, so all of them get encrypted with the
+			newest key in the encryption configuration. Run this after rotating the encryption
+			key and restarting the kube-apiserver.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			return encryption.ReEncryptSecrets(client)
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	return cmd
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// newCmdEtcdUtility returns the command group for managing the local/stacked etcd cluster
+func newCmdEtcdUtility(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "etcd",
+		Short: "Commands related to managing the etcd cluster backing kubeadm clusters",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdEtcdSnapshot(out))
+	cmd.AddCommand(newCmdEtcdMember(out))
+	cmd.AddCommand(newCmdEtcdDefragment(out))
+	cmd.AddCommand(newCmdEtcdRepair(out))
+	return cmd
+}
+
+// newCmdEtcdRepair returns the command recovering from stale learner members
+func newCmdEtcdRepair(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var certificatesDir string
+
+	cmd := &cobra.Command{
+		Use:   "repair",
+		Short: "Remove stale learner members that block adding new members to the etcd cluster",
+		Long: cmdutil.LongDesc(`
+			Detect and remove etcd learner members that were added by an interrupted
+			control-plane join and never started. Such stale learners can never be promoted
+			and prevent the cluster from accepting new members.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			etcdClient, err := etcdClientFromFlags(kubeconfigFile, certificatesDir)
+			if err != nil {
+				return err
+			}
+			removed, err := etcdClient.RemoveStaleLearners()
+			if err != nil {
+				return err
+			}
+			if len(removed) == 0 {
+				fmt.Fprintln(out, "[etcd] No stale learner members found")
+				return nil
+			}
+			for _, member := range removed {
+				fmt.Fprintf(out, "[etcd] Removed stale learner member %x\n", member.ID)
+			}
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the etcd certificates are stored.")
+	return cmd
+}
+
+// newCmdEtcdDefragment returns the command running etcd maintenance
+func newCmdEtcdDefragment(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var certificatesDir string
+	var threshold int64
+
+	cmd := &cobra.Command{
+		Use:   "defragment",
+		Short: "Compact the etcd key space and defragment the members of the etcd cluster",
+		Long: cmdutil.LongDesc(`
+			Compact the etcd key space up to the current revision and perform a rolling
+			defragmentation of all members whose database size is at least the given
+			threshold, verifying cluster health between members. Large databases are a
+			common cause of failed upgrades due to etcd space alarms.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			etcdClient, err := etcdClientFromFlags(kubeconfigFile, certificatesDir)
+			if err != nil {
+				return err
+			}
+			return etcdClient.CompactAndDefragment(threshold)
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the etcd certificates are stored.")
+	cmd.Flags().Int64Var(&threshold, "threshold", 100*1024*1024, "Only defragment members whose database is at least this size in bytes. Pass 0 to defragment unconditionally.")
+	return cmd
+}
+
+// newCmdEtcdMember returns the command group for etcd member management
+func newCmdEtcdMember(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "member",
+		Short: "Manage the members of the etcd cluster backing the cluster",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdEtcdMemberList(out))
+	cmd.AddCommand(newCmdEtcdMemberRemove(out))
+	cmd.AddCommand(newCmdEtcdMemberPromote(out))
+	return cmd
+}
+
+// etcdClientFromFlags builds an etcd client from the common kubeconfig/cert-dir flags
+func etcdClientFromFlags(kubeconfigFile, certificatesDir string) (*etcdutil.Client, error) {
+	client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+	if err != nil {
+		return nil, err
+	}
+	return etcdutil.NewFromCluster(client, certificatesDir)
+}
+
+// newCmdEtcdMemberList returns the command listing the etcd members
+func newCmdEtcdMemberList(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var certificatesDir string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the members of the etcd cluster, including learners",
+		Long: cmdutil.LongDesc(`
+			List the members of the etcd cluster managed by kubeadm, including their member
+			IDs, peer and client addresses and learner state, so broken quorum can be
+			diagnosed without installing etcdctl and copying certificates around.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			etcdClient, err := etcdClientFromFlags(kubeconfigFile, certificatesDir)
+			if err != nil {
+				return err
+			}
+			members, err := etcdClient.ListMemberInfos()
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				b, err := json.MarshalIndent(members, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(b))
+				return nil
+			}
+
+			tabw := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+			fmt.Fprintln(tabw, "ID\tNAME\tPEER URLS\tCLIENT URLS\tLEARNER")
+			for _, member := range members {
+				fmt.Fprintf(tabw, "%x\t%s\t%s\t%s\t%t\n", member.ID, member.Name, strings.Join(member.PeerURLs, ","), strings.Join(member.ClientURLs, ","), member.IsLearner)
+			}
+			return tabw.Flush()
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the etcd certificates are stored.")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format. One of: text|json.")
+	return cmd
+}
+
+// newCmdEtcdMemberRemove returns the command removing an etcd member
+func newCmdEtcdMemberRemove(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var certificatesDir string
+
+	cmd := &cobra.Command{
+		Use:   "remove [member-id]",
+		Short: "Remove a member from the etcd cluster by its hexadecimal member ID",
+		Long: cmdutil.LongDesc(`
+			Remove a member from the etcd cluster managed by kubeadm, e.g. a dead member left
+			behind after the loss of a control-plane node that blocks the quorum.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing the hexadecimal ID of the member to remove")
+			}
+			id, err := strconv.ParseUint(args[0], 16, 64)
+			if err != nil {
+				return errors.Wrapf(err, "%q is not a valid hexadecimal member ID", args[0])
+			}
+
+			etcdClient, err := etcdClientFromFlags(kubeconfigFile, certificatesDir)
+			if err != nil {
+				return err
+			}
+			if _, err := etcdClient.RemoveMember(id); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "[etcd] Removed member %x from the etcd cluster\n", id)
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the etcd certificates are stored.")
+	return cmd
+}
+
+// newCmdEtcdMemberPromote returns the command promoting an etcd learner member
+func newCmdEtcdMemberPromote(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var certificatesDir string
+
+	cmd := &cobra.Command{
+		Use:   "promote [member-id]",
+		Short: "Promote a learner member of the etcd cluster to a voting member",
+		Long: cmdutil.LongDesc(`
+			Promote a raft learner member to a voting member by its hexadecimal member ID,
+			e.g. when a joining control-plane node was interrupted before the promotion
+			happened automatically.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing the hexadecimal ID of the member to promote")
+			}
+			id, err := strconv.ParseUint(args[0], 16, 64)
+			if err != nil {
+				return errors.Wrapf(err, "%q is not a valid hexadecimal member ID", args[0])
+			}
+
+			etcdClient, err := etcdClientFromFlags(kubeconfigFile, certificatesDir)
+			if err != nil {
+				return err
+			}
+			if err := etcdClient.PromoteMember(id); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "[etcd] Promoted member %x to a voting member\n", id)
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the etcd certificates are stored.")
+	return cmd
+}
+
+// newCmdEtcdSnapshot returns the command group for etcd snapshot handling
+func newCmdEtcdSnapshot(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save and restore snapshots of the etcd database backing the cluster",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdEtcdSnapshotSave(out))
+	cmd.AddCommand(newCmdEtcdSnapshotRestore(out))
+	return cmd
+}
+
+// newCmdEtcdSnapshotSave returns the command for saving an etcd snapshot
+func newCmdEtcdSnapshotSave(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var certificatesDir string
+
+	cmd := &cobra.Command{
+		Use:   "save [snapshot-file]",
+		Short: "Save a verified snapshot of the etcd database backing the cluster",
+		Long: cmdutil.LongDesc(`
+			Connect to the etcd cluster managed by kubeadm using the etcd client certificates
+			from the kubeadm certificates directory, stream a snapshot of the database to the
+			given file and verify its integrity hash - without requiring etcdctl or manual TLS
+			flag handling.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing the path to store the snapshot at")
+			}
+
+			kubeconfigFile = cmdutil.GetKubeConfigPath(kubeconfigFile)
+			client, err := kubeconfigutil.ClientSetFromFile(kubeconfigFile)
+			if err != nil {
+				return err
+			}
+
+			etcdClient, err := etcdutil.NewFromCluster(client, certificatesDir)
+			if err != nil {
+				return err
+			}
+
+			if err := etcdClient.SaveSnapshot(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "[etcd] Saved a verified snapshot to %q\n", args[0])
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the etcd certificates are stored.")
+	return cmd
+}
+
+// newCmdEtcdSnapshotRestore returns the command for restoring an etcd snapshot
+func newCmdEtcdSnapshotRestore(out io.Writer) *cobra.Command {
+	var dataDir string
+
+	cmd := &cobra.Command{
+		Use:   "restore [snapshot-file]",
+		Short: "Restore a snapshot of the etcd database into a fresh data directory",
+		Long: cmdutil.LongDesc(`
+			Verify the integrity of the given snapshot file and restore it into a fresh etcd
+			data directory. The etcd static pod must be stopped (e.g. by moving its manifest
+			out of the manifests directory) before restoring, and must afterwards be started
+			once with --force-new-cluster so the member metadata embedded in the snapshot is
+			rewritten for the recovered cluster.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing the path of the snapshot to restore")
+			}
+
+			if err := etcdutil.RestoreSnapshot(args[0], dataDir); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(out, "[etcd] Restored the snapshot %q into %q\n", args[0], dataDir)
+			fmt.Fprintln(out, "[etcd] Start etcd once with --force-new-cluster to rewrite the member metadata, then remove the flag again")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dataDir, "data-dir", kubeadmapiv1beta2.DefaultEtcdDataDir, "The etcd data directory to restore the snapshot into. Must be empty or absent.")
+	return cmd
+}
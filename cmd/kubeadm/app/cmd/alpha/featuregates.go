@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/features"
+	controlplanephase "k8s.io/kubernetes/cmd/kubeadm/app/phases/controlplane"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/uploadconfig"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// newCmdFeatureGatesUtility returns the command group for managing feature gates of a running cluster
+func newCmdFeatureGatesUtility(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "feature-gates",
+		Short: "Commands related to changing feature gates of a running cluster",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdFeatureGatesSet(out))
+	return cmd
+}
+
+// newCmdFeatureGatesSet returns the command changing feature gates on a running cluster
+func newCmdFeatureGatesSet(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "set [gates]",
+		Short: "Change kubeadm feature gates on a running cluster and roll out the affected manifests",
+		Long: cmdutil.LongDesc(`
+			Update the feature gates stored in the kubeadm-config ConfigMap, regenerate the
+			control-plane static pod manifests on this node, show the resulting diff and write
+			the new manifests, so a gate can be changed without hand-editing the ConfigMap and
+			manually re-running phases. Run 'kubeadm upgrade node' on the other control-plane
+			nodes to roll the change out there.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing the feature gates to set, e.g. 'IPv6DualStack=true'")
+			}
+
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+
+			cfg, err := configutil.FetchInitConfigurationFromCluster(client, out, "feature-gates", false)
+			if err != nil {
+				return err
+			}
+
+			requestedGates, err := features.NewFeatureGate(&features.InitFeatureGates, args[0])
+			if err != nil {
+				return err
+			}
+			if cfg.FeatureGates == nil {
+				cfg.FeatureGates = map[string]bool{}
+			}
+			for gate, enabled := range requestedGates {
+				cfg.FeatureGates[gate] = enabled
+			}
+			if err := features.ValidateVersion(features.InitFeatureGates, cfg.FeatureGates, cfg.KubernetesVersion); err != nil {
+				return err
+			}
+
+			// regenerate the manifests into a temporary directory and diff them against
+			// the ones currently on disk
+			tmpDir, err := ioutil.TempDir("", "kubeadm-feature-gates")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmpDir)
+			if err := controlplanephase.CreateInitStaticPodManifestFiles(tmpDir, "", cfg); err != nil {
+				return err
+			}
+
+			changed := false
+			for _, component := range kubeadmconstants.ControlPlaneComponents {
+				currentPath := kubeadmconstants.GetStaticPodFilepath(component, kubeadmconstants.GetStaticPodDirectory())
+				newPath := kubeadmconstants.GetStaticPodFilepath(component, tmpDir)
+				diff, err := diffManifests(currentPath, newPath)
+				if err != nil {
+					return err
+				}
+				if diff == "" {
+					continue
+				}
+				changed = true
+				fmt.Fprintf(out, "[feature-gates] Changes for %q:\n%s\n", component, diff)
+			}
+			if !changed {
+				fmt.Fprintln(out, "[feature-gates] The manifests on this node are unchanged")
+			}
+
+			if dryRun {
+				fmt.Fprintln(out, "[feature-gates] Dry-run: not updating the cluster configuration or the manifests")
+				return nil
+			}
+
+			if err := uploadconfig.UploadConfiguration(cfg, client); err != nil {
+				return err
+			}
+			if changed {
+				if err := controlplanephase.CreateInitStaticPodManifestFiles(kubeadmconstants.GetStaticPodDirectory(), "", cfg); err != nil {
+					return err
+				}
+				fmt.Fprintln(out, "[feature-gates] Wrote the updated manifests; the kubelet will restart the affected components")
+			}
+			fmt.Fprintln(out, "[feature-gates] Run 'kubeadm upgrade node' on the other control-plane nodes to apply the change there")
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().BoolVar(&dryRun, options.DryRun, dryRun, "Only show the configuration and manifest changes that would be made.")
+	return cmd
+}
+
+// diffManifests returns a unified diff between the manifests at the two paths
+func diffManifests(currentPath, newPath string) (string, error) {
+	currentBytes, err := ioutil.ReadFile(currentPath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	newBytes, err := ioutil.ReadFile(newPath)
+	if err != nil {
+		return "", err
+	}
+	if string(currentBytes) == string(newBytes) {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(currentBytes)),
+		B:        difflib.SplitLines(string(newBytes)),
+		FromFile: currentPath,
+		ToFile:   newPath,
+		Context:  3,
+	})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/hardening"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+)
+
+// newCmdHardeningUtility returns the command group for CIS benchmark hardening
+func newCmdHardeningUtility(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hardening",
+		Short: "Commands related to the CIS Kubernetes Benchmark hardening profile",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdHardeningProfile(out))
+	cmd.AddCommand(newCmdHardeningAudit(out))
+	return cmd
+}
+
+// newCmdHardeningProfile returns the command applying the CIS profile to a configuration
+func newCmdHardeningProfile(out io.Writer) *cobra.Command {
+	var cfgPath string
+
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Apply the CIS hardening profile to a kubeadm configuration and print the result",
+		Long: cmdutil.LongDesc(`
+			Apply the flag based controls of the CIS Kubernetes Benchmark to the given
+			kubeadm configuration (disabled profiling, AlwaysPullImages admission, service
+			account lookup, terminated pod garbage collection) without overriding values set
+			explicitly, and print the hardened configuration for use with
+			'kubeadm init --config'.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfgPath == "" {
+				return errors.New("--config is required")
+			}
+			internalcfg, err := configutil.LoadInitConfigurationFromFile(cfgPath)
+			if err != nil {
+				return err
+			}
+
+			hardening.ApplyCISProfile(&internalcfg.ClusterConfiguration)
+
+			b, err := configutil.MarshalKubeadmConfigObject(&internalcfg.ClusterConfiguration)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s", b)
+			return nil
+		},
+	}
+
+	options.AddConfigFlag(cmd.Flags(), &cfgPath)
+	return cmd
+}
+
+// newCmdHardeningAudit returns the command auditing this node against the CIS file controls
+func newCmdHardeningAudit(out io.Writer) *cobra.Command {
+	var certificatesDir string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit this node against the file related CIS Kubernetes Benchmark controls",
+		Long: cmdutil.LongDesc(`
+			Verify the file permissions of the static Pod manifests, the kubeconfig files
+			and the PKI private keys on this node against the CIS Kubernetes Benchmark,
+			reporting one structured finding per control (with -o json for automation).
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			findings := hardening.AuditNode(certificatesDir)
+
+			if outputFormat == "json" {
+				b, err := json.MarshalIndent(findings, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(b))
+			} else {
+				for _, finding := range findings {
+					fmt.Fprintf(out, "[audit] %s: %s", finding.Status, finding.Control)
+					if finding.Detail != "" {
+						fmt.Fprintf(out, " (%s)", finding.Detail)
+					}
+					fmt.Fprintln(out)
+				}
+			}
+
+			for _, finding := range findings {
+				if finding.Status == "fail" {
+					return errors.New("the node does not satisfy all audited controls")
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the PKI certificates are stored.")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format. One of: text|json.")
+	return cmd
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	certsapi "k8s.io/api/certificates/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	csrutil "k8s.io/client-go/util/certificate/csr"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	kubeletphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/kubelet"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// newCmdRenewKubeletClientCert returns the command rotating the kubelet client certificate
+func newCmdRenewKubeletClientCert(out io.Writer) *cobra.Command {
+	var nodeName string
+
+	cmd := &cobra.Command{
+		Use:   "renew-kubelet-client",
+		Short: "Rotate this node's kubelet client certificate when automatic rotation is broken",
+		Long: cmdutil.LongDesc(`
+			Request a fresh kubelet client certificate for this node via the certificates
+			API and point the kubelet credentials at it. The request is submitted with the
+			node's current kubelet credentials, falling back to the bootstrap kubeconfig
+			when they no longer authenticate - so a node whose automatic rotation broke or
+			was disabled can be recovered without a full re-join.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if nodeName == "" {
+				var err error
+				nodeName, err = kubeadmutil.GetHostname("")
+				if err != nil {
+					return err
+				}
+			}
+			return renewKubeletClientCert(out, nodeName)
+		},
+	}
+
+	cmd.Flags().StringVar(&nodeName, options.NodeName, nodeName, "The name this node is registered with. Defaults to the hostname.")
+	return cmd
+}
+
+// kubeletClientFromKubeconfigs builds a client from the kubelet credentials, falling
+// back to the bootstrap kubeconfig when they no longer authenticate
+func kubeletClientFromKubeconfigs(out io.Writer) (clientset.Interface, error) {
+	kubeletKubeConfig := filepath.Join(constants.KubernetesDir, constants.KubeletKubeConfigFileName)
+	client, err := kubeconfigutil.ClientSetFromFile(kubeletKubeConfig)
+	if err == nil {
+		if _, err := client.Discovery().ServerVersion(); err == nil {
+			return client, nil
+		}
+	}
+
+	fmt.Fprintln(out, "[renew-kubelet-client] The kubelet credentials no longer authenticate; falling back to the bootstrap kubeconfig")
+	bootstrapKubeConfig := filepath.Join(constants.KubernetesDir, constants.KubeletBootstrapKubeConfigFileName)
+	client, err = kubeconfigutil.ClientSetFromFile(bootstrapKubeConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "neither the kubelet credentials nor the bootstrap kubeconfig are usable")
+	}
+	return client, nil
+}
+
+// renewKubeletClientCert performs the rotation
+func renewKubeletClientCert(out io.Writer, nodeName string) error {
+	client, err := kubeletClientFromKubeconfigs(out)
+	if err != nil {
+		return err
+	}
+
+	// generate a fresh key and a kubelet client CSR for this node
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate a private key")
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the private key")
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: keyutil.ECPrivateKeyBlockType, Bytes: keyDER})
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   constants.NodesUserPrefix + nodeName,
+			Organization: []string{constants.NodesGroup},
+		},
+	}, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the certificate request")
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	req, err := client.CertificatesV1beta1().CertificateSigningRequests().Create(&certsapi.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "kubeadm-kubelet-client-",
+		},
+		Spec: certsapi.CertificateSigningRequestSpec{
+			Request: csrPEM,
+			Usages:  []certsapi.KeyUsage{certsapi.UsageDigitalSignature, certsapi.UsageKeyEncipherment, certsapi.UsageClientAuth},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the certificate signing request")
+	}
+	fmt.Fprintf(out, "[renew-kubelet-client] Created CSR %q; waiting up to 5m0s for it to be approved and issued\n", req.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	certPEM, err := csrutil.WaitForCertificate(ctx, client.CertificatesV1beta1().CertificateSigningRequests(), req)
+	if err != nil {
+		return errors.Wrap(err, "the certificate was not issued; approve the CSR and retry")
+	}
+
+	// store the issued certificate together with its key where the kubelet rotation
+	// machinery keeps its credentials and refresh the current symlink
+	pkiDir := filepath.Join(constants.KubeletRunDirectory, "pki")
+	if err := os.MkdirAll(pkiDir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory %q", pkiDir)
+	}
+	certPath := filepath.Join(pkiDir, fmt.Sprintf("kubelet-client-%s.pem", time.Now().Format("2006-01-02-15-04-05")))
+	if err := ioutil.WriteFile(certPath, append(certPEM, keyPEM...), 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the renewed credentials to %q", certPath)
+	}
+	currentPath := filepath.Join(pkiDir, "kubelet-client-current.pem")
+	if err := os.Remove(currentPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove the previous %q", currentPath)
+	}
+	if err := os.Symlink(certPath, currentPath); err != nil {
+		return errors.Wrapf(err, "failed to point %q at the renewed credentials", currentPath)
+	}
+
+	fmt.Fprintf(out, "[renew-kubelet-client] Wrote the renewed credentials to %q\n", certPath)
+	kubeletphase.TryRestartKubelet()
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/manifesthash"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// newCmdManifestsUtility returns the command group for static pod manifest handling
+func newCmdManifestsUtility(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifests",
+		Short: "Commands related to the control-plane static Pod manifests",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdManifestsVerifyHashes(out))
+	return cmd
+}
+
+// newCmdManifestsVerifyHashes returns the command verifying the manifest hashes
+func newCmdManifestsVerifyHashes(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var nodeName string
+
+	cmd := &cobra.Command{
+		Use:   "verify-hashes",
+		Short: "Verify that the static Pod manifests on this node match the hashes stored for it",
+		Long: cmdutil.LongDesc(`
+			Compare the control-plane static Pod manifests on disk with the expected hashes
+			kubeadm stored as annotations on this node, detecting manual tampering or
+			partially applied upgrades.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+
+			if nodeName == "" {
+				nodeName, err = kubeadmutil.GetHostname("")
+				if err != nil {
+					return err
+				}
+			}
+
+			mismatches, err := manifesthash.VerifyManifestHashes(client, nodeName, constants.GetStaticPodDirectory())
+			if err != nil {
+				return err
+			}
+
+			if len(mismatches) == 0 {
+				fmt.Fprintln(out, "[manifest-hash] All manifests match the stored hashes")
+				return nil
+			}
+			for _, mismatch := range mismatches {
+				current := mismatch.CurrentHash
+				if current == "" {
+					current = "<missing>"
+				}
+				fmt.Fprintf(out, "[manifest-hash] %s: expected %s, found %s\n", mismatch.Component, mismatch.ExpectedHash, current)
+			}
+			return errors.New("one or more manifests do not match the stored hashes")
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&nodeName, options.NodeName, nodeName, "The name of the node to verify. Defaults to the hostname.")
+	return cmd
+}
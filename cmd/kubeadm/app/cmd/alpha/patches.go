@@ -0,0 +1,107 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/kustomize"
+)
+
+// newCmdPatchesUtility returns the command group for working with patches directories
+func newCmdPatchesUtility(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "patches",
+		Short: "Commands related to user supplied patches",
+		Long:  cmdutil.MacroCommandLongDescription,
+	}
+
+	cmd.AddCommand(newCmdPatchesValidate(out))
+	return cmd
+}
+
+// newCmdPatchesValidate returns the command validating a patches directory
+func newCmdPatchesValidate(out io.Writer) *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "validate [patches-directory]",
+		Short: "Validate the patches in a directory without applying them",
+		Long: cmdutil.LongDesc(`
+			Parse every patch in the given directory and report files that cannot be parsed,
+			patches whose target is not among the objects kubeadm patches, and pairs of
+			patches modifying the same fields - problems that today only surface in the
+			middle of init or upgrade.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing the path of the patches directory to validate")
+			}
+
+			diagnostics, err := kustomize.ValidatePatchesDir(args[0], knownPatchTargets())
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				b, err := json.MarshalIndent(diagnostics, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(b))
+			} else {
+				if len(diagnostics) == 0 {
+					fmt.Fprintln(out, "[patches] No problems found")
+				}
+				for _, diagnostic := range diagnostics {
+					fmt.Fprintf(out, "[patches] %s: %s: %s\n", diagnostic.Severity, diagnostic.File, diagnostic.Message)
+				}
+			}
+
+			for _, diagnostic := range diagnostics {
+				if diagnostic.Severity == kustomize.DiagnosticSeverityError {
+					return errors.New("the patches directory contains invalid patches")
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format. One of: text|json.")
+	return cmd
+}
+
+// knownPatchTargets returns the kind/name pairs of the objects kubeadm patches
+func knownPatchTargets() map[string]bool {
+	return map[string]bool{
+		"Pod/" + constants.KubeAPIServer:            true,
+		"Pod/" + constants.KubeControllerManager:    true,
+		"Pod/" + constants.KubeScheduler:            true,
+		"Pod/" + constants.Etcd:                     true,
+		"Deployment/coredns":                        true,
+		"Service/kube-dns":                          true,
+		"DaemonSet/" + constants.KubeProxy:          true,
+		"ConfigMap/" + constants.KubeProxyConfigMap: true,
+		"KubeletConfiguration/":                     true,
+	}
+}
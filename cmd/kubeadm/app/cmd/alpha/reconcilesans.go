@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	certsphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/certs"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/uploadconfig"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+	staticpodutil "k8s.io/kubernetes/cmd/kubeadm/app/util/staticpod"
+)
+
+// sansUpdatedAnnotation forces the kubelet to restart the API server after the serving
+// certificate was regenerated
+const sansUpdatedAnnotation = "kubeadm.kubernetes.io/sans-reconciled-at"
+
+// newCmdCertsReconcileSANs returns the command regenerating the API server serving
+// certificate for an updated SAN list
+func newCmdCertsReconcileSANs(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var extraSANs []string
+
+	cmd := &cobra.Command{
+		Use:   "reconcile-sans",
+		Short: "Regenerate the API server serving certificate for the SAN list in the cluster configuration",
+		Long: cmdutil.LongDesc(`
+			Regenerate the serving certificate of the API server on this node from the
+			certSANs stored in the cluster configuration, optionally appending additional
+			SANs first (which are persisted for the other control-plane nodes), and restart
+			the API server to pick the certificate up. Run the command on every
+			control-plane node. Today changing SANs requires manually deleting certificates
+			and re-running phases per node.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+
+			cfg, err := configutil.FetchInitConfigurationFromCluster(client, out, "reconcile-sans", false)
+			if err != nil {
+				return err
+			}
+
+			// persist any newly requested SANs, so the other control-plane nodes
+			// reconcile against the same list
+			if len(extraSANs) > 0 {
+				existing := map[string]bool{}
+				for _, san := range cfg.APIServer.CertSANs {
+					existing[san] = true
+				}
+				changed := false
+				for _, san := range extraSANs {
+					if !existing[san] {
+						cfg.APIServer.CertSANs = append(cfg.APIServer.CertSANs, san)
+						changed = true
+					}
+				}
+				if changed {
+					if err := uploadconfig.UploadConfiguration(cfg, client); err != nil {
+						return err
+					}
+				}
+			}
+
+			// remove the current serving certificate, so the certs phase regenerates it
+			for _, file := range []string{constants.APIServerCertName, constants.APIServerKeyName} {
+				path := filepath.Join(cfg.CertificatesDir, file)
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+			if err := certsphase.CreateCertAndKeyFilesWithCA(&certsphase.KubeadmCertAPIServer, &certsphase.KubeadmCertRootCA, cfg); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "[reconcile-sans] Regenerated the API server serving certificate with SANs %v\n", cfg.APIServer.CertSANs)
+
+			// force the kubelet to restart the API server by touching its manifest
+			manifestPath := constants.GetStaticPodFilepath(constants.KubeAPIServer, constants.GetStaticPodDirectory())
+			pod, err := staticpodutil.ReadStaticPodFromDisk(manifestPath)
+			if err != nil {
+				fmt.Fprintf(out, "[reconcile-sans] Could not read the API server manifest (%v); restart the kube-apiserver manually to pick up the certificate\n", err)
+				return nil
+			}
+			if pod.ObjectMeta.Annotations == nil {
+				pod.ObjectMeta.Annotations = map[string]string{}
+			}
+			pod.ObjectMeta.Annotations[sansUpdatedAnnotation] = time.Now().Format(time.RFC3339)
+			if err := staticpodutil.WriteStaticPodToDisk(constants.KubeAPIServer, constants.GetStaticPodDirectory(), *pod); err != nil {
+				return err
+			}
+			fmt.Fprintln(out, "[reconcile-sans] Updated the API server manifest; the kubelet will restart it")
+			fmt.Fprintln(out, "[reconcile-sans] Run this command on the other control-plane nodes as well")
+			return nil
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringSliceVar(&extraSANs, "cert-sans", extraSANs, "Additional SANs to append to the stored certSANs before reconciling.")
+	return cmd
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/uploadconfig"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+)
+
+// newCmdRemoveControlPlaneNode returns the command removing a control-plane node from the cluster
+func newCmdRemoveControlPlaneNode(out io.Writer) *cobra.Command {
+	var kubeconfigFile string
+	var certificatesDir string
+	var nodeName string
+
+	cmd := &cobra.Command{
+		Use:   "remove-control-plane-node",
+		Short: "Safely remove a control-plane node from the cluster - the inverse of join --control-plane",
+		Long: cmdutil.LongDesc(`
+			Remove a control-plane node from the cluster: cordon the node, remove its etcd
+			member from the stacked etcd cluster, remove its API endpoint from the kubeadm
+			ClusterStatus and delete the Node object. Drain the node's workloads first (e.g.
+			with 'kubectl drain') and run 'kubeadm reset' on the node afterwards to clean up
+			its local manifests and certificates - today this whole procedure is a manual
+			multi-tool exercise.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if nodeName == "" {
+				return errors.New("--node-name is required")
+			}
+			client, err := kubeconfigutil.ClientSetFromFile(cmdutil.GetKubeConfigPath(kubeconfigFile))
+			if err != nil {
+				return err
+			}
+			return runRemoveControlPlaneNode(out, client, certificatesDir, nodeName)
+		},
+	}
+
+	options.AddKubeConfigFlag(cmd.Flags(), &kubeconfigFile)
+	cmd.Flags().StringVar(&certificatesDir, options.CertificatesDir, kubeadmapiv1beta2.DefaultCertificatesDir, "The path where the etcd certificates are stored.")
+	cmd.Flags().StringVar(&nodeName, options.NodeName, nodeName, "The name of the control-plane node to remove.")
+	return cmd
+}
+
+// runRemoveControlPlaneNode performs the scale-down steps
+func runRemoveControlPlaneNode(out io.Writer, client clientset.Interface, certificatesDir, nodeName string) error {
+	node, err := client.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the Node %q", nodeName)
+	}
+
+	// 1. cordon the node, so nothing new is scheduled while it goes away
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+		if _, err := client.CoreV1().Nodes().Update(node); err != nil {
+			return errors.Wrapf(err, "failed to cordon the Node %q", nodeName)
+		}
+		fmt.Fprintf(out, "[remove-control-plane-node] Cordoned node %q; drain its workloads with 'kubectl drain %s' if you haven't\n", nodeName, nodeName)
+	}
+
+	// 2. remove the node's etcd member, when the cluster uses stacked etcd
+	clusterStatus, err := configutil.GetClusterStatus(client)
+	if err != nil {
+		return err
+	}
+	if endpoint, ok := clusterStatus.APIEndpoints[nodeName]; ok {
+		etcdClient, err := etcdutil.NewFromCluster(client, certificatesDir)
+		if err != nil {
+			fmt.Fprintf(out, "[remove-control-plane-node] Could not connect to etcd (%v); for external etcd this is expected\n", err)
+		} else {
+			peerURL := etcdutil.GetPeerURL(&endpoint)
+			id, err := etcdClient.GetMemberID(peerURL)
+			if err != nil {
+				fmt.Fprintf(out, "[remove-control-plane-node] No etcd member with peer URL %q found; skipping the member removal\n", peerURL)
+			} else {
+				if _, err := etcdClient.RemoveMember(id); err != nil {
+					return errors.Wrapf(err, "failed to remove the etcd member of node %q", nodeName)
+				}
+				fmt.Fprintf(out, "[remove-control-plane-node] Removed the etcd member of node %q\n", nodeName)
+			}
+		}
+
+		// 3. remove the node's API endpoint from the ClusterStatus
+		if err := uploadconfig.ResetClusterStatusForNode(nodeName, client); err != nil {
+			return err
+		}
+	}
+
+	// 4. delete the Node object
+	if err := client.CoreV1().Nodes().Delete(nodeName, &metav1.DeleteOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to delete the Node %q", nodeName)
+	}
+	fmt.Fprintf(out, "[remove-control-plane-node] Deleted node %q from the cluster\n", nodeName)
+	fmt.Fprintf(out, "[remove-control-plane-node] Run 'kubeadm reset' on the removed machine to clean up its local state\n")
+	return nil
+}
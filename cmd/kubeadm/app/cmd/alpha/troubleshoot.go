@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	utilsexec "k8s.io/utils/exec"
+)
+
+// kubeconfigSecretPattern matches the secret carrying fields of kubeconfig files
+var kubeconfigSecretPattern = regexp.MustCompile(`(?m)^(\s*(client-key-data|client-certificate-data|token|password)):.*$`)
+
+// newCmdTroubleshoot returns the command collecting a support bundle from the local node
+func newCmdTroubleshoot(out io.Writer) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "troubleshoot",
+		Short: "Collect a support bundle with the standard diagnostics from this node",
+		Long: cmdutil.LongDesc(`
+			Collect a support bundle from the local node: the static pod manifests, the
+			kubeconfig files with their secrets redacted, the certificate expiration dates
+			and the recent kubelet logs, packaged as a tarball with an index.json - the
+			standard first ask in every kubeadm bug report.
+			` + cmdutil.AlphaDisclaimer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return collectSupportBundle(out, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", "kubeadm-support-bundle.tar.gz", "Path of the support bundle to write.")
+	return cmd
+}
+
+// bundleEntry describes one file of the support bundle for the index
+type bundleEntry struct {
+	// Name is the path of the entry within the bundle
+	Name string `json:"name"`
+
+	// Description states what the entry contains
+	Description string `json:"description"`
+
+	// Error is set when the entry could not be collected
+	Error string `json:"error,omitempty"`
+}
+
+// collectSupportBundle gathers the diagnostics and writes the bundle tarball
+func collectSupportBundle(out io.Writer, output string) error {
+	f, err := os.Create(output)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the support bundle %q", output)
+	}
+	defer f.Close()
+
+	gzWriter := gzip.NewWriter(f)
+	defer gzWriter.Close()
+	tarWriter := tar.NewWriter(gzWriter)
+	defer tarWriter.Close()
+
+	index := []bundleEntry{}
+	addFile := func(name, description string, contents []byte, err error) {
+		entry := bundleEntry{Name: name, Description: description}
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			if writeErr := writeTarFile(tarWriter, name, contents); writeErr != nil {
+				entry.Error = writeErr.Error()
+			}
+		}
+		index = append(index, entry)
+	}
+
+	// static pod manifests
+	manifestDir := constants.GetStaticPodDirectory()
+	manifests, _ := ioutil.ReadDir(manifestDir)
+	for _, manifest := range manifests {
+		if manifest.IsDir() {
+			continue
+		}
+		contents, err := ioutil.ReadFile(filepath.Join(manifestDir, manifest.Name()))
+		addFile("manifests/"+manifest.Name(), "static Pod manifest", contents, err)
+	}
+
+	// redacted kubeconfig files
+	for _, kubeconfig := range []string{
+		constants.AdminKubeConfigFileName,
+		constants.KubeletKubeConfigFileName,
+		constants.ControllerManagerKubeConfigFileName,
+		constants.SchedulerKubeConfigFileName,
+	} {
+		contents, err := ioutil.ReadFile(filepath.Join(constants.KubernetesDir, kubeconfig))
+		if err == nil {
+			contents = kubeconfigSecretPattern.ReplaceAll(contents, []byte("$1: <redacted>"))
+		}
+		addFile("kubeconfig/"+kubeconfig, "kubeconfig file with secrets redacted", contents, err)
+	}
+
+	// certificate expiration overview
+	expirations, err := collectCertificateExpirations(kubeadmapiv1beta2.DefaultCertificatesDir)
+	addFile("certificate-expirations.json", "expiration dates of the PKI certificates", expirations, err)
+
+	// recent kubelet logs, best-effort
+	kubeletLogs, err := utilsexec.New().Command("journalctl", "-u", "kubelet", "--no-pager", "--since", "-1h").CombinedOutput()
+	addFile("kubelet.log", "kubelet logs of the last hour", kubeletLogs, err)
+
+	// the index goes last, so it covers all entries
+	indexBytes, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the bundle index")
+	}
+	if err := writeTarFile(tarWriter, "index.json", indexBytes); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "[troubleshoot] Wrote the support bundle to %q (%d entries)\n", output, len(index))
+	return nil
+}
+
+// collectCertificateExpirations reads every certificate below the PKI directory and
+// returns their expiration dates as JSON
+func collectCertificateExpirations(pkiDir string) ([]byte, error) {
+	type certExpiration struct {
+		Certificate string    `json:"certificate"`
+		NotAfter    time.Time `json:"notAfter"`
+		Expired     bool      `json:"expired"`
+	}
+
+	expirations := []certExpiration{}
+	err := filepath.Walk(pkiDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".crt") {
+			return nil
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		block, _ := pem.Decode(contents)
+		if block == nil {
+			return nil
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil
+		}
+		expirations = append(expirations, certExpiration{
+			Certificate: strings.TrimPrefix(path, pkiDir+"/"),
+			NotAfter:    cert.NotAfter,
+			Expired:     time.Now().After(cert.NotAfter),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(expirations, "", "  ")
+}
+
+// writeTarFile adds a single file to the tar archive
+func writeTarFile(tarWriter *tar.Writer, name string, contents []byte) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(contents)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to write the tar header for %q", name)
+	}
+	if _, err := tarWriter.Write(contents); err != nil {
+		return errors.Wrapf(err, "failed to write %q into the bundle", name)
+	}
+	return nil
+}
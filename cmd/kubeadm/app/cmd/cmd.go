@@ -25,6 +25,7 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/upgrade"
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	logutil "k8s.io/kubernetes/cmd/kubeadm/app/util/log"
 	// Register the kubeadm configuration types because CLI flag generation
 	// depends on the generated defaults.
 )
@@ -32,6 +33,7 @@ import (
 // NewKubeadmCommand returns cobra.Command to run kubeadm command
 func NewKubeadmCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 	var rootfsPath string
+	var logFormat string
 
 	cmds := &cobra.Command{
 		Use:   "kubeadm",
@@ -70,6 +72,9 @@ func NewKubeadmCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := logutil.SetFormat(logFormat); err != nil {
+				return err
+			}
 			if rootfsPath != "" {
 				if err := kubeadmutil.Chroot(rootfsPath); err != nil {
 					return err
@@ -92,6 +97,7 @@ func NewKubeadmCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 	cmds.AddCommand(alpha.NewCmdAlpha(in, out))
 
 	options.AddKubeadmOtherFlags(cmds.PersistentFlags(), &rootfsPath)
+	cmds.PersistentFlags().StringVar(&logFormat, "log-format", logutil.FormatText, "Log output format of structured kubeadm messages. One of: text|json.")
 
 	return cmds
 }
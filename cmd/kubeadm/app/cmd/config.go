@@ -18,10 +18,13 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"sort"
+	"text/tabwriter"
 
 	"github.com/lithammer/dedent"
 	"github.com/pkg/errors"
@@ -29,11 +32,16 @@ import (
 	flag "github.com/spf13/pflag"
 	"k8s.io/klog"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	clientset "k8s.io/client-go/kubernetes"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	kubeadmscheme "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
 	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	outputapischeme "k8s.io/kubernetes/cmd/kubeadm/app/apis/output/scheme"
+	outputapiv1alpha1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/output/v1alpha1"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	phaseutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases"
 	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
@@ -41,9 +49,14 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/features"
 	"k8s.io/kubernetes/cmd/kubeadm/app/images"
+	controlplanephase "k8s.io/kubernetes/cmd/kubeadm/app/phases/controlplane"
+	etcdphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/etcd"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/uploadconfig"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
 	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
 	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/output"
 	utilruntime "k8s.io/kubernetes/cmd/kubeadm/app/util/runtime"
 	utilsexec "k8s.io/utils/exec"
 )
@@ -87,6 +100,60 @@ func NewCmdConfig(out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdConfigUpload(out, &kubeConfigFile))
 	cmd.AddCommand(NewCmdConfigView(out, &kubeConfigFile))
 	cmd.AddCommand(NewCmdConfigImages(out))
+	cmd.AddCommand(NewCmdConfigRender(out))
+	cmd.AddCommand(NewCmdConfigWizard(os.Stdin, out))
+	cmd.AddCommand(NewCmdConfigExportManifests(out))
+	return cmd
+}
+
+// NewCmdConfigRender returns cobra.Command for "kubeadm config render" command
+func NewCmdConfigRender(out io.Writer) *cobra.Command {
+	var cfgPath string
+	externalInitCfg := &kubeadmapiv1beta2.InitConfiguration{}
+	kubeadmscheme.Scheme.Default(externalInitCfg)
+	externalClusterCfg := &kubeadmapiv1beta2.ClusterConfiguration{}
+	kubeadmscheme.Scheme.Default(externalClusterCfg)
+
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render the static Pod manifests kubeadm would write for this configuration",
+		Long: dedent.Dedent(`
+			This command renders the static Pod manifests for the control plane components
+			(and for local etcd, when configured) exactly as 'kubeadm init' would write them,
+			without touching the node. This lets tooling inspect or reuse the manifests
+			without exec-ing kubeadm on a target machine.
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			klog.V(1).Infoln("[config] retrieving ClientSet from file")
+			internalcfg, err := configutil.LoadOrDefaultInitConfiguration(cfgPath, externalInitCfg, externalClusterCfg)
+			if err != nil {
+				return err
+			}
+
+			componentNames := []string{constants.KubeAPIServer, constants.KubeControllerManager, constants.KubeScheduler}
+			manifests, err := controlplanephase.RenderStaticPodManifests(&internalcfg.ClusterConfiguration, &internalcfg.LocalAPIEndpoint, componentNames...)
+			if err != nil {
+				return err
+			}
+
+			if internalcfg.Etcd.Local != nil {
+				etcdSpec := etcdphase.GetEtcdPodSpec(&internalcfg.ClusterConfiguration, &internalcfg.LocalAPIEndpoint, internalcfg.NodeRegistration.Name, []etcdutil.Member{})
+				serialized, err := kubeadmutil.MarshalToYaml(&etcdSpec, v1.SchemeGroupVersion)
+				if err != nil {
+					return errors.Wrap(err, "failed to marshal manifest for etcd to YAML")
+				}
+				manifests[constants.Etcd] = serialized
+				componentNames = append(componentNames, constants.Etcd)
+			}
+
+			for _, componentName := range componentNames {
+				fmt.Fprintf(out, "---\n# %s\n%s", componentName, manifests[componentName])
+			}
+			return nil
+		},
+	}
+
+	options.AddConfigFlag(cmd.Flags(), &cfgPath)
 	return cmd
 }
 
@@ -102,9 +169,90 @@ func NewCmdConfigPrint(out io.Writer) *cobra.Command {
 	}
 	cmd.AddCommand(NewCmdConfigPrintInitDefaults(out))
 	cmd.AddCommand(NewCmdConfigPrintJoinDefaults(out))
+	cmd.AddCommand(NewCmdConfigPrintPatchTargets(out))
+	cmd.AddCommand(NewCmdConfigPrintFeatureGates(out))
+	return cmd
+}
+
+// NewCmdConfigPrintFeatureGates returns cobra.Command for "kubeadm config print feature-gates" command
+func NewCmdConfigPrintFeatureGates(out io.Writer) *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "feature-gates",
+		Short: "Print the feature gates known to kubeadm, their maturity and defaults",
+		Long: dedent.Dedent(`
+			This command prints all feature gates known to this version of kubeadm, together
+			with their maturity (alpha/beta/GA/deprecated), default value, whether they are
+			locked and their deprecation schedule. Use -o json for consumption by tooling.
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gates := features.ListGates(&features.InitFeatureGates)
+
+			if outputFormat == "json" {
+				b, err := json.MarshalIndent(gates, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(b))
+				return nil
+			}
+
+			tabw := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+			fmt.Fprintln(tabw, "NAME\tMATURITY\tDEFAULT\tLOCKED\tDESCRIPTION")
+			for _, gate := range gates {
+				fmt.Fprintf(tabw, "%s\t%s\t%t\t%t\t%s\n", gate.Name, gate.Maturity, gate.Default, gate.Locked, gate.Description)
+			}
+			return tabw.Flush()
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format. One of: text|json.")
 	return cmd
 }
 
+// NewCmdConfigPrintPatchTargets returns cobra.Command for "kubeadm config print patch-targets" command
+func NewCmdConfigPrintPatchTargets(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "patch-targets",
+		Short: "Print the objects patches in a --patches directory can target",
+		Long: dedent.Dedent(`
+			This command prints the set of objects that can be targeted by the patches of a
+			patches directory, together with the group/version/kind/name to use in the patch.
+
+			The generated kubeconfig files are deliberately not patchable: they embed
+			credentials, and rewriting them through generic patches too easily produces a
+			file whose certificates and context no longer match.
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targets := []struct {
+				description string
+				apiVersion  string
+				kind        string
+				namespace   string
+				name        string
+			}{
+				{"kube-apiserver static Pod", "v1", "Pod", metav1.NamespaceSystem, constants.KubeAPIServer},
+				{"kube-controller-manager static Pod", "v1", "Pod", metav1.NamespaceSystem, constants.KubeControllerManager},
+				{"kube-scheduler static Pod", "v1", "Pod", metav1.NamespaceSystem, constants.KubeScheduler},
+				{"etcd static Pod", "v1", "Pod", metav1.NamespaceSystem, constants.Etcd},
+				{"CoreDNS Deployment", "apps/v1", "Deployment", metav1.NamespaceSystem, "coredns"},
+				{"CoreDNS Service", "v1", "Service", metav1.NamespaceSystem, "kube-dns"},
+				{"kube-proxy DaemonSet", "apps/v1", "DaemonSet", metav1.NamespaceSystem, constants.KubeProxy},
+				{"kube-proxy ConfigMap", "v1", "ConfigMap", metav1.NamespaceSystem, constants.KubeProxyConfigMap},
+				{"kubelet configuration file", "kubelet.config.k8s.io/v1beta1", "KubeletConfiguration", "", ""},
+			}
+
+			tabw := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+			fmt.Fprintln(tabw, "TARGET\tAPIVERSION\tKIND\tNAMESPACE\tNAME")
+			for _, target := range targets {
+				fmt.Fprintf(tabw, "%s\t%s\t%s\t%s\t%s\n", target.description, target.apiVersion, target.kind, target.namespace, target.name)
+			}
+			return tabw.Flush()
+		},
+	}
+}
+
 // NewCmdConfigPrintInitDefaults returns cobra.Command for "kubeadm config print init-defaults" command
 func NewCmdConfigPrintInitDefaults(out io.Writer) *cobra.Command {
 	return newCmdConfigPrintActionDefaults(out, "init", getDefaultInitConfigBytes)
@@ -450,6 +598,173 @@ func NewCmdConfigImages(out io.Writer) *cobra.Command {
 	}
 	cmd.AddCommand(NewCmdConfigImagesList(out, nil))
 	cmd.AddCommand(NewCmdConfigImagesPull())
+	cmd.AddCommand(NewCmdConfigImagesVerify(out))
+	cmd.AddCommand(NewCmdConfigImagesExport(out))
+	cmd.AddCommand(NewCmdConfigImagesImport(out))
+	return cmd
+}
+
+// NewCmdConfigImagesExport returns the "kubeadm config images export" command
+func NewCmdConfigImagesExport(out io.Writer) *cobra.Command {
+	externalClusterCfg := &kubeadmapiv1beta2.ClusterConfiguration{}
+	kubeadmscheme.Scheme.Default(externalClusterCfg)
+	externalInitCfg := &kubeadmapiv1beta2.InitConfiguration{}
+	kubeadmscheme.Scheme.Default(externalInitCfg)
+	var cfgPath, featureGatesString, output string
+	var err error
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the images used by kubeadm into a tarball bundle for offline provisioning",
+		Long: dedent.Dedent(`
+			This command pulls all images 'kubeadm init' would use and exports them, together
+			with a JSON manifest of their digests, into a tarball bundle that can be copied to
+			air-gapped nodes and loaded there with 'kubeadm config images import'.
+		`),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			externalClusterCfg.FeatureGates, err = features.NewFeatureGate(&features.InitFeatureGates, featureGatesString)
+			if err != nil {
+				return err
+			}
+			internalcfg, err := configutil.LoadOrDefaultInitConfiguration(cfgPath, externalInitCfg, externalClusterCfg)
+			if err != nil {
+				return err
+			}
+			containerRuntime, err := utilruntime.NewContainerRuntime(utilsexec.New(), internalcfg.NodeRegistration.CRISocket)
+			if err != nil {
+				return err
+			}
+
+			if err := PullControlPlaneImages(containerRuntime, &internalcfg.ClusterConfiguration, utilruntime.DefaultPullConcurrency, "text"); err != nil {
+				return err
+			}
+
+			imageList := images.GetControlPlaneImages(&internalcfg.ClusterConfiguration)
+			if err := containerRuntime.ExportImages(imageList, output); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "[config/images] Exported %d images to %q\n", len(imageList), output)
+
+			// store the digest manifest next to the bundle for later verification
+			digests := images.ResolveImageDigests(imageList)
+			b, err := json.MarshalIndent(digests, "", "  ")
+			if err != nil {
+				return err
+			}
+			digestsPath := output + ".digests.json"
+			if err := ioutil.WriteFile(digestsPath, b, 0644); err != nil {
+				return errors.Wrapf(err, "failed to write the digest manifest %q", digestsPath)
+			}
+			fmt.Fprintf(out, "[config/images] Wrote the digest manifest to %q\n", digestsPath)
+			return nil
+		},
+	}
+	AddImagesCommonConfigFlags(cmd.PersistentFlags(), externalClusterCfg, &cfgPath, &featureGatesString)
+	cmdutil.AddCRISocketFlag(cmd.PersistentFlags(), &externalInitCfg.NodeRegistration.CRISocket)
+	cmd.Flags().StringVar(&output, "output-bundle", "kubeadm-images.tar", "Path of the tarball bundle to write.")
+	return cmd
+}
+
+// NewCmdConfigImagesImport returns the "kubeadm config images import" command
+func NewCmdConfigImagesImport(out io.Writer) *cobra.Command {
+	externalInitCfg := &kubeadmapiv1beta2.InitConfiguration{}
+	kubeadmscheme.Scheme.Default(externalInitCfg)
+
+	cmd := &cobra.Command{
+		Use:   "import [bundle]",
+		Short: "Load an image bundle created with 'kubeadm config images export' into the runtime",
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing the path of the bundle to import")
+			}
+			containerRuntime, err := utilruntime.NewContainerRuntime(utilsexec.New(), externalInitCfg.NodeRegistration.CRISocket)
+			if err != nil {
+				return err
+			}
+			if err := containerRuntime.ImportImages(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "[config/images] Imported the image bundle %q\n", args[0])
+			return nil
+		},
+	}
+	cmdutil.AddCRISocketFlag(cmd.PersistentFlags(), &externalInitCfg.NodeRegistration.CRISocket)
+	return cmd
+}
+
+// NewCmdConfigImagesVerify returns the "kubeadm config images verify" command
+func NewCmdConfigImagesVerify(out io.Writer) *cobra.Command {
+	externalClusterCfg := &kubeadmapiv1beta2.ClusterConfiguration{}
+	kubeadmscheme.Scheme.Default(externalClusterCfg)
+	var cfgPath, featureGatesString, digestsFile string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Resolve the images kubeadm would use to digests and verify them against a pinning file",
+		Long: dedent.Dedent(`
+			This command resolves every image 'kubeadm init' would use to the manifest digest
+			currently served by its registry and prints the result as JSON, so supply chain
+			sensitive users can pin and audit exactly what will be run. With --digests-file, a
+			JSON map of image to expected digest, the command fails when a served digest does
+			not match the pinned one.
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			featureGates, err := features.NewFeatureGate(&features.InitFeatureGates, featureGatesString)
+			if err != nil {
+				return err
+			}
+			externalClusterCfg.FeatureGates = featureGates
+			imagesList, err := NewImagesList(cfgPath, externalClusterCfg)
+			if err != nil {
+				return err
+			}
+
+			digests := images.ResolveImageDigests(images.GetControlPlaneImages(&imagesList.cfg.ClusterConfiguration))
+
+			b, err := json.MarshalIndent(digests, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(b))
+
+			failed := false
+			for _, digest := range digests {
+				if digest.Error != "" {
+					failed = true
+				}
+			}
+
+			if digestsFile != "" {
+				pinned := map[string]string{}
+				pinnedBytes, err := ioutil.ReadFile(digestsFile)
+				if err != nil {
+					return errors.Wrapf(err, "failed to read the digests file %q", digestsFile)
+				}
+				if err := json.Unmarshal(pinnedBytes, &pinned); err != nil {
+					return errors.Wrapf(err, "failed to parse the digests file %q", digestsFile)
+				}
+				for _, digest := range digests {
+					expected, ok := pinned[digest.Image]
+					if !ok {
+						fmt.Fprintf(out, "no pinned digest for image %q\n", digest.Image)
+						failed = true
+						continue
+					}
+					if expected != digest.Digest {
+						fmt.Fprintf(out, "digest mismatch for image %q: pinned %s, served %s\n", digest.Image, expected, digest.Digest)
+						failed = true
+					}
+				}
+			}
+
+			if failed {
+				return errors.New("image verification failed")
+			}
+			return nil
+		},
+	}
+	AddImagesCommonConfigFlags(cmd.Flags(), externalClusterCfg, &cfgPath, &featureGatesString)
+	cmd.Flags().StringVar(&digestsFile, "digests-file", "", "Path to a JSON file mapping image names to their expected manifest digests.")
 	return cmd
 }
 
@@ -459,7 +774,8 @@ func NewCmdConfigImagesPull() *cobra.Command {
 	kubeadmscheme.Scheme.Default(externalClusterCfg)
 	externalInitCfg := &kubeadmapiv1beta2.InitConfiguration{}
 	kubeadmscheme.Scheme.Default(externalInitCfg)
-	var cfgPath, featureGatesString string
+	var cfgPath, featureGatesString, outputFormat string
+	var parallelism int
 	var err error
 
 	cmd := &cobra.Command{
@@ -478,11 +794,18 @@ func NewCmdConfigImagesPull() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return PullControlPlaneImages(containerRuntime, &internalcfg.ClusterConfiguration)
+			if internalcfg.ImagePull != nil {
+				if err := utilruntime.ApplyImagePullConfiguration(containerRuntime, internalcfg.ImagePull.CredentialsFile); err != nil {
+					return err
+				}
+			}
+			return PullControlPlaneImages(containerRuntime, &internalcfg.ClusterConfiguration, parallelism, outputFormat)
 		},
 	}
 	AddImagesCommonConfigFlags(cmd.PersistentFlags(), externalClusterCfg, &cfgPath, &featureGatesString)
 	cmdutil.AddCRISocketFlag(cmd.PersistentFlags(), &externalInitCfg.NodeRegistration.CRISocket)
+	cmd.Flags().IntVar(&parallelism, "parallelism", utilruntime.DefaultPullConcurrency, "The number of images to pull in parallel.")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format for the per image pull events. One of: text|json.")
 
 	return cmd
 }
@@ -502,13 +825,27 @@ func NewImagesPull(runtime utilruntime.ContainerRuntime, images []string) *Image
 }
 
 // PullControlPlaneImages pulls all images that the ImagesPull knows about
-func PullControlPlaneImages(runtime utilruntime.ContainerRuntime, cfg *kubeadmapi.ClusterConfiguration) error {
-	images := images.GetControlPlaneImages(cfg)
-	for _, image := range images {
-		if err := runtime.PullImage(image); err != nil {
-			return errors.Wrapf(err, "failed to pull image %q", image)
+func PullControlPlaneImages(runtime utilruntime.ContainerRuntime, cfg *kubeadmapi.ClusterConfiguration, parallelism int, outputFormat string) error {
+	results := utilruntime.PullImages(runtime, images.GetControlPlaneImages(cfg), parallelism, func(result utilruntime.PullResult) {
+		if outputFormat == "json" {
+			b, err := json.Marshal(result)
+			if err == nil {
+				fmt.Println(string(b))
+			}
+			return
+		}
+		switch result.Status {
+		case "failed":
+			fmt.Printf("[config/images] Failed to pull %s: %s\n", result.Image, result.Error)
+		default:
+			fmt.Printf("[config/images] Pulled %s\n", result.Image)
+		}
+	})
+
+	for _, result := range results {
+		if result.Status == "failed" {
+			return errors.Errorf("failed to pull image %q: %s", result.Image, result.Error)
 		}
-		fmt.Printf("[config/images] Pulled %s\n", image)
 	}
 	return nil
 }
@@ -526,6 +863,8 @@ func NewCmdConfigImagesList(out io.Writer, mockK8sVersion *string) *cobra.Comman
 		externalcfg.KubernetesVersion = *mockK8sVersion
 	}
 
+	outputFlags := output.NewOutputFlags(&imagesTextPrintFlags{}).WithTypeSetter(outputapischeme.Scheme).WithDefaultOutput(output.TextOutput)
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Print a list of images kubeadm will use. The configuration file is used in case any images or image repositories are customized",
@@ -535,18 +874,52 @@ func NewCmdConfigImagesList(out io.Writer, mockK8sVersion *string) *cobra.Comman
 				return err
 			}
 
+			printer, err := outputFlags.ToPrinter()
+			if err != nil {
+				return err
+			}
+
 			imagesList, err := NewImagesList(cfgPath, externalcfg)
 			if err != nil {
 				return err
 			}
 
-			return imagesList.Run(out)
+			return imagesList.Run(out, printer)
 		},
 	}
+	outputFlags.AddFlags(cmd)
 	AddImagesCommonConfigFlags(cmd.PersistentFlags(), externalcfg, &cfgPath, &featureGatesString)
 	return cmd
 }
 
+// imagesTextPrinter prints the image list in the plain text form, one image per line
+type imagesTextPrinter struct {
+	output.TextPrinter
+}
+
+// PrintObj is an implementation of ResourcePrinter.PrintObj for the plain text output
+func (itp *imagesTextPrinter) PrintObj(obj runtime.Object, writer io.Writer) error {
+	imagesObj, ok := obj.(*outputapiv1alpha1.Images)
+	if !ok {
+		return errors.New("unexpected object type for the images text printer")
+	}
+	for _, img := range imagesObj.Images {
+		fmt.Fprintln(writer, img)
+	}
+	return nil
+}
+
+// imagesTextPrintFlags provides flags necessary for printing the image list as text
+type imagesTextPrintFlags struct{}
+
+// ToPrinter returns kubeadm printer for the text output format
+func (ipf *imagesTextPrintFlags) ToPrinter(outputFormat string) (output.Printer, error) {
+	if outputFormat == output.TextOutput {
+		return &imagesTextPrinter{}, nil
+	}
+	return nil, genericclioptions.NoCompatiblePrinterError{OutputFormat: &outputFormat, AllowedFormats: []string{output.TextOutput}}
+}
+
 // NewImagesList returns the underlying struct for the "kubeadm config images list" command
 func NewImagesList(cfgPath string, cfg *kubeadmapiv1beta2.ClusterConfiguration) (*ImagesList, error) {
 	// Avoid running the CRI auto-detection code as we don't need it
@@ -572,13 +945,11 @@ type ImagesList struct {
 }
 
 // Run runs the images command and writes the result to the io.Writer passed in
-func (i *ImagesList) Run(out io.Writer) error {
-	imgs := images.GetControlPlaneImages(&i.cfg.ClusterConfiguration)
-	for _, img := range imgs {
-		fmt.Fprintln(out, img)
+func (i *ImagesList) Run(out io.Writer, printer output.Printer) error {
+	imgs := outputapiv1alpha1.Images{
+		Images: images.GetControlPlaneImages(&i.cfg.ClusterConfiguration),
 	}
-
-	return nil
+	return printer.PrintObj(&imgs, out)
 }
 
 // AddImagesCommonConfigFlags adds the flags that configure kubeadm (and affect the images kubeadm will use)
@@ -114,7 +114,7 @@ func TestImagesListRunWithCustomConfigPath(t *testing.T) {
 				t.Fatalf("Failed getting the kubeadm images command: %v", err)
 			}
 			var output bytes.Buffer
-			if i.Run(&output) != nil {
+			if i.Run(&output, &imagesTextPrinter{}) != nil {
 				t.Fatalf("Error from running the images command: %v", err)
 			}
 			actual := strings.Split(output.String(), "\n")
@@ -183,7 +183,7 @@ func TestConfigImagesListRunWithoutPath(t *testing.T) {
 			}
 
 			var output bytes.Buffer
-			if i.Run(&output) != nil {
+			if i.Run(&output, &imagesTextPrinter{}) != nil {
 				t.Fatalf("did not expect an error running the Images command: %v", err)
 			}
 
@@ -0,0 +1,156 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lithammer/dedent"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmscheme "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/features"
+	"k8s.io/kubernetes/cmd/kubeadm/app/images"
+	dnsaddon "k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/dns"
+	proxyaddon "k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/proxy"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+)
+
+// NewCmdConfigExportManifests returns the "kubeadm config export-manifests" command
+func NewCmdConfigExportManifests(out io.Writer) *cobra.Command {
+	externalInitCfg := &kubeadmapiv1beta2.InitConfiguration{}
+	kubeadmscheme.Scheme.Default(externalInitCfg)
+	externalClusterCfg := &kubeadmapiv1beta2.ClusterConfiguration{}
+	kubeadmscheme.Scheme.Default(externalClusterCfg)
+	var cfgPath, outputDir string
+
+	cmd := &cobra.Command{
+		Use:   "export-manifests",
+		Short: "Export the addon objects kubeadm would create into a kustomize-compatible directory",
+		Long: dedent.Dedent(`
+			This command renders the objects kubeadm creates in the cluster for its managed
+			addons (the CoreDNS Deployment, Service, ConfigMap and RBAC, and the kube-proxy
+			DaemonSet) as they would look on a fresh cluster, and writes them as a directory
+			with a kustomization.yaml - so GitOps-driven clusters can keep the
+			kubeadm-managed objects under source control and detect drift.
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			internalcfg, err := configutil.LoadOrDefaultInitConfiguration(cfgPath, externalInitCfg, externalClusterCfg)
+			if err != nil {
+				return err
+			}
+			return exportAddonManifests(out, &internalcfg.ClusterConfiguration, outputDir)
+		},
+	}
+
+	options.AddConfigFlag(cmd.Flags(), &cfgPath)
+	cmd.Flags().StringVar(&outputDir, "output-dir", "kubeadm-manifests", "Directory to write the exported manifests into.")
+	return cmd
+}
+
+// exportAddonManifests renders and writes the addon manifests plus a kustomization.yaml
+func exportAddonManifests(out io.Writer, cfg *kubeadmapi.ClusterConfiguration, outputDir string) error {
+	manifests := map[string][]byte{}
+
+	// CoreDNS, as rendered for a fresh cluster (no kube-dns translation applies)
+	replicas := int32(2)
+	coreDNSDeployment, err := kubeadmutil.ParseTemplate(dnsaddon.CoreDNSDeployment, struct {
+		DeploymentName, Image, ControlPlaneTaintKey string
+		Replicas                                    *int32
+	}{
+		DeploymentName:       constants.CoreDNSDeploymentName,
+		Image:                images.GetDNSImage(cfg, constants.CoreDNSImageName),
+		ControlPlaneTaintKey: constants.LabelNodeRoleMaster,
+		Replicas:             &replicas,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error when parsing CoreDNS deployment template")
+	}
+	manifests["coredns-deployment.yaml"] = coreDNSDeployment
+
+	coreDNSConfigMap, err := kubeadmutil.ParseTemplate(dnsaddon.CoreDNSConfigMap, struct{ DNSDomain, UpstreamNameserver, Federation, StubDomain string }{
+		DNSDomain:          cfg.Networking.DNSDomain,
+		UpstreamNameserver: "/etc/resolv.conf",
+	})
+	if err != nil {
+		return errors.Wrap(err, "error when parsing CoreDNS configMap template")
+	}
+	manifests["coredns-configmap.yaml"] = coreDNSConfigMap
+
+	dnsip, err := constants.GetDNSIP(cfg.Networking.ServiceSubnet, features.Enabled(cfg.FeatureGates, features.IPv6DualStack))
+	if err != nil {
+		return err
+	}
+	coreDNSService, err := kubeadmutil.ParseTemplate(dnsaddon.KubeDNSService, struct{ DNSIP string }{DNSIP: dnsip.String()})
+	if err != nil {
+		return errors.Wrap(err, "error when parsing CoreDNS service template")
+	}
+	manifests["coredns-service.yaml"] = coreDNSService
+
+	manifests["coredns-clusterrole.yaml"] = []byte(dnsaddon.CoreDNSClusterRole)
+	manifests["coredns-clusterrolebinding.yaml"] = []byte(dnsaddon.CoreDNSClusterRoleBinding)
+	manifests["coredns-serviceaccount.yaml"] = []byte(dnsaddon.CoreDNSServiceAccount)
+
+	// kube-proxy DaemonSet (the ConfigMap content is node independent component config
+	// and is exported by 'kubeadm config view' style tooling instead)
+	if cfg.Proxy == nil || !cfg.Proxy.Disabled {
+		proxyDaemonSet, err := kubeadmutil.ParseTemplate(proxyaddon.KubeProxyDaemonSet19, struct{ Image, ProxyConfigMap, ProxyConfigMapKey string }{
+			Image:             images.GetKubernetesImage(constants.KubeProxy, cfg),
+			ProxyConfigMap:    constants.KubeProxyConfigMap,
+			ProxyConfigMapKey: constants.KubeProxyConfigMapKey,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error when parsing kube-proxy daemonset template")
+		}
+		manifests["kube-proxy-daemonset.yaml"] = proxyDaemonSet
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create the output directory %q", outputDir)
+	}
+
+	names := []string{}
+	for name := range manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := ioutil.WriteFile(filepath.Join(outputDir, name), manifests[name], 0644); err != nil {
+			return errors.Wrapf(err, "failed to write %q", name)
+		}
+	}
+
+	kustomization := fmt.Sprintf("resources:\n- %s\n", strings.Join(names, "\n- "))
+	if err := ioutil.WriteFile(filepath.Join(outputDir, "kustomization.yaml"), []byte(kustomization), 0644); err != nil {
+		return errors.Wrap(err, "failed to write the kustomization.yaml")
+	}
+
+	fmt.Fprintf(out, "[export-manifests] Wrote %d manifests and a kustomization.yaml to %q\n", len(names), outputDir)
+	return nil
+}
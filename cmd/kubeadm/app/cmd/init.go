@@ -40,10 +40,13 @@ import (
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/features"
 	certsphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/certs"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/hooks"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/reproducible"
 	kubeconfigphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/kubeconfig"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
 	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/warnings"
 )
 
 var (
@@ -99,6 +102,7 @@ type initOptions struct {
 	uploadCerts             bool
 	skipCertificateKeyPrint bool
 	kustomizeDir            string
+	reproducibleSeed        string
 }
 
 // compile-time assert that the local data object satisfies the phases data interface.
@@ -115,6 +119,7 @@ type initData struct {
 	ignorePreflightErrors   sets.String
 	certificatesDir         string
 	dryRunDir               string
+	actionLog               *apiclient.ActionLog
 	externalCA              bool
 	client                  clientset.Interface
 	outputWriter            io.Writer
@@ -144,11 +149,43 @@ func NewCmdInit(out io.Writer, initOptions *initOptions) *cobra.Command {
 			data := c.(*initData)
 			fmt.Printf("[init] Using Kubernetes version: %s\n", data.cfg.KubernetesVersion)
 
+			if !data.dryRun {
+				if err := hooks.RunHooks(data.outputWriter, data.cfg.Hooks, hooks.PreInit); err != nil {
+					return err
+				}
+			}
+
 			if err := initRunner.Run(args); err != nil {
 				return err
 			}
 
-			return showJoinCommand(data, out)
+			if !data.dryRun {
+				if err := hooks.RunHooks(data.outputWriter, data.cfg.Hooks, hooks.PostInit); err != nil {
+					return err
+				}
+			}
+
+			if data.dryRun {
+				// Store the exact plan of API actions the dry-run recorded
+				actionLogPath := filepath.Join(data.dryRunDir, "action-log.json")
+				actionLogFile, err := os.Create(actionLogPath)
+				if err != nil {
+					return errors.Wrapf(err, "couldn't create the dry-run action log %q", actionLogPath)
+				}
+				defer actionLogFile.Close()
+				if err := data.actionLog.WriteJSON(actionLogFile); err != nil {
+					return err
+				}
+				fmt.Printf("[dryrun] Wrote the plan of API actions to %q\n", actionLogPath)
+			}
+
+			if err := showJoinCommand(data, out); err != nil {
+				return err
+			}
+
+			// print the dedicated section with all warnings collected during the run
+			warnings.Flush(out)
+			return nil
 		},
 		Args: cobra.NoArgs,
 	}
@@ -184,6 +221,7 @@ func NewCmdInit(out io.Writer, initOptions *initOptions) *cobra.Command {
 	initRunner.AppendPhase(phases.NewBootstrapTokenPhase())
 	initRunner.AppendPhase(phases.NewKubeletFinalizePhase())
 	initRunner.AppendPhase(phases.NewAddonPhase())
+	initRunner.AppendPhase(phases.NewSelfHostingPhase())
 
 	// sets the data builder function, that will be used by the runner
 	// both when running the entire workflow or single phases
@@ -277,6 +315,10 @@ func AddInitOtherFlags(flagSet *flag.FlagSet, initOptions *initOptions) {
 		"Don't print the key used to encrypt the control-plane certificates.",
 	)
 	options.AddKustomizePodsFlag(flagSet, &initOptions.kustomizeDir)
+	flagSet.StringVar(
+		&initOptions.reproducibleSeed, options.ReproducibleSeed, initOptions.reproducibleSeed,
+		"Derive certificate serial numbers and artifact timestamps deterministically from this seed, so repeated runs produce diffable artifacts. Private keys stay random.",
+	)
 }
 
 // newInitOptions returns a struct ready for being used for creating cmd init flags.
@@ -306,6 +348,10 @@ func newInitOptions() *initOptions {
 // This func takes care of validating initOptions passed to the command, and then it converts
 // options into the internal InitConfiguration type that is used as input all the phases in the kubeadm init workflow
 func newInitData(cmd *cobra.Command, args []string, options *initOptions, out io.Writer) (*initData, error) {
+	if options.reproducibleSeed != "" {
+		reproducible.SetSeed(options.reproducibleSeed)
+	}
+
 	// Re-apply defaults to the public kubeadm API (this will set only values not exposed/not set as a flags)
 	kubeadmscheme.Scheme.Default(options.externalInitCfg)
 	kubeadmscheme.Scheme.Default(options.externalClusterCfg)
@@ -396,12 +442,17 @@ func newInitData(cmd *cobra.Command, args []string, options *initOptions, out io
 		return nil, errors.New("can't use upload-certs with an external CA or an external front-proxy CA")
 	}
 
+	// propagate the configured timeouts into the subsystems whose waits are still
+	// governed by package level settings
+	cmdutil.ApplyClusterTimeouts(cfg.Timeouts)
+
 	return &initData{
 		cfg:                     cfg,
 		certificatesDir:         cfg.CertificatesDir,
 		skipTokenPrint:          options.skipTokenPrint,
 		dryRun:                  options.dryRun,
 		dryRunDir:               dryRunDir,
+		actionLog:               &apiclient.ActionLog{},
 		kubeconfigDir:           options.kubeconfigDir,
 		kubeconfigPath:          options.kubeconfigPath,
 		ignorePreflightErrors:   ignorePreflightErrorsSet,
@@ -520,7 +571,9 @@ func (d *initData) Client() (clientset.Interface, error) {
 			}
 			// If we're dry-running, we should create a faked client that answers some GETs in order to be able to do the full init flow and just logs the rest of requests
 			dryRunGetter := apiclient.NewInitDryRunGetter(d.cfg.NodeRegistration.Name, svcSubnetCIDR.String())
-			d.client = apiclient.NewDryRunClient(dryRunGetter, os.Stdout)
+			dryRunOpts := apiclient.GetDefaultDryRunClientOptions(dryRunGetter, os.Stdout)
+			dryRunOpts.ActionLog = d.actionLog
+			d.client = apiclient.NewDryRunClientWithOpts(dryRunOpts)
 		} else {
 			// If we're acting for real, we should create a connection to the API server and wait for it to come up
 			var err error
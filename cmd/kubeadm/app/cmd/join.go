@@ -41,8 +41,10 @@ import (
 	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/discovery"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/hooks"
 	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
 	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/warnings"
 )
 
 var (
@@ -167,10 +169,18 @@ func NewCmdJoin(out io.Writer, joinOptions *joinOptions) *cobra.Command {
 
 			data := c.(*joinData)
 
+			if err := hooks.RunHooks(data.outputWriter, data.cfg.Hooks, hooks.PreJoin); err != nil {
+				return err
+			}
+
 			if err := joinRunner.Run(args); err != nil {
 				return err
 			}
 
+			if err := hooks.RunHooks(data.outputWriter, data.cfg.Hooks, hooks.PostJoin); err != nil {
+				return err
+			}
+
 			// if the node is hosting a new control plane instance
 			if data.cfg.ControlPlane != nil {
 				// outputs the join control plane done message and exit
@@ -193,6 +203,8 @@ func NewCmdJoin(out io.Writer, joinOptions *joinOptions) *cobra.Command {
 				fmt.Fprint(data.outputWriter, joinWorkerNodeDoneMsg)
 			}
 
+			// print the dedicated section with all warnings collected during the run
+			warnings.Flush(data.outputWriter)
 			return nil
 		},
 		// We accept the control-plane location as an optional positional argument
@@ -454,6 +466,9 @@ func (j *joinData) InitCfg() (*kubeadmapi.InitConfiguration, error) {
 	}
 	klog.V(1).Infoln("[preflight] Fetching init configuration")
 	initCfg, err := fetchInitConfigurationFromJoinConfiguration(j.cfg, j.tlsBootstrapCfg)
+	if err == nil {
+		cmdutil.ApplyClusterTimeouts(initCfg.Timeouts)
+	}
 	j.initCfg = initCfg
 	return initCfg, err
 }
@@ -50,6 +50,15 @@ const (
 	// IgnorePreflightErrors sets the path a list of checks whose errors will be shown as warnings. Example: 'IsPrivilegedUser,Swap'. Value 'all' ignores errors from all checks.
 	IgnorePreflightErrors = "ignore-preflight-errors"
 
+	// PreflightReportFile flag sets the file where the structured results of the preflight checks are stored as JSON
+	PreflightReportFile = "report-file"
+
+	// PreflightFix flag enables named preflight remediations that fix specific check failures
+	PreflightFix = "fix"
+
+	// ReproducibleSeed flag enables deterministic artifact generation derived from the given seed
+	ReproducibleSeed = "reproducible-seed"
+
 	// ImageRepository sets the container registry to pull control plane images from.
 	ImageRepository = "image-repository"
 
@@ -104,6 +113,12 @@ const (
 	// TokenGroups flag sets the authentication groups of the token
 	TokenGroups = "groups"
 
+	// TokenSingleUse flag marks a bootstrap token for deletion after its first successful join
+	TokenSingleUse = "single-use"
+
+	// TokenAllowedCIDRs flag restricts the source networks a bootstrap token may be used from
+	TokenAllowedCIDRs = "allowed-cidrs"
+
 	// TokenDescription flag sets the description of the token
 	TokenDescription = "description"
 
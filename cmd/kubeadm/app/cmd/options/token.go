@@ -79,6 +79,22 @@ func (bto *BootstrapTokenOptions) AddGroupsFlag(fs *pflag.FlagSet) {
 	)
 }
 
+// AddSingleUseFlag adds the --single-use flag to the given flagset
+func (bto *BootstrapTokenOptions) AddSingleUseFlag(fs *pflag.FlagSet) {
+	fs.BoolVar(
+		&bto.SingleUse, TokenSingleUse, bto.SingleUse,
+		"Store a single-use marker in the token Secret. NOTE: kubeadm itself does not enforce this; the marker is advisory until an external controller deletes marked tokens after their first join.",
+	)
+}
+
+// AddAllowedCIDRsFlag adds the --allowed-cidrs flag to the given flagset
+func (bto *BootstrapTokenOptions) AddAllowedCIDRsFlag(fs *pflag.FlagSet) {
+	fs.StringSliceVar(
+		&bto.AllowedCIDRs, TokenAllowedCIDRs, bto.AllowedCIDRs,
+		"Store the allowed source networks in the token Secret. You can pass --allowed-cidrs multiple times or provide a comma separated list of CIDRs. NOTE: kubeadm itself does not enforce this; the marker is advisory until an external admission or audit mechanism acts on it.",
+	)
+}
+
 // AddDescriptionFlag adds the --description flag to the given flagset
 func (bto *BootstrapTokenOptions) AddDescriptionFlag(fs *pflag.FlagSet) {
 	fs.StringVar(
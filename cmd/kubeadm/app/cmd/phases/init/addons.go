@@ -25,6 +25,7 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/workflow"
 	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
 	dnsaddon "k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/dns"
+	konnectivityaddon "k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/konnectivity"
 	proxyaddon "k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/proxy"
 )
 
@@ -70,35 +71,39 @@ func NewAddonPhase() workflow.Phase {
 	}
 }
 
-func getInitData(c workflow.RunData) (*kubeadmapi.InitConfiguration, clientset.Interface, error) {
+func getInitData(c workflow.RunData) (*kubeadmapi.InitConfiguration, clientset.Interface, string, error) {
 	data, ok := c.(InitData)
 	if !ok {
-		return nil, nil, errors.New("addon phase invoked with an invalid data struct")
+		return nil, nil, "", errors.New("addon phase invoked with an invalid data struct")
 	}
 	cfg := data.Cfg()
 	client, err := data.Client()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
-	return cfg, client, err
+	return cfg, client, data.KustomizeDir(), err
 }
 
 // runCoreDNSAddon installs CoreDNS addon to a Kubernetes cluster
 func runCoreDNSAddon(c workflow.RunData) error {
-	cfg, client, err := getInitData(c)
+	cfg, client, kustomizeDir, err := getInitData(c)
 	if err != nil {
 		return err
 	}
-	return dnsaddon.EnsureDNSAddon(&cfg.ClusterConfiguration, client)
+	return dnsaddon.EnsureDNSAddon(&cfg.ClusterConfiguration, client, kustomizeDir)
 }
 
 // runKubeProxyAddon installs KubeProxy addon to a Kubernetes cluster
 func runKubeProxyAddon(c workflow.RunData) error {
-	cfg, client, err := getInitData(c)
+	cfg, client, kustomizeDir, err := getInitData(c)
 	if err != nil {
 		return err
 	}
-	return proxyaddon.EnsureProxyAddon(&cfg.ClusterConfiguration, &cfg.LocalAPIEndpoint, client)
+	if err := proxyaddon.EnsureProxyAddon(&cfg.ClusterConfiguration, &cfg.LocalAPIEndpoint, client, kustomizeDir); err != nil {
+		return err
+	}
+	// deploy the konnectivity-agent when konnectivity is enabled
+	return konnectivityaddon.EnsureKonnectivityAddon(&cfg.ClusterConfiguration, &cfg.LocalAPIEndpoint, client)
 }
 
 func getAddonPhaseFlags(name string) []string {
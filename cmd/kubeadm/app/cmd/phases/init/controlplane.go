@@ -18,14 +18,21 @@ package phases
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/workflow"
 	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/konnectivity"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/admission"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/controlplane"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/encryption"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/endpointprovider"
 )
 
 var (
@@ -133,6 +140,19 @@ func runControlPlanePhase(c workflow.RunData) error {
 	}
 
 	fmt.Printf("[control-plane] Using manifest folder %q\n", data.ManifestDir())
+
+	// deploy the control plane endpoint provider static pod, when one is configured
+	if err := endpointprovider.CreateEndpointProviderManifest(data.ManifestDir(), &data.Cfg().ClusterConfiguration); err != nil {
+		return err
+	}
+
+	// deploy the konnectivity-server and the egress selector configuration, when configured
+	if err := konnectivity.WriteEgressSelectorConfiguration(&data.Cfg().ClusterConfiguration); err != nil {
+		return err
+	}
+	if err := konnectivity.CreateServerStaticPodManifest(data.ManifestDir(), &data.Cfg().ClusterConfiguration); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -144,7 +164,33 @@ func runControlPlaneSubphase(component string) func(c workflow.RunData) error {
 		}
 		cfg := data.Cfg()
 
+		if component == kubeadmconstants.KubeAPIServer && !data.DryRun() {
+			// Make sure the encryption configuration exists before the API server starts
+			if err := encryption.CreateEncryptionConfiguration(&cfg.ClusterConfiguration); err != nil {
+				return err
+			}
+			// Render the admission configuration, when declared
+			if err := admission.CreateAdmissionConfiguration(&cfg.ClusterConfiguration); err != nil {
+				return err
+			}
+			// Write the inline audit policy, when declared
+			if err := writeAuditPolicy(&cfg.ClusterConfiguration); err != nil {
+				return err
+			}
+		}
+
 		fmt.Printf("[control-plane] Creating static Pod manifest for %q\n", component)
 		return controlplane.CreateStaticPodFiles(data.ManifestDir(), data.KustomizeDir(), &cfg.ClusterConfiguration, &cfg.LocalAPIEndpoint, component)
 	}
 }
+
+// writeAuditPolicy stores the inline audit policy of the configuration on disk, so the
+// API server can mount it
+func writeAuditPolicy(cfg *kubeadmapi.ClusterConfiguration) error {
+	if cfg.APIServer.Audit == nil || cfg.APIServer.Audit.PolicyContent == "" {
+		return nil
+	}
+	policyFile := filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.AuditPolicyFileName)
+	fmt.Printf("[control-plane] Writing audit policy to %q\n", policyFile)
+	return ioutil.WriteFile(policyFile, []byte(cfg.APIServer.Audit.PolicyContent), 0600)
+}
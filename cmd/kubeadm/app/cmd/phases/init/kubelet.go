@@ -77,8 +77,13 @@ func runKubeletStart(c workflow.RunData) error {
 		return errors.New("no kubelet component config found in the active component config set")
 	}
 
+	// Write the drop-in file with the instance specific kubelet configuration fields
+	if err := kubeletphase.WriteInstanceConfigToDisk(&data.Cfg().NodeRegistration, data.KubeletDir()); err != nil {
+		return errors.Wrap(err, "error writing instance specific kubelet configuration to disk")
+	}
+
 	// Write the kubelet configuration file to disk.
-	if err := kubeletphase.WriteConfigToDisk(kubeletCfg, data.KubeletDir()); err != nil {
+	if err := kubeletphase.WriteConfigToDisk(kubeletCfg, data.KubeletDir(), data.KustomizeDir()); err != nil {
 		return errors.Wrap(err, "error writing kubelet configuration to disk")
 	}
 
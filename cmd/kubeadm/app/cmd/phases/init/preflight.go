@@ -18,11 +18,15 @@ package phases
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/workflow"
 	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/preflight"
 	utilsexec "k8s.io/utils/exec"
 )
@@ -32,6 +36,12 @@ var (
 		# Run pre-flight checks for kubeadm init using a config file.
 		kubeadm init phase preflight --config kubeadm-config.yml
 		`)
+
+	// preflightReportFile is the path where the structured results of the run are stored, when set
+	preflightReportFile string
+
+	// preflightFixes holds the names of the remediations enabled via --fix
+	preflightFixes []string
 )
 
 // NewPreflightPhase creates a kubeadm workflow phase that implements preflight checks for a new control-plane node.
@@ -46,9 +56,17 @@ func NewPreflightPhase() workflow.Phase {
 			options.CfgPath,
 			options.IgnorePreflightErrors,
 		},
+		LocalFlags: preflightLocalFlags(),
 	}
 }
 
+func preflightLocalFlags() *pflag.FlagSet {
+	set := pflag.NewFlagSet("preflight", pflag.ExitOnError)
+	set.StringVar(&preflightReportFile, options.PreflightReportFile, preflightReportFile, "Store the structured results of the executed checks as JSON in this file.")
+	set.StringSliceVar(&preflightFixes, options.PreflightFix, preflightFixes, "A list of remediations to apply before running the checks. Example: 'load-br-netfilter,disable-swap'. Value 'all' applies all remediations.")
+	return set
+}
+
 // runPreflight executes preflight checks logic.
 func runPreflight(c workflow.RunData) error {
 	data, ok := c.(InitData)
@@ -56,10 +74,27 @@ func runPreflight(c workflow.RunData) error {
 		return errors.New("preflight phase invoked with an invalid data struct")
 	}
 
+	if len(preflightFixes) > 0 {
+		ports := []int{int(data.Cfg().LocalAPIEndpoint.BindPort), kubeadmconstants.KubeSchedulerPort, kubeadmconstants.KubeControllerManagerPort, kubeadmconstants.KubeletPort}
+		if err := preflight.RunRemediations(sets.NewString(preflightFixes...), utilsexec.New(), data.DryRun(), ports); err != nil {
+			return err
+		}
+	}
+
 	fmt.Println("[preflight] Running pre-flight checks")
-	if err := preflight.RunInitNodeChecks(utilsexec.New(), data.Cfg(), data.IgnorePreflightErrors(), false, false); err != nil {
+	checks, err := preflight.InitNodeChecks(utilsexec.New(), data.Cfg(), data.IgnorePreflightErrors(), false, false)
+	if err != nil {
 		return err
 	}
+	report, checksErr := preflight.RunChecksWithReport(checks, os.Stderr, data.IgnorePreflightErrors())
+	if preflightReportFile != "" && report != nil {
+		if err := report.WriteToFile(preflightReportFile); err != nil {
+			return err
+		}
+	}
+	if checksErr != nil {
+		return checksErr
+	}
 
 	if !data.DryRun() {
 		fmt.Println("[preflight] Pulling images required for setting up a Kubernetes cluster")
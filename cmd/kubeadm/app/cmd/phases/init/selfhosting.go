@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package phases
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/workflow"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/features"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/selfhosting"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+)
+
+// NewSelfHostingPhase returns the phase that pivots the control plane from static Pods
+// to workloads managed by the cluster itself, gated on the SelfHosting feature gate
+func NewSelfHostingPhase() workflow.Phase {
+	return workflow.Phase{
+		Name:   "self-hosting",
+		Short:  "Pivot the control plane from static Pods to self-hosted workloads (gated on the SelfHosting feature gate)",
+		Hidden: true,
+		RunIf: func(c workflow.RunData) (bool, error) {
+			data, ok := c.(InitData)
+			if !ok {
+				return false, errors.New("self-hosting phase invoked with an invalid data struct")
+			}
+			return features.Enabled(data.Cfg().FeatureGates, features.SelfHosting), nil
+		},
+		Run: runSelfHosting,
+	}
+}
+
+// runSelfHosting performs the pivot to the self-hosted control plane
+func runSelfHosting(c workflow.RunData) error {
+	data, ok := c.(InitData)
+	if !ok {
+		return errors.New("self-hosting phase invoked with an invalid data struct")
+	}
+
+	client, err := data.Client()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("[self-hosting] Pivoting the control plane from static Pods to self-hosted workloads")
+	waiter := apiclient.NewKubeWaiter(client, kubeadmconstants.DefaultControlPlaneTimeout, data.OutputWriter())
+	return selfhosting.CreateSelfHostedControlPlane(
+		kubeadmconstants.GetStaticPodDirectory(),
+		kubeadmconstants.KubernetesDir,
+		data.Cfg(),
+		client,
+		waiter,
+		data.DryRun(),
+		false,
+	)
+}
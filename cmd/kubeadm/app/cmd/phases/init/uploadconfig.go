@@ -29,6 +29,8 @@ import (
 	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	kubeletphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/kubelet"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/manifesthash"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/patches"
 	patchnodephase "k8s.io/kubernetes/cmd/kubeadm/app/phases/patchnode"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/uploadconfig"
 )
@@ -109,6 +111,14 @@ func runUploadKubeadmConfig(c workflow.RunData) error {
 	if err := uploadconfig.UploadConfiguration(cfg, client); err != nil {
 		return errors.Wrap(err, "error uploading the kubeadm ClusterConfiguration")
 	}
+
+	// persist the local patch set in the cluster, so joining control-plane nodes and
+	// upgrades apply the same patches
+	if data, ok := c.(InitData); ok && cfg.PatchesConfigMap != "" && data.KustomizeDir() != "" {
+		if err := patches.UploadPatches(client, data.KustomizeDir(), cfg.PatchesConfigMap); err != nil {
+			return errors.Wrap(err, "error uploading the patches")
+		}
+	}
 	return nil
 }
 
@@ -128,6 +138,11 @@ func runUploadKubeletConfig(c workflow.RunData) error {
 	if err := patchnodephase.AnnotateCRISocket(client, cfg.NodeRegistration.Name, cfg.NodeRegistration.CRISocket); err != nil {
 		return errors.Wrap(err, "Error writing Crisocket information for the control-plane node")
 	}
+
+	klog.V(1).Infoln("[upload-config] Storing the expected static Pod manifest hashes for the control-plane node")
+	if err := manifesthash.StoreManifestHashes(client, cfg.NodeRegistration.Name, kubeadmconstants.GetStaticPodDirectory()); err != nil {
+		return errors.Wrap(err, "error storing the expected manifest hashes for the control-plane node")
+	}
 	return nil
 }
 
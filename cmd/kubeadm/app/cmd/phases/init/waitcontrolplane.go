@@ -92,6 +92,9 @@ func runWaitControlPlanePhase(c workflow.RunData) error {
 	}
 
 	timeout := data.Cfg().ClusterConfiguration.APIServer.TimeoutForControlPlane.Duration
+	if timeouts := data.Cfg().ClusterConfiguration.Timeouts; timeouts != nil && timeouts.ControlPlaneComponentHealthCheck != nil {
+		timeout = timeouts.ControlPlaneComponentHealthCheck.Duration
+	}
 	waiter, err := newControlPlaneWaiter(data.DryRun(), timeout, client, data.OutputWriter())
 	if err != nil {
 		return errors.Wrap(err, "error creating waiter")
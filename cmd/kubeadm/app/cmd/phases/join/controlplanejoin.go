@@ -194,7 +194,7 @@ func runMarkControlPlanePhase(c workflow.RunData) error {
 		return err
 	}
 
-	if err := markcontrolplanephase.MarkControlPlane(client, cfg.NodeRegistration.Name, cfg.NodeRegistration.Taints); err != nil {
+	if err := markcontrolplanephase.MarkControlPlane(client, cfg.NodeRegistration.Name, cfg.NodeRegistration.Taints, cfg.ClusterConfiguration.ControlPlaneMarking); err != nil {
 		return errors.Wrap(err, "error applying control-plane label and taints")
 	}
 
@@ -32,6 +32,7 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/controlplane"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/copycerts"
 	kubeconfigphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/kubeconfig"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/patches"
 	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
 )
 
@@ -187,9 +188,22 @@ func runControlPlanePrepareControlPlaneSubphase(c workflow.RunData) error {
 	fmt.Printf("[control-plane] Using manifest folder %q\n", kubeadmconstants.GetStaticPodDirectory())
 	for _, component := range kubeadmconstants.ControlPlaneComponents {
 		fmt.Printf("[control-plane] Creating static Pod manifest for %q\n", component)
+		kustomizeDir := data.KustomizeDir()
+		if kustomizeDir == "" && cfg.PatchesConfigMap != "" {
+			// fall back to the patch set persisted in the cluster
+			client, err := bootstrapClient(data)
+			if err != nil {
+				return err
+			}
+			kustomizeDir, err = patches.DownloadPatches(client, cfg.PatchesConfigMap)
+			if err != nil {
+				return err
+			}
+		}
+
 		err := controlplane.CreateStaticPodFiles(
 			kubeadmconstants.GetStaticPodDirectory(),
-			data.KustomizeDir(),
+			kustomizeDir,
 			&cfg.ClusterConfiguration,
 			&cfg.LocalAPIEndpoint,
 			component,
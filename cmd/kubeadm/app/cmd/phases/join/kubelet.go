@@ -133,6 +133,11 @@ func runKubeletStartJoinPhase(c workflow.RunData) (returnErr error) {
 	klog.V(1).Infoln("[kubelet-start] Stopping the kubelet")
 	kubeletphase.TryStopKubelet()
 
+	// Write the drop-in file with the instance specific kubelet configuration fields
+	if err := kubeletphase.WriteInstanceConfigToDisk(&cfg.NodeRegistration, kubeadmconstants.KubeletRunDirectory); err != nil {
+		return errors.Wrap(err, "error writing instance specific kubelet configuration to disk")
+	}
+
 	// Write the configuration for the kubelet (using the bootstrap token credentials) to disk so the kubelet can start
 	if err := kubeletphase.DownloadConfig(bootstrapClient, kubeletVersion, kubeadmconstants.KubeletRunDirectory); err != nil {
 		return err
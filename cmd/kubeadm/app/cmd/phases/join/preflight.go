@@ -19,15 +19,19 @@ package phases
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"text/template"
 
 	"github.com/lithammer/dedent"
 	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/workflow"
 	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/certs"
 	"k8s.io/kubernetes/cmd/kubeadm/app/preflight"
 	utilsexec "k8s.io/utils/exec"
@@ -39,6 +43,12 @@ var (
 		kubeadm join phase preflight --config kubeadm-config.yml
 		`)
 
+	// preflightReportFile is the path where the structured results of the run are stored, when set
+	preflightReportFile string
+
+	// preflightFixes holds the names of the remediations enabled via --fix
+	preflightFixes []string
+
 	notReadyToJoinControlPlaneTemp = template.Must(template.New("join").Parse(dedent.Dedent(`
 		One or more conditions for hosting a new control plane instance is not satisfied.
 
@@ -75,9 +85,17 @@ func NewPreflightPhase() workflow.Phase {
 			options.TokenDiscoverySkipCAHash,
 			options.CertificateKey,
 		},
+		LocalFlags: preflightLocalFlags(),
 	}
 }
 
+func preflightLocalFlags() *pflag.FlagSet {
+	set := pflag.NewFlagSet("preflight", pflag.ExitOnError)
+	set.StringVar(&preflightReportFile, options.PreflightReportFile, preflightReportFile, "Store the structured results of the executed checks as JSON in this file.")
+	set.StringSliceVar(&preflightFixes, options.PreflightFix, preflightFixes, "A list of remediations to apply before running the checks. Example: 'load-br-netfilter,disable-swap'. Value 'all' applies all remediations.")
+	return set
+}
+
 // runPreflight executes preflight checks logic.
 func runPreflight(c workflow.RunData) error {
 	j, ok := c.(JoinData)
@@ -86,11 +104,28 @@ func runPreflight(c workflow.RunData) error {
 	}
 	fmt.Println("[preflight] Running pre-flight checks")
 
+	if len(preflightFixes) > 0 {
+		ports := []int{kubeadmconstants.KubeletPort}
+		if err := preflight.RunRemediations(sets.NewString(preflightFixes...), utilsexec.New(), false, ports); err != nil {
+			return err
+		}
+	}
+
 	// Start with general checks
 	klog.V(1).Infoln("[preflight] Running general checks")
-	if err := preflight.RunJoinNodeChecks(utilsexec.New(), j.Cfg(), j.IgnorePreflightErrors()); err != nil {
+	checks, err := preflight.JoinNodeChecks(utilsexec.New(), j.Cfg(), j.IgnorePreflightErrors())
+	if err != nil {
 		return err
 	}
+	report, checksErr := preflight.RunChecksWithReport(checks, os.Stderr, j.IgnorePreflightErrors())
+	if preflightReportFile != "" && report != nil {
+		if err := report.WriteToFile(preflightReportFile); err != nil {
+			return err
+		}
+	}
+	if checksErr != nil {
+		return checksErr
+	}
 
 	initCfg, err := j.InitCfg()
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/workflow"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/execer"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/initsystem"
 	utilruntime "k8s.io/kubernetes/cmd/kubeadm/app/util/runtime"
 	utilsexec "k8s.io/utils/exec"
@@ -77,7 +78,7 @@ func runCleanupNode(c workflow.RunData) error {
 	}
 
 	klog.V(1).Info("[reset] Removing Kubernetes-managed containers")
-	if err := removeContainers(utilsexec.New(), r.CRISocketPath()); err != nil {
+	if err := removeContainers(execer.New(), r.CRISocketPath()); err != nil {
 		klog.Warningf("[reset] Failed to remove containers: %v\n", err)
 	}
 
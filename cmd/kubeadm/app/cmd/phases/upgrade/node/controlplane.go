@@ -19,11 +19,14 @@ package node
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/workflow"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	kubeconfigphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/kubeconfig"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/upgrade"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 )
@@ -78,6 +81,25 @@ func runControlPlane() func(c workflow.RunData) error {
 			return errors.Wrap(err, "couldn't complete the static pod upgrade")
 		}
 
+		// regenerate the kubeconfig files of this node from the cluster configuration,
+		// so secondary control-plane nodes pick up endpoint or CA changes as well
+		if data.RenewKubeConfigs() {
+			fmt.Println("[upgrade] Regenerating the kubeconfig files of this node")
+			for _, kubeConfigFileName := range []string{
+				kubeadmconstants.AdminKubeConfigFileName,
+				kubeadmconstants.ControllerManagerKubeConfigFileName,
+				kubeadmconstants.SchedulerKubeConfigFileName,
+			} {
+				kubeConfigFilePath := filepath.Join(kubeadmconstants.KubernetesDir, kubeConfigFileName)
+				if err := os.Remove(kubeConfigFilePath); err != nil && !os.IsNotExist(err) {
+					return errors.Wrapf(err, "couldn't remove the outdated kubeconfig file %q", kubeConfigFilePath)
+				}
+				if err := kubeconfigphase.CreateKubeConfigFile(kubeConfigFileName, kubeadmconstants.KubernetesDir, cfg); err != nil {
+					return errors.Wrapf(err, "couldn't regenerate the kubeconfig file %q", kubeConfigFileName)
+				}
+			}
+		}
+
 		fmt.Println("[upgrade] The control plane instance for this node was successfully updated!")
 
 		return nil
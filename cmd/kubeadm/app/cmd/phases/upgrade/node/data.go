@@ -26,6 +26,7 @@ import (
 type Data interface {
 	EtcdUpgrade() bool
 	RenewCerts() bool
+	RenewKubeConfigs() bool
 	DryRun() bool
 	KubeletVersion() string
 	Cfg() *kubeadmapi.InitConfiguration
@@ -97,6 +97,12 @@ func runKubeletConfigPhase() func(c workflow.RunData) error {
 			return err
 		}
 
+		// Regenerate the dynamic kubelet flags and restart the kubelet only when they changed
+		changed, err := kubeletphase.ReconcileKubeletDynamicEnvFile(&cfg.ClusterConfiguration, &cfg.NodeRegistration, false, kubeletDir)
+		if err != nil {
+			return err
+		}
+
 		// If we're dry-running, print the generated manifests
 		if dryRun {
 			if err := printFilesIfDryRunning(dryRun, kubeletDir); err != nil {
@@ -105,6 +111,13 @@ func runKubeletConfigPhase() func(c workflow.RunData) error {
 			return nil
 		}
 
+		if changed {
+			fmt.Println("[upgrade] The kubelet environment file changed; restarting the kubelet")
+			kubeletphase.TryRestartKubelet()
+		} else {
+			fmt.Println("[upgrade] The kubelet environment file is unchanged; not restarting the kubelet")
+		}
+
 		fmt.Println("[upgrade] The configuration for this node was successfully updated!")
 		fmt.Println("[upgrade] Now you should go ahead and upgrade the kubelet package using your package manager.")
 		return nil
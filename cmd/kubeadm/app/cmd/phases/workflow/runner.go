@@ -21,8 +21,10 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/trace"
 )
 
 // phaseSeparator defines the separator to be used when concatenating nested
@@ -71,6 +73,31 @@ type Runner struct {
 	// a list of wrappers to phases composing the workflow with contextual
 	// information supporting phase execution.
 	phaseRunners []*phaseRunner
+
+	// prePhaseHooks are invoked before every executed phase; an error returned by a
+	// pre-phase hook vetoes the phase and aborts the workflow
+	prePhaseHooks []PrePhaseHook
+
+	// postPhaseHooks are invoked after every executed phase, e.g. for audit logging
+	// or metrics, and receive the error the phase returned (if any)
+	postPhaseHooks []PostPhaseHook
+}
+
+// PrePhaseHook is invoked before a phase runs. Returning an error vetoes the phase and
+// aborts the workflow.
+type PrePhaseHook func(phaseName string, data RunData) error
+
+// PostPhaseHook is invoked after a phase ran, together with the error it returned
+type PostPhaseHook func(phaseName string, data RunData, phaseErr error)
+
+// AppendPrePhaseHook registers a hook that runs before every executed phase
+func (e *Runner) AppendPrePhaseHook(hook PrePhaseHook) {
+	e.prePhaseHooks = append(e.prePhaseHooks, hook)
+}
+
+// AppendPostPhaseHook registers a hook that runs after every executed phase
+func (e *Runner) AppendPostPhaseHook(hook PostPhaseHook) {
+	e.postPhaseHooks = append(e.postPhaseHooks, hook)
 }
 
 // phaseRunner provides a wrapper to a Phase with the addition of a set
@@ -229,9 +256,24 @@ func (e *Runner) Run(args []string) error {
 			}
 		}
 
-		// Runs the phase action (if defined)
+		// Runs the phase action (if defined), tracing its execution time and invoking
+		// the registered hooks around it
 		if p.Run != nil {
-			if err := p.Run(data); err != nil {
+			for _, hook := range e.prePhaseHooks {
+				if err := hook(p.generatedName, data); err != nil {
+					return errors.Wrapf(err, "phase %s vetoed by a pre-phase hook", p.generatedName)
+				}
+			}
+
+			endSpan := trace.StartSpan("phase/" + p.generatedName)
+			err := p.Run(data)
+			endSpan(err)
+
+			for _, hook := range e.postPhaseHooks {
+				hook(p.generatedName, data, err)
+			}
+
+			if err != nil {
 				return errors.Wrapf(err, "error execution phase %s", p.generatedName)
 			}
 		}
@@ -239,6 +281,9 @@ func (e *Runner) Run(args []string) error {
 		return nil
 	})
 
+	// export the spans recorded during this run, if tracing is configured
+	trace.Flush()
+
 	return err
 }
 
@@ -623,3 +623,38 @@ func cmdHasFlags(cmd *cobra.Command, expectedFlags ...string) error {
 
 	return nil
 }
+
+func TestPhaseHooks(t *testing.T) {
+	var w Runner
+	w.AppendPhase(phaseBuilder2("foo", runConditionPass, runPass))
+	w.AppendPhase(phaseBuilder2("bar", runConditionPass, runPass))
+	w.SetDataInitializer(func(*cobra.Command, []string) (RunData, error) { return nil, nil })
+
+	pre := []string{}
+	post := []string{}
+	w.AppendPrePhaseHook(func(phaseName string, _ RunData) error {
+		pre = append(pre, phaseName)
+		return nil
+	})
+	w.AppendPostPhaseHook(func(phaseName string, _ RunData, phaseErr error) {
+		post = append(post, phaseName)
+	})
+
+	if err := w.Run([]string{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(pre) != 2 || len(post) != 2 {
+		t.Errorf("expected both hooks to run for both phases, got pre=%v post=%v", pre, post)
+	}
+
+	// a vetoing pre-phase hook must abort the workflow
+	var w2 Runner
+	w2.AppendPhase(phaseBuilder2("foo", runConditionPass, runPass))
+	w2.SetDataInitializer(func(*cobra.Command, []string) (RunData, error) { return nil, nil })
+	w2.AppendPrePhaseHook(func(phaseName string, _ RunData) error {
+		return errors.New("not allowed")
+	})
+	if err := w2.Run([]string{}); err == nil {
+		t.Error("expected the veto to abort the workflow, got no error")
+	}
+}
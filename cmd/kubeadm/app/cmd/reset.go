@@ -40,8 +40,9 @@ import (
 
 var (
 	iptablesCleanupInstructions = dedent.Dedent(`
-		The reset process does not reset or clean up iptables rules or IPVS tables.
+		The reset process does not reset or clean up iptables rules, nftables rules or IPVS tables.
 		If you wish to reset iptables, you must do so manually by using the "iptables" command.
+		If kube-proxy was running in the "nftables" mode, flush its tables by using the "nft" command.
 
 		If your cluster was setup to utilize IPVS, run ipvsadm --clear (or similar)
 		to reset your system's IPVS tables.
@@ -47,6 +47,7 @@ import (
 	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	tokenphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/bootstraptoken/node"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/copycerts"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
 	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
@@ -98,9 +99,12 @@ func NewCmdToken(out io.Writer, errW io.Writer) *cobra.Command {
 
 	var cfgPath string
 	var printJoinCommand bool
+	var uploadCerts bool
 	var certificateKey string
 	bto := options.NewBootstrapTokenOptions()
 
+	createOutputFlags := output.NewOutputFlags(&tokenCreateTextPrintFlags{}).WithTypeSetter(outputapischeme.Scheme).WithDefaultOutput(output.TextOutput)
+
 	createCmd := &cobra.Command{
 		Use:                   "create [token]",
 		DisableFlagsInUseLine: true,
@@ -133,18 +137,29 @@ func NewCmdToken(out io.Writer, errW io.Writer) *cobra.Command {
 				return err
 			}
 
-			return RunCreateToken(out, client, cfgPath, cfg, printJoinCommand, certificateKey, kubeConfigFile)
+			printer, err := createOutputFlags.ToPrinter()
+			if err != nil {
+				return err
+			}
+
+			return RunCreateToken(out, client, cfgPath, cfg, printJoinCommand, uploadCerts, certificateKey, kubeConfigFile, printer)
 		},
 	}
 
+	createOutputFlags.AddFlags(createCmd)
+
 	options.AddConfigFlag(createCmd.Flags(), &cfgPath)
 	createCmd.Flags().BoolVar(&printJoinCommand,
 		"print-join-command", false, "Instead of printing only the token, print the full 'kubeadm join' flag needed to join the cluster using the token.")
 	createCmd.Flags().StringVar(&certificateKey,
 		options.CertificateKey, "", "When used together with '--print-join-command', print the full 'kubeadm join' flag needed to join the cluster as a control-plane. To create a new certificate key you must use 'kubeadm init phase upload-certs --upload-certs'.")
+	createCmd.Flags().BoolVar(&uploadCerts,
+		options.UploadCerts, false, "When used together with '--print-join-command', upload the control-plane certificates protected by a freshly generated certificate key and print the full control-plane 'kubeadm join' flag.")
 	bto.AddTTLFlagWithName(createCmd.Flags(), "ttl")
 	bto.AddUsagesFlag(createCmd.Flags())
 	bto.AddGroupsFlag(createCmd.Flags())
+	bto.AddSingleUseFlag(createCmd.Flags())
+	bto.AddAllowedCIDRsFlag(createCmd.Flags())
 	bto.AddDescriptionFlag(createCmd.Flags())
 
 	tokenCmd.AddCommand(createCmd)
@@ -203,6 +218,25 @@ func NewCmdToken(out io.Writer, errW io.Writer) *cobra.Command {
 	}
 	tokenCmd.AddCommand(deleteCmd)
 
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete all expired bootstrap tokens on the server",
+		Long: dedent.Dedent(`
+			This command will delete all bootstrap tokens that have expired, so fleet automation
+			can clean up leftover tokens in bulk without listing and parsing them first.
+		`),
+		RunE: func(tokenCmd *cobra.Command, args []string) error {
+			kubeConfigFile = cmdutil.GetKubeConfigPath(kubeConfigFile)
+			client, err := getClientset(kubeConfigFile, dryRun)
+			if err != nil {
+				return err
+			}
+
+			return RunPruneTokens(out, client)
+		},
+	}
+	tokenCmd.AddCommand(pruneCmd)
+
 	return tokenCmd
 }
 
@@ -229,7 +263,7 @@ func NewCmdTokenGenerate(out io.Writer) *cobra.Command {
 }
 
 // RunCreateToken generates a new bootstrap token and stores it as a secret on the server.
-func RunCreateToken(out io.Writer, client clientset.Interface, cfgPath string, initCfg *kubeadmapiv1beta2.InitConfiguration, printJoinCommand bool, certificateKey string, kubeConfigFile string) error {
+func RunCreateToken(out io.Writer, client clientset.Interface, cfgPath string, initCfg *kubeadmapiv1beta2.InitConfiguration, printJoinCommand, uploadCerts bool, certificateKey string, kubeConfigFile string, printer output.Printer) error {
 	// ClusterConfiguration is needed just for the call to LoadOrDefaultInitConfiguration
 	clusterCfg := &kubeadmapiv1beta2.ClusterConfiguration{
 		// KubernetesVersion is not used, but we set this explicitly to avoid
@@ -251,11 +285,52 @@ func RunCreateToken(out io.Writer, client clientset.Interface, cfgPath string, i
 		return err
 	}
 
+	if uploadCerts {
+		if !printJoinCommand {
+			return errors.New("cannot use --upload-certs without --print-join-command")
+		}
+		if certificateKey != "" {
+			return errors.New("cannot use --upload-certs together with --certificate-key")
+		}
+		certificateKey, err = copycerts.CreateCertificateKey()
+		if err != nil {
+			return err
+		}
+		klog.V(1).Infoln("[token] uploading the control-plane certificates")
+		if err := copycerts.UploadCerts(client, internalcfg, certificateKey); err != nil {
+			return errors.Wrap(err, "error uploading the control-plane certificates")
+		}
+	}
+
 	klog.V(1).Infoln("[token] creating token")
 	if err := tokenphase.CreateNewTokens(client, internalcfg.BootstrapTokens); err != nil {
 		return err
 	}
 
+	// with a structured output format the created token (or, with --print-join-command,
+	// the full set of join parameters) is printed as an API object
+	if _, isTextPrinter := printer.(*tokenCreateTextPrinter); printer != nil && !isTextPrinter {
+		if printJoinCommand {
+			joinParameters, err := cmdutil.GetJoinParameters(kubeConfigFile, internalcfg.BootstrapTokens[0].Token.String(), certificateKey, certificateKey != "")
+			if err != nil {
+				return errors.Wrap(err, "failed to get join parameters")
+			}
+			return printer.PrintObj(joinParameters, out)
+		}
+		token := internalcfg.BootstrapTokens[0]
+		outputToken := outputapiv1alpha1.BootstrapToken{
+			BootstrapToken: kubeadmapiv1beta2.BootstrapToken{
+				Token:       &kubeadmapiv1beta2.BootstrapTokenString{ID: token.Token.ID, Secret: token.Token.Secret},
+				Description: token.Description,
+				TTL:         token.TTL,
+				Expires:     token.Expires,
+				Usages:      token.Usages,
+				Groups:      token.Groups,
+			},
+		}
+		return printer.PrintObj(&outputToken, out)
+	}
+
 	// if --print-join-command was specified, print a machine-readable full `kubeadm join` command
 	// otherwise, just print the token
 	if printJoinCommand {
@@ -288,6 +363,22 @@ func RunCreateToken(out io.Writer, client clientset.Interface, cfgPath string, i
 	return nil
 }
 
+// tokenCreateTextPrinter marks that the default token/join-command text output should be used
+type tokenCreateTextPrinter struct {
+	output.TextPrinter
+}
+
+// tokenCreateTextPrintFlags provides flags necessary for the default token create text output
+type tokenCreateTextPrintFlags struct{}
+
+// ToPrinter returns kubeadm printer for the text output format
+func (tpf *tokenCreateTextPrintFlags) ToPrinter(outputFormat string) (output.Printer, error) {
+	if outputFormat == output.TextOutput {
+		return &tokenCreateTextPrinter{}, nil
+	}
+	return nil, genericclioptions.NoCompatiblePrinterError{OutputFormat: &outputFormat, AllowedFormats: []string{output.TextOutput}}
+}
+
 // RunGenerateToken just generates a random token for the user
 func RunGenerateToken(out io.Writer) error {
 	klog.V(1).Infoln("[token] generating random token")
@@ -438,6 +529,46 @@ func RunDeleteTokens(out io.Writer, client clientset.Interface, tokenIDsOrTokens
 	return nil
 }
 
+// RunPruneTokens removes all expired bootstrap tokens from the server.
+func RunPruneTokens(out io.Writer, client clientset.Interface) error {
+	tokenSelector := fields.SelectorFromSet(
+		map[string]string{
+			"type": string(bootstrapapi.SecretTypeBootstrapToken),
+		},
+	)
+	listOptions := metav1.ListOptions{
+		FieldSelector: tokenSelector.String(),
+	}
+
+	klog.V(1).Info("[token] retrieving list of bootstrap tokens")
+	secrets, err := client.CoreV1().Secrets(metav1.NamespaceSystem).List(listOptions)
+	if err != nil {
+		return errors.Wrap(err, "failed to list bootstrap tokens")
+	}
+
+	pruned := 0
+	for _, secret := range secrets.Items {
+		token, err := kubeadmapi.BootstrapTokenFromSecret(&secret)
+		if err != nil {
+			fmt.Fprintf(out, "%v\n", err)
+			continue
+		}
+		if token.Expires == nil || token.Expires.After(time.Now()) {
+			continue
+		}
+		if err := client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(secret.Name, nil); err != nil {
+			return errors.Wrapf(err, "failed to delete bootstrap token %q", token.Token.ID)
+		}
+		fmt.Fprintf(out, "expired bootstrap token %q deleted\n", token.Token.ID)
+		pruned++
+	}
+
+	if pruned == 0 {
+		fmt.Fprintln(out, "no expired bootstrap tokens found")
+	}
+	return nil
+}
+
 func getClientset(file string, dryRun bool) (clientset.Interface, error) {
 	if dryRun {
 		dryRunGetter, err := apiclient.NewClientBackedDryRunGetterFromKubeconfig(file)
@@ -157,6 +157,10 @@ func enforceRequirements(flags *applyPlanFlags, dryRun bool, newK8sVersion strin
 	}
 
 	// Use a real version getter interface that queries the API server, the kubeadm client and the Kubernetes CI system for latest versions
+	// propagate the configured timeouts into the subsystems whose waits are still
+	// governed by package level settings
+	cmdutil.ApplyClusterTimeouts(cfg.Timeouts)
+
 	return client, upgrade.NewOfflineVersionGetter(upgrade.NewKubeVersionGetter(client), newK8sVersion), cfg, nil
 }
 
@@ -193,6 +197,11 @@ func runPreflightChecks(client clientset.Interface, ignorePreflightErrors sets.S
 }
 
 // getClient gets a real or fake client depending on whether the user is dry-running or not
+// serverSideDryRunWrites makes dry-run clients created by getClient execute write
+// operations as server-side dry-run requests against the live cluster instead of faking
+// their success locally
+var serverSideDryRunWrites = false
+
 func getClient(file string, dryRun bool) (clientset.Interface, error) {
 	if dryRun {
 		dryRunGetter, err := apiclient.NewClientBackedDryRunGetterFromKubeconfig(file)
@@ -212,6 +221,11 @@ func getClient(file string, dryRun bool) (clientset.Interface, error) {
 		dryRunOpts := apiclient.GetDefaultDryRunClientOptions(dryRunGetter, os.Stdout)
 		// Print GET and LIST requests
 		dryRunOpts.PrintGETAndLIST = true
+		if serverSideDryRunWrites {
+			// Execute writes as server-side dry-run requests, so admission and
+			// validation run for real without persisting anything
+			dryRunOpts.WriteReactionFunc = dryRunGetter.ServerSideDryRunReaction
+		}
 		fakeclient := apiclient.NewDryRunClientWithOpts(dryRunOpts)
 		// As we know the return of Discovery() of the fake clientset is of type *fakediscovery.FakeDiscovery
 		// we can convert it to that struct.
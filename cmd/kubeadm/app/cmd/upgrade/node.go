@@ -28,6 +28,7 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	phases "k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/upgrade/node"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/phases/workflow"
+	cmdutil "k8s.io/kubernetes/cmd/kubeadm/app/cmd/util"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
 )
@@ -36,12 +37,13 @@ import (
 // Please note that this structure includes the public kubeadm config API, but only a subset of the options
 // supported by this api will be exposed as a flag.
 type nodeOptions struct {
-	kubeConfigPath string
-	kubeletVersion string
-	etcdUpgrade    bool
-	renewCerts     bool
-	dryRun         bool
-	kustomizeDir   string
+	kubeConfigPath   string
+	kubeletVersion   string
+	etcdUpgrade      bool
+	renewCerts       bool
+	renewKubeConfigs bool
+	dryRun           bool
+	kustomizeDir     string
 }
 
 // compile-time assert that the local data object satisfies the phases data interface.
@@ -52,6 +54,7 @@ var _ phases.Data = &nodeData{}
 type nodeData struct {
 	etcdUpgrade        bool
 	renewCerts         bool
+	renewKubeConfigs   bool
 	dryRun             bool
 	kubeletVersion     string
 	cfg                *kubeadmapi.InitConfiguration
@@ -116,6 +119,7 @@ func addUpgradeNodeFlags(flagSet *flag.FlagSet, nodeOptions *nodeOptions) {
 	flagSet.StringVar(&nodeOptions.kubeletVersion, options.KubeletVersion, nodeOptions.kubeletVersion, "The *desired* version for the kubelet config after the upgrade. If not specified, the KubernetesVersion from the kubeadm-config ConfigMap will be used")
 	flagSet.BoolVar(&nodeOptions.renewCerts, options.CertificateRenewal, nodeOptions.renewCerts, "Perform the renewal of certificates used by component changed during upgrades.")
 	flagSet.BoolVar(&nodeOptions.etcdUpgrade, options.EtcdUpgrade, nodeOptions.etcdUpgrade, "Perform the upgrade of etcd.")
+	flagSet.BoolVar(&nodeOptions.renewKubeConfigs, "renew-kubeconfigs", nodeOptions.renewKubeConfigs, "Regenerate the kubeconfig files of this control-plane node from the cluster configuration.")
 }
 
 // newNodeData returns a new nodeData struct to be used for the execution of the kubeadm upgrade node workflow.
@@ -142,10 +146,12 @@ func newNodeData(cmd *cobra.Command, args []string, options *nodeOptions) (*node
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to fetch the kubeadm-config ConfigMap")
 	}
+	cmdutil.ApplyClusterTimeouts(cfg.Timeouts)
 
 	return &nodeData{
 		etcdUpgrade:        options.etcdUpgrade,
 		renewCerts:         options.renewCerts,
+		renewKubeConfigs:   options.renewKubeConfigs,
 		dryRun:             options.dryRun,
 		kubeletVersion:     options.kubeletVersion,
 		cfg:                cfg,
@@ -170,6 +176,11 @@ func (d *nodeData) RenewCerts() bool {
 	return d.renewCerts
 }
 
+// RenewKubeConfigs returns whether the kubeconfig files of the node should be regenerated
+func (d *nodeData) RenewKubeConfigs() bool {
+	return d.renewKubeConfigs
+}
+
 // KubeletVersion returns the kubeletVersion flag.
 func (d *nodeData) KubeletVersion() string {
 	return d.kubeletVersion
@@ -35,6 +35,10 @@ import (
 
 type planFlags struct {
 	*applyPlanFlags
+
+	// versionFile is a local release metadata file version labels are resolved from
+	// instead of the release buckets, for air-gapped clusters
+	versionFile string
 }
 
 // NewCmdPlan returns the cobra command for `kubeadm upgrade plan`
@@ -58,6 +62,7 @@ func NewCmdPlan(apf *applyPlanFlags) *cobra.Command {
 
 	// Register the common flags for apply and plan
 	addApplyPlanFlags(cmd.Flags(), flags.applyPlanFlags)
+	cmd.Flags().StringVar(&flags.versionFile, "version-file", flags.versionFile, "Path to a local release metadata file with one \"label version\" pair per line, used to resolve version labels instead of the release buckets.")
 	return cmd
 }
 
@@ -71,12 +76,30 @@ func runPlan(flags *planFlags, userVersion string) error {
 		return err
 	}
 
+	// In offline mode all version labels resolve from the local release metadata file
+	if flags.versionFile != "" {
+		versionGetter = upgrade.NewFileVersionGetter(versionGetter, flags.versionFile)
+	}
+
 	var etcdClient etcdutil.ClusterInterrogator
 
 	// Currently this is the only method we have for distinguishing
 	// external etcd vs static pod etcd
 	isExternalEtcd := cfg.Etcd.External != nil
 	if isExternalEtcd {
+		// Validate the external etcd cluster and print the per-endpoint results
+		statuses, validateErr := etcdutil.ValidateExternalEtcd(cfg.Etcd.External)
+		for _, status := range statuses {
+			if status.Error != "" {
+				fmt.Printf("[upgrade/etcd] endpoint %s: %s\n", status.Endpoint, status.Error)
+			} else {
+				fmt.Printf("[upgrade/etcd] endpoint %s: version %s, cluster ID %s\n", status.Endpoint, status.Version, status.ClusterID)
+			}
+		}
+		if validateErr != nil {
+			return errors.Wrap(validateErr, "[upgrade/etcd] FATAL")
+		}
+
 		etcdClient, err = etcdutil.New(
 			cfg.Etcd.External.Endpoints,
 			cfg.Etcd.External.CAFile,
@@ -98,13 +121,13 @@ func runPlan(flags *planFlags, userVersion string) error {
 	}
 
 	// Tell the user which upgrades are available
-	printAvailableUpgrades(availUpgrades, os.Stdout, isExternalEtcd)
+	printAvailableUpgrades(availUpgrades, os.Stdout, isExternalEtcd, cfg.Proxy != nil && cfg.Proxy.Disabled)
 	return nil
 }
 
 // printAvailableUpgrades prints a UX-friendly overview of what versions are available to upgrade to
 // TODO look into columnize or some other formatter when time permits instead of using the tabwriter
-func printAvailableUpgrades(upgrades []upgrade.Upgrade, w io.Writer, isExternalEtcd bool) {
+func printAvailableUpgrades(upgrades []upgrade.Upgrade, w io.Writer, isExternalEtcd, isProxyDisabled bool) {
 
 	// Return quickly if no upgrades can be made
 	if len(upgrades) == 0 {
@@ -170,10 +193,20 @@ func printAvailableUpgrades(upgrades []upgrade.Upgrade, w io.Writer, isExternalE
 		fmt.Fprintf(tabw, "API Server\t%s\t%s\n", upgrade.Before.KubeVersion, upgrade.After.KubeVersion)
 		fmt.Fprintf(tabw, "Controller Manager\t%s\t%s\n", upgrade.Before.KubeVersion, upgrade.After.KubeVersion)
 		fmt.Fprintf(tabw, "Scheduler\t%s\t%s\n", upgrade.Before.KubeVersion, upgrade.After.KubeVersion)
-		fmt.Fprintf(tabw, "Kube Proxy\t%s\t%s\n", upgrade.Before.KubeVersion, upgrade.After.KubeVersion)
+		if isProxyDisabled {
+			// The kube-proxy add-on is disabled in the cluster configuration and is not managed by kubeadm
+			fmt.Fprintf(tabw, "Kube Proxy\tdisabled\tdisabled\n")
+		} else {
+			fmt.Fprintf(tabw, "Kube Proxy\t%s\t%s\n", upgrade.Before.KubeVersion, upgrade.After.KubeVersion)
+		}
 
 		// TODO There is currently no way to cleanly output upgrades that involve adding, removing, or changing components
 		// https://github.com/kubernetes/kubeadm/issues/810 was created to track addressing this.
+		if upgrade.After.DNSType == kubeadmapi.ExternalDNS {
+			// The DNS add-on is managed outside of kubeadm and is not upgraded by it
+			fmt.Fprintf(tabw, "DNS\t%s\texternally managed\n", upgrade.Before.DNSVersion)
+		}
+
 		printCoreDNS, printKubeDNS := false, false
 		coreDNSBeforeVersion, coreDNSAfterVersion, kubeDNSBeforeVersion, kubeDNSAfterVersion := "", "", "", ""
 
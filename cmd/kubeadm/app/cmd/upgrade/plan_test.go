@@ -74,6 +74,7 @@ func TestPrintAvailableUpgrades(t *testing.T) {
 		buf           *bytes.Buffer
 		expectedBytes []byte
 		externalEtcd  bool
+		proxyDisabled bool
 	}{
 		{
 			name:     "Up to date",
@@ -134,6 +135,55 @@ Note: Before you can perform this upgrade, you have to update kubeadm to v1.8.3.
 
 _____________________________________________________________________
 
+`),
+		},
+		{
+			name:          "patch version available and the kube-proxy addon is disabled",
+			proxyDisabled: true,
+			upgrades: []upgrade.Upgrade{
+				{
+					Description: "version in the v1.8 series",
+					Before: upgrade.ClusterState{
+						KubeVersion: "v1.8.1",
+						KubeletVersions: map[string]uint16{
+							"v1.8.1": 1,
+						},
+						KubeadmVersion: "v1.8.2",
+						DNSType:        kubeadmapi.KubeDNS,
+						DNSVersion:     "1.14.5",
+						EtcdVersion:    "3.0.17",
+					},
+					After: upgrade.ClusterState{
+						KubeVersion:    "v1.8.3",
+						KubeadmVersion: "v1.8.3",
+						DNSType:        kubeadmapi.KubeDNS,
+						DNSVersion:     "1.14.5",
+						EtcdVersion:    "3.0.17",
+					},
+				},
+			},
+			expectedBytes: []byte(`Components that must be upgraded manually after you have upgraded the control plane with 'kubeadm upgrade apply':
+COMPONENT   CURRENT      AVAILABLE
+Kubelet     1 x v1.8.1   v1.8.3
+
+Upgrade to the latest version in the v1.8 series:
+
+COMPONENT            CURRENT    AVAILABLE
+API Server           v1.8.1     v1.8.3
+Controller Manager   v1.8.1     v1.8.3
+Scheduler            v1.8.1     v1.8.3
+Kube Proxy           disabled   disabled
+Kube DNS             1.14.5     1.14.5
+Etcd                 3.0.17     3.0.17
+
+You can now apply the upgrade by executing the following command:
+
+	kubeadm upgrade apply v1.8.3
+
+Note: Before you can perform this upgrade, you have to update kubeadm to v1.8.3.
+
+_____________________________________________________________________
+
 `),
 		},
 		{
@@ -613,7 +663,7 @@ _____________________________________________________________________
 	for _, rt := range tests {
 		t.Run(rt.name, func(t *testing.T) {
 			rt.buf = bytes.NewBufferString("")
-			printAvailableUpgrades(rt.upgrades, rt.buf, rt.externalEtcd)
+			printAvailableUpgrades(rt.upgrades, rt.buf, rt.externalEtcd, rt.proxyDisabled)
 			actualBytes := rt.buf.Bytes()
 			if !bytes.Equal(actualBytes, rt.expectedBytes) {
 				t.Errorf(
@@ -23,8 +23,11 @@ import (
 
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/options"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
 )
 
 // SubCmdRunE returns a function that handles a case where a subcommand must be specified
@@ -87,3 +90,18 @@ func AddCRISocketFlag(flagSet *pflag.FlagSet, criSocket *string) {
 		"Path to the CRI socket to connect. If empty kubeadm will try to auto-detect this value; use this option only if you have more than one CRI installed or if you have non-standard CRI socket.",
 	)
 }
+
+// ApplyClusterTimeouts propagates the configured timeouts into the subsystems whose
+// waits are governed by package level settings. It must run wherever the cluster
+// configuration becomes available - init, join and upgrade all call it.
+func ApplyClusterTimeouts(timeouts *kubeadmapi.Timeouts) {
+	if timeouts == nil {
+		return
+	}
+	if timeouts.EtcdAPICall != nil {
+		etcdutil.SetRequestTimeout(timeouts.EtcdAPICall.Duration)
+	}
+	if timeouts.KubeletHealthCheck != nil {
+		apiclient.SetKubeletHealthCheckTimeout(timeouts.KubeletHealthCheck.Duration)
+	}
+}
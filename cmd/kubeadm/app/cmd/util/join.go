@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcertutil "k8s.io/client-go/util/cert"
+	outputapiv1alpha1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/output/v1alpha1"
 	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/pubkeypin"
 )
@@ -47,17 +48,20 @@ func GetJoinControlPlaneCommand(kubeConfigFile, token, key string, skipTokenPrin
 	return getJoinCommand(kubeConfigFile, token, key, true, skipTokenPrint, skipCertificateKeyPrint)
 }
 
-func getJoinCommand(kubeConfigFile, token, key string, controlPlane, skipTokenPrint, skipCertificateKeyPrint bool) (string, error) {
+// GetJoinParameters returns the parameters needed to join a node to the cluster
+// (the current cluster in the kubeconfig file) as a versioned output API object,
+// suitable for -o json|yaml consumption by automation.
+func GetJoinParameters(kubeConfigFile, token, key string, controlPlane bool) (*outputapiv1alpha1.JoinCommand, error) {
 	// load the kubeconfig file to get the CA certificate and endpoint
 	config, err := clientcmd.LoadFromFile(kubeConfigFile)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to load kubeconfig")
+		return nil, errors.Wrap(err, "failed to load kubeconfig")
 	}
 
 	// load the default cluster config
 	clusterConfig := kubeconfigutil.GetClusterFromKubeConfig(config)
 	if clusterConfig == nil {
-		return "", errors.New("failed to get default cluster config")
+		return nil, errors.New("failed to get default cluster config")
 	}
 
 	// load CA certificates from the kubeconfig (either from PEM data or by file path)
@@ -65,15 +69,15 @@ func getJoinCommand(kubeConfigFile, token, key string, controlPlane, skipTokenPr
 	if clusterConfig.CertificateAuthorityData != nil {
 		caCerts, err = clientcertutil.ParseCertsPEM(clusterConfig.CertificateAuthorityData)
 		if err != nil {
-			return "", errors.Wrap(err, "failed to parse CA certificate from kubeconfig")
+			return nil, errors.Wrap(err, "failed to parse CA certificate from kubeconfig")
 		}
 	} else if clusterConfig.CertificateAuthority != "" {
 		caCerts, err = clientcertutil.CertsFromFile(clusterConfig.CertificateAuthority)
 		if err != nil {
-			return "", errors.Wrap(err, "failed to load CA certificate referenced by kubeconfig")
+			return nil, errors.Wrap(err, "failed to load CA certificate referenced by kubeconfig")
 		}
 	} else {
-		return "", errors.New("no CA certificates found in kubeconfig")
+		return nil, errors.New("no CA certificates found in kubeconfig")
 	}
 
 	// hash all the CA certs and include their public key pins as trusted values
@@ -82,10 +86,28 @@ func getJoinCommand(kubeConfigFile, token, key string, controlPlane, skipTokenPr
 		publicKeyPins = append(publicKeyPins, pubkeypin.Hash(caCert))
 	}
 
+	joinCommand := &outputapiv1alpha1.JoinCommand{
+		Token:             token,
+		APIServerEndpoint: strings.Replace(clusterConfig.Server, "https://", "", -1),
+		CACertHashes:      publicKeyPins,
+		ControlPlane:      controlPlane,
+	}
+	if controlPlane {
+		joinCommand.CertificateKey = key
+	}
+	return joinCommand, nil
+}
+
+func getJoinCommand(kubeConfigFile, token, key string, controlPlane, skipTokenPrint, skipCertificateKeyPrint bool) (string, error) {
+	joinParameters, err := GetJoinParameters(kubeConfigFile, token, key, controlPlane)
+	if err != nil {
+		return "", err
+	}
+
 	ctx := map[string]interface{}{
-		"Token":                token,
-		"CAPubKeyPins":         publicKeyPins,
-		"ControlPlaneHostPort": strings.Replace(clusterConfig.Server, "https://", "", -1),
+		"Token":                joinParameters.Token,
+		"CAPubKeyPins":         joinParameters.CACertHashes,
+		"ControlPlaneHostPort": joinParameters.APIServerEndpoint,
 		"CertificateKey":       key,
 		"ControlPlane":         controlPlane,
 	}
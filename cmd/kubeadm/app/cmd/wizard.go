@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/lithammer/dedent"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	kubeadmscheme "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+)
+
+// wizardQuestion describes one decision the wizard asks about
+type wizardQuestion struct {
+	prompt       string
+	defaultValue string
+	apply        func(cfg *kubeadmapiv1beta2.ClusterConfiguration, answer string)
+}
+
+// NewCmdConfigWizard returns the "kubeadm config wizard" command
+func NewCmdConfigWizard(in io.Reader, out io.Writer) *cobra.Command {
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "wizard",
+		Short: "Interactively answer the key cluster decisions and emit a kubeadm config file",
+		Long: dedent.Dedent(`
+			This command walks through the key decisions of setting up a cluster (Kubernetes
+			version, networking, control plane endpoint, DNS add-on) by prompting for each of
+			them, and emits the resulting configuration as a file that can be passed to
+			'kubeadm init --config'. Pressing enter accepts the shown default.
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigWizard(in, out, outputFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFile, "output", "", "Write the generated configuration to this file instead of stdout.")
+	return cmd
+}
+
+// runConfigWizard asks the questions and renders the configuration
+func runConfigWizard(in io.Reader, out io.Writer, outputFile string) error {
+	clusterCfg := &kubeadmapiv1beta2.ClusterConfiguration{}
+	kubeadmscheme.Scheme.Default(clusterCfg)
+
+	questions := []wizardQuestion{
+		{
+			prompt:       "Kubernetes version to deploy",
+			defaultValue: clusterCfg.KubernetesVersion,
+			apply: func(cfg *kubeadmapiv1beta2.ClusterConfiguration, answer string) {
+				cfg.KubernetesVersion = answer
+			},
+		},
+		{
+			prompt:       "Service subnet CIDR",
+			defaultValue: clusterCfg.Networking.ServiceSubnet,
+			apply: func(cfg *kubeadmapiv1beta2.ClusterConfiguration, answer string) {
+				cfg.Networking.ServiceSubnet = answer
+			},
+		},
+		{
+			prompt:       "Pod subnet CIDR (empty when the CNI plugin brings its own)",
+			defaultValue: clusterCfg.Networking.PodSubnet,
+			apply: func(cfg *kubeadmapiv1beta2.ClusterConfiguration, answer string) {
+				cfg.Networking.PodSubnet = answer
+			},
+		},
+		{
+			prompt:       "Control plane endpoint for HA setups (host:port, empty for single control-plane)",
+			defaultValue: clusterCfg.ControlPlaneEndpoint,
+			apply: func(cfg *kubeadmapiv1beta2.ClusterConfiguration, answer string) {
+				cfg.ControlPlaneEndpoint = answer
+			},
+		},
+		{
+			prompt:       "Image repository to pull control plane images from",
+			defaultValue: clusterCfg.ImageRepository,
+			apply: func(cfg *kubeadmapiv1beta2.ClusterConfiguration, answer string) {
+				cfg.ImageRepository = answer
+			},
+		},
+	}
+
+	reader := bufio.NewReader(in)
+	for _, question := range questions {
+		fmt.Fprintf(out, "%s [%s]: ", question.prompt, question.defaultValue)
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			// stdin is exhausted; keep the defaults for the remaining questions
+			break
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			answer = question.defaultValue
+		}
+		question.apply(clusterCfg, answer)
+	}
+
+	b, err := configutil.MarshalKubeadmConfigObject(clusterCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the generated configuration")
+	}
+
+	if outputFile != "" {
+		if err := ioutil.WriteFile(outputFile, b, 0644); err != nil {
+			return errors.Wrapf(err, "failed to write the generated configuration to %q", outputFile)
+		}
+		fmt.Fprintf(out, "Wrote the configuration to %q; use it with 'kubeadm init --config %s'\n", outputFile, outputFile)
+		return nil
+	}
+
+	fmt.Fprintf(out, "\n%s", b)
+	return nil
+}
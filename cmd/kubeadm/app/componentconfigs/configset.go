@@ -21,11 +21,13 @@ import (
 
 	"github.com/pkg/errors"
 
+	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
 
@@ -34,9 +36,10 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 )
 
-// handler is a package internal type that handles component config factory and common functionality.
-// Every component config group should have exactly one static instance of handler.
-type handler struct {
+// Handler handles component config factory and common functionality.
+// Every component config group should have exactly one static instance of Handler,
+// registered with RegisterHandler.
+type Handler struct {
 	// GroupVersion holds this handler's group name and preferred version
 	GroupVersion schema.GroupVersion
 
@@ -46,15 +49,28 @@ type handler struct {
 	// CreateEmpty returns an empty kubeadmapi.ComponentConfig (not even defaulted)
 	CreateEmpty func() kubeadmapi.ComponentConfig
 
+	// GetConfigMapName returns the name of the ConfigMap where this component config is
+	// stored for a cluster of the given Kubernetes version
+	GetConfigMapName func(k8sVersion *version.Version) string
+
+	// ConfigMapKey is the key under which the config document is stored in its ConfigMap
+	ConfigMapKey string
+
 	// fromCluster should load the component config from a config map on the cluster.
+	// It can be left unset, in which case a generic implementation based on
+	// GetConfigMapName and ConfigMapKey is used.
 	// Don't use this directly! Use FromCluster instead!
-	fromCluster func(*handler, clientset.Interface, *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error)
+	fromCluster func(*Handler, clientset.Interface, *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error)
+
+	// skipGenericUpload is set on built-in handlers whose configs are uploaded by their
+	// own dedicated phases (kubelet, kube-proxy) and must not be uploaded by Upload
+	skipGenericUpload bool
 }
 
 // FromDocumentMap looks in the document map for documents with this handler's group.
 // If such are found a new component config is instantiated and the documents are loaded into it.
 // No error is returned if no documents are found.
-func (h *handler) FromDocumentMap(docmap kubeadmapi.DocumentMap) (kubeadmapi.ComponentConfig, error) {
+func (h *Handler) FromDocumentMap(docmap kubeadmapi.DocumentMap) (kubeadmapi.ComponentConfig, error) {
 	for gvk := range docmap {
 		if gvk.Group == h.GroupVersion.Group {
 			cfg := h.CreateEmpty()
@@ -69,7 +85,7 @@ func (h *handler) FromDocumentMap(docmap kubeadmapi.DocumentMap) (kubeadmapi.Com
 
 // fromConfigMap is an utility function, which will load the value of a key of a config map and use h.FromDocumentMap() to perform the parsing
 // This is an utility func. Used by the component config support implementations. Don't use it outside of that context.
-func (h *handler) fromConfigMap(client clientset.Interface, cmName, cmKey string, mustExist bool) (kubeadmapi.ComponentConfig, error) {
+func (h *Handler) fromConfigMap(client clientset.Interface, cmName, cmKey string, mustExist bool) (kubeadmapi.ComponentConfig, error) {
 	configMap, err := apiclient.GetConfigMapWithRetry(client, metav1.NamespaceSystem, cmName)
 	if err != nil {
 		if !mustExist && (apierrors.IsNotFound(err) || apierrors.IsForbidden(err)) {
@@ -93,19 +109,34 @@ func (h *handler) fromConfigMap(client clientset.Interface, cmName, cmKey string
 }
 
 // FromCluster loads a component from a config map in the cluster
-func (h *handler) FromCluster(clientset clientset.Interface, clusterCfg *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error) {
-	return h.fromCluster(h, clientset, clusterCfg)
+func (h *Handler) FromCluster(clientset clientset.Interface, clusterCfg *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error) {
+	if h.fromCluster != nil {
+		return h.fromCluster(h, clientset, clusterCfg)
+	}
+	return genericConfigFromCluster(h, clientset, clusterCfg)
+}
+
+// genericConfigFromCluster loads a component config from the ConfigMap described by the
+// handler's GetConfigMapName and ConfigMapKey. It is used for all registered component
+// configs that don't provide their own fromCluster implementation.
+func genericConfigFromCluster(h *Handler, clientset clientset.Interface, clusterCfg *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error) {
+	k8sVersion, err := version.ParseGeneric(clusterCfg.KubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.fromConfigMap(clientset, h.GetConfigMapName(k8sVersion), h.ConfigMapKey, false)
 }
 
 // Marshal is an utility function, used by the component config support implementations to marshal a runtime.Object to YAML with the
 // correct group and version
-func (h *handler) Marshal(object runtime.Object) ([]byte, error) {
+func (h *Handler) Marshal(object runtime.Object) ([]byte, error) {
 	return kubeadmutil.MarshalToYamlForCodecs(object, h.GroupVersion, Codecs)
 }
 
 // Unmarshal attempts to unmarshal a runtime.Object from a document map. If no object is found, no error is returned.
 // If a matching group is found, but no matching version an error is returned indicating that users should do manual conversion.
-func (h *handler) Unmarshal(from kubeadmapi.DocumentMap, into runtime.Object) error {
+func (h *Handler) Unmarshal(from kubeadmapi.DocumentMap, into runtime.Object) error {
 	for gvk, yaml := range from {
 		// If this is a different group, we ignore it
 		if gvk.Group != h.GroupVersion.Group {
@@ -125,10 +156,37 @@ func (h *handler) Unmarshal(from kubeadmapi.DocumentMap, into runtime.Object) er
 	return nil
 }
 
-// known holds the known component config handlers. Add new component configs here.
-var known = []*handler{
-	&kubeProxyHandler,
-	&kubeletHandler,
+// known holds the registered component config handlers, keyed by config group.
+// The built-in kubelet and kube-proxy handlers are registered in init funcs of this package.
+var known = map[string]*Handler{}
+
+// RegisterHandler adds a component config handler to the registry, making kubeadm default,
+// validate, upload and download the respective component config alongside the built-in ones.
+// Out-of-tree components must call this before the kubeadm scheme is used, i.e. from an
+// init func, and the handler's group must not be registered already.
+func RegisterHandler(h *Handler) error {
+	group := h.GroupVersion.Group
+	if _, ok := known[group]; ok {
+		return errors.Errorf("there is already a component config handler for group %q", group)
+	}
+	known[group] = h
+	return h.AddToScheme(Scheme)
+}
+
+// knownHandlers returns the registered handlers sorted by group, so that all operations
+// over the registry are predictable.
+func knownHandlers() []*Handler {
+	groups := []string{}
+	for group := range known {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	handlers := []*Handler{}
+	for _, group := range groups {
+		handlers = append(handlers, known[group])
+	}
+	return handlers
 }
 
 // ensureInitializedComponentConfigs is an utility func to initialize the ComponentConfigMap in ClusterConfiguration prior to possible writes to it
@@ -142,7 +200,7 @@ func ensureInitializedComponentConfigs(clusterCfg *kubeadmapi.ClusterConfigurati
 func Default(clusterCfg *kubeadmapi.ClusterConfiguration, localAPIEndpoint *kubeadmapi.APIEndpoint) {
 	ensureInitializedComponentConfigs(clusterCfg)
 
-	for _, handler := range known {
+	for _, handler := range knownHandlers() {
 		// If the component config exists, simply default it. Otherwise, create it before defaulting.
 		group := handler.GroupVersion.Group
 		if componentCfg, ok := clusterCfg.ComponentConfigs[group]; ok {
@@ -159,7 +217,7 @@ func Default(clusterCfg *kubeadmapi.ClusterConfiguration, localAPIEndpoint *kube
 func FetchFromCluster(clusterCfg *kubeadmapi.ClusterConfiguration, client clientset.Interface) error {
 	ensureInitializedComponentConfigs(clusterCfg)
 
-	for _, handler := range known {
+	for _, handler := range knownHandlers() {
 		componentCfg, err := handler.FromCluster(client, clusterCfg)
 		if err != nil {
 			return err
@@ -177,7 +235,7 @@ func FetchFromCluster(clusterCfg *kubeadmapi.ClusterConfiguration, client client
 func FetchFromDocumentMap(clusterCfg *kubeadmapi.ClusterConfiguration, docmap kubeadmapi.DocumentMap) error {
 	ensureInitializedComponentConfigs(clusterCfg)
 
-	for _, handler := range known {
+	for _, handler := range knownHandlers() {
 		componentCfg, err := handler.FromDocumentMap(docmap)
 		if err != nil {
 			return err
@@ -191,6 +249,49 @@ func FetchFromDocumentMap(clusterCfg *kubeadmapi.ClusterConfiguration, docmap ku
 	return nil
 }
 
+// Upload saves the component configs of all registered handlers that are present in the
+// ClusterConfiguration to their respective ConfigMaps in the cluster. Configs that are
+// uploaded by dedicated kubeadm phases (kubelet, kube-proxy) are skipped.
+func Upload(clusterCfg *kubeadmapi.ClusterConfiguration, client clientset.Interface) error {
+	k8sVersion, err := version.ParseGeneric(clusterCfg.KubernetesVersion)
+	if err != nil {
+		return err
+	}
+
+	for _, handler := range knownHandlers() {
+		if handler.skipGenericUpload {
+			continue
+		}
+
+		componentCfg, ok := clusterCfg.ComponentConfigs[handler.GroupVersion.Group]
+		if !ok {
+			continue
+		}
+
+		yaml, err := componentCfg.Marshal()
+		if err != nil {
+			return err
+		}
+
+		configMapName := handler.GetConfigMapName(k8sVersion)
+		klog.V(1).Infof("uploading the %s component config in ConfigMap %q", handler.GroupVersion.Group, configMapName)
+		err = apiclient.CreateOrUpdateConfigMap(client, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName,
+				Namespace: metav1.NamespaceSystem,
+			},
+			Data: map[string]string{
+				handler.ConfigMapKey: string(yaml),
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Validate is a placeholder for performing a validation on an already loaded component configs in a ClusterConfiguration
 // Currently it prints a warning that no validation was performed
 func Validate(clusterCfg *kubeadmapi.ClusterConfiguration) field.ErrorList {
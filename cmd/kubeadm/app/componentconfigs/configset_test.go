@@ -24,6 +24,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/version"
 	clientsetfake "k8s.io/client-go/kubernetes/fake"
 
@@ -87,6 +88,35 @@ func TestFromCluster(t *testing.T) {
 	}
 }
 
+func TestRegisterHandler(t *testing.T) {
+	// re-registering a known group must fail
+	if err := RegisterHandler(&kubeProxyHandler); err == nil {
+		t.Fatal("expected an error when re-registering an already registered group, got none")
+	}
+
+	fakeGroup := "fakecomponent.config.k8s.io"
+	fakeHandler := &Handler{
+		GroupVersion: schema.GroupVersion{Group: fakeGroup, Version: "v1alpha1"},
+		AddToScheme:  func(*runtime.Scheme) error { return nil },
+		CreateEmpty: func() kubeadmapi.ComponentConfig {
+			return nil
+		},
+		GetConfigMapName: func(_ *version.Version) string {
+			return "fakecomponent-config"
+		},
+		ConfigMapKey: "config",
+	}
+
+	if err := RegisterHandler(fakeHandler); err != nil {
+		t.Fatalf("failed to register a new component config handler: %v", err)
+	}
+	defer delete(known, fakeGroup)
+
+	if _, ok := known[fakeGroup]; !ok {
+		t.Fatalf("the handler for group %q was not added to the registry", fakeGroup)
+	}
+}
+
 func TestFetchFromDocumentMap(t *testing.T) {
 	test := dedent.Dedent(`
 	apiVersion: kubeproxy.config.k8s.io/v1alpha1
@@ -19,6 +19,7 @@ package componentconfigs
 import (
 	"path/filepath"
 
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/version"
 	clientset "k8s.io/client-go/kubernetes"
 	kubeletconfig "k8s.io/kubelet/config/v1beta1"
@@ -52,16 +53,23 @@ const (
 )
 
 // kubeletHandler is the handler instance for the kubelet component config
-var kubeletHandler = handler{
-	GroupVersion: kubeletconfig.SchemeGroupVersion,
-	AddToScheme:  kubeletconfig.AddToScheme,
+var kubeletHandler = Handler{
+	GroupVersion:     kubeletconfig.SchemeGroupVersion,
+	AddToScheme:      kubeletconfig.AddToScheme,
+	GetConfigMapName: constants.GetKubeletConfigMapName,
+	ConfigMapKey:     constants.KubeletBaseConfigurationConfigMapKey,
 	CreateEmpty: func() kubeadmapi.ComponentConfig {
 		return &kubeletConfig{}
 	},
-	fromCluster: kubeletConfigFromCluster,
+	fromCluster:       kubeletConfigFromCluster,
+	skipGenericUpload: true,
 }
 
-func kubeletConfigFromCluster(h *handler, clientset clientset.Interface, clusterCfg *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error) {
+func init() {
+	utilruntime.Must(RegisterHandler(&kubeletHandler))
+}
+
+func kubeletConfigFromCluster(h *Handler, clientset clientset.Interface, clusterCfg *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error) {
 	// Read the ConfigMap from the cluster based on what version the kubelet is
 	k8sVersion, err := version.ParseGeneric(clusterCfg.KubernetesVersion)
 	if err != nil {
@@ -173,4 +181,20 @@ func (kc *kubeletConfig) Default(cfg *kubeadmapi.ClusterConfiguration, _ *kubead
 	// We cannot show a warning for RotateCertificates==false and we must hardcode it to true.
 	// There is no way to determine if the user has set this or not, given the field is a non-pointer.
 	kc.config.RotateCertificates = kubeletRotateCertificates
+
+	// Make the kubelet request its serving certificate via CSRs when the feature gate is
+	// enabled; the CSRs have to be approved by an approver integration or manually
+	if features.Enabled(cfg.FeatureGates, features.ServerTLSBootstrap) {
+		kc.config.ServerTLSBootstrap = true
+	}
+
+	// Apply the cluster wide TLS policy to the kubelet as well
+	if cfg.TLS != nil {
+		if cfg.TLS.MinVersion != "" && kc.config.TLSMinVersion == "" {
+			kc.config.TLSMinVersion = cfg.TLS.MinVersion
+		}
+		if len(cfg.TLS.CipherSuites) > 0 && len(kc.config.TLSCipherSuites) == 0 {
+			kc.config.TLSCipherSuites = cfg.TLS.CipherSuites
+		}
+	}
 }
@@ -19,6 +19,8 @@ package componentconfigs
 import (
 	"net"
 
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
 	clientset "k8s.io/client-go/kubernetes"
 	kubeproxyconfig "k8s.io/kube-proxy/config/v1alpha1"
 
@@ -37,19 +39,47 @@ const (
 )
 
 // kubeProxyHandler is the handler instance for the kube-proxy component config
-var kubeProxyHandler = handler{
+var kubeProxyHandler = Handler{
 	GroupVersion: kubeproxyconfig.SchemeGroupVersion,
 	AddToScheme:  kubeproxyconfig.AddToScheme,
+	GetConfigMapName: func(_ *version.Version) string {
+		return kubeadmconstants.KubeProxyConfigMap
+	},
+	ConfigMapKey: kubeadmconstants.KubeProxyConfigMapKey,
 	CreateEmpty: func() kubeadmapi.ComponentConfig {
 		return &kubeProxyConfig{}
 	},
-	fromCluster: kubeProxyConfigFromCluster,
+	fromCluster:       kubeProxyConfigFromCluster,
+	skipGenericUpload: true,
+}
+
+func init() {
+	utilruntime.Must(RegisterHandler(&kubeProxyHandler))
 }
 
-func kubeProxyConfigFromCluster(h *handler, clientset clientset.Interface, _ *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error) {
+func kubeProxyConfigFromCluster(h *Handler, clientset clientset.Interface, clusterCfg *kubeadmapi.ClusterConfiguration) (kubeadmapi.ComponentConfig, error) {
+	// Don't attempt to download the kube-proxy config if the add-on is disabled in the
+	// cluster configuration, as its ConfigMap is not expected to exist in that case
+	if clusterCfg.Proxy != nil && clusterCfg.Proxy.Disabled {
+		return nil, nil
+	}
 	return h.fromConfigMap(clientset, kubeadmconstants.KubeProxyConfigMap, kubeadmconstants.KubeProxyConfigMapKey, false)
 }
 
+// GetKubeProxyMode returns the proxy mode from the kube-proxy component config in the
+// passed ClusterConfiguration, or an empty string if no such config is present
+func GetKubeProxyMode(clusterCfg *kubeadmapi.ClusterConfiguration) string {
+	componentCfg, ok := clusterCfg.ComponentConfigs[KubeProxyGroup]
+	if !ok {
+		return ""
+	}
+	kubeProxyCfg, ok := componentCfg.(*kubeProxyConfig)
+	if !ok {
+		return ""
+	}
+	return string(kubeProxyCfg.config.Mode)
+}
+
 // kubeProxyConfig implements the kubeadmapi.ComponentConfig interface for kube-proxy
 type kubeProxyConfig struct {
 	config kubeproxyconfig.KubeProxyConfiguration
@@ -32,12 +32,11 @@ var Codecs = serializer.NewCodecFactory(Scheme)
 
 func init() {
 	metav1.AddToGroupVersion(Scheme, schema.GroupVersion{Version: "v1"})
-	AddToScheme(Scheme)
 }
 
 // AddToScheme builds the kubeadm ComponentConfig scheme using all known ComponentConfig versions.
 func AddToScheme(scheme *runtime.Scheme) {
-	for _, handler := range known {
+	for _, handler := range knownHandlers() {
 		utilruntime.Must(handler.AddToScheme(scheme))
 	}
 }
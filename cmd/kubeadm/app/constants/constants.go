@@ -221,12 +221,22 @@ const (
 	// ClusterStatusConfigMapKey specifies in what ConfigMap key the cluster status should be stored
 	ClusterStatusConfigMapKey = "ClusterStatus"
 
+	// NodePreauthConfigMap specifies in what ConfigMap in the kube-system namespace the pre-authorized
+	// node identities (node name to public key fingerprint) are stored
+	NodePreauthConfigMap = "kubeadm-preauthorized-nodes"
+
 	// KubeProxyConfigMap specifies in what ConfigMap in the kube-system namespace the kube-proxy configuration should be stored
 	KubeProxyConfigMap = "kube-proxy"
 
 	// KubeProxyConfigMapKey specifies in what ConfigMap key the component config of kube-proxy should be stored
 	KubeProxyConfigMapKey = "config.conf"
 
+	// KubeProxyModeIPTables is the kube-proxy mode that programs services with iptables rules
+	KubeProxyModeIPTables = "iptables"
+
+	// KubeProxyModeNFTables is the kube-proxy mode that programs services with nftables rules
+	KubeProxyModeNFTables = "nftables"
+
 	// KubeletBaseConfigurationConfigMapPrefix specifies in what ConfigMap in the kube-system namespace the initial remote configuration of kubelet should be stored
 	KubeletBaseConfigurationConfigMapPrefix = "kubelet-config-"
 
@@ -247,12 +257,73 @@ const (
 	// This directory should exist under KubeletRunDirectory
 	DynamicKubeletConfigurationDirectoryName = "dynamic-config"
 
+	// KubeletInstanceConfigurationDirectoryName specifies the directory which stores per-node kubelet configuration
+	// drop-in files that are merged over the configuration in KubeletConfigurationFileName whenever it is written
+	// This directory should exist under KubeletRunDirectory
+	KubeletInstanceConfigurationDirectoryName = "config.d"
+
+	// KubeletInstanceConfigurationFileName specifies the name of the drop-in file in which kubeadm stores the kubelet
+	// configuration fields that must differ per node
+	// This file should exist under KubeletInstanceConfigurationDirectoryName
+	KubeletInstanceConfigurationFileName = "instance-config.yaml"
+
 	// KubeletEnvFileName is a file "kubeadm init" writes at runtime. Using that interface, kubeadm can customize certain
 	// kubelet flags conditionally based on the environment at runtime. Also, parameters given to the configuration file
 	// might be passed through this file. "kubeadm init" writes one variable, with the name ${KubeletEnvFileVariableName}.
 	// This file should exist under KubeletRunDirectory
 	KubeletEnvFileName = "kubeadm-flags.env"
 
+	// EncryptionConfigurationFileName defines the file name of the EncryptionConfiguration
+	// generated for the kube-apiserver when encryption of API data at rest is enabled.
+	// This file should exist under KubernetesDir
+	EncryptionConfigurationFileName = "encryption-config.yaml"
+
+	// EncryptionConfigurationVolumeName specifies the name of the volume mounting the
+	// encryption configuration into the kube-apiserver static pod
+	EncryptionConfigurationVolumeName = "encryption-config"
+
+	// AdmissionConfigurationFileName defines the file name of the AdmissionConfiguration
+	// generated for the kube-apiserver when admission plugin configuration is declared.
+	// This file should exist under KubernetesDir
+	AdmissionConfigurationFileName = "admission-config.yaml"
+
+	// AdmissionConfigurationVolumeName specifies the name of the volume mounting the
+	// admission configuration into the kube-apiserver static pod
+	AdmissionConfigurationVolumeName = "admission-config"
+
+	// AuditPolicyFileName defines the file name of the audit policy kubeadm writes when
+	// the audit configuration declares the policy inline.
+	// This file should exist under KubernetesDir
+	AuditPolicyFileName = "audit-policy.yaml"
+
+	// AuditPolicyVolumeName specifies the name of the volume mounting the audit policy
+	// into the kube-apiserver static pod
+	AuditPolicyVolumeName = "audit-policy"
+
+	// AuditLogVolumeName specifies the name of the volume mounting the audit log
+	// directory into the kube-apiserver static pod
+	AuditLogVolumeName = "audit-log"
+
+	// AuditWebhookVolumeName specifies the name of the volume mounting the audit webhook
+	// kubeconfig into the kube-apiserver static pod
+	AuditWebhookVolumeName = "audit-webhook"
+
+	// EgressSelectorConfigurationFileName defines the file name of the egress selector
+	// configuration generated when konnectivity is enabled.
+	// This file should exist under KubernetesDir
+	EgressSelectorConfigurationFileName = "egress-selector-configuration.yaml"
+
+	// EgressSelectorConfigurationVolumeName specifies the name of the volume mounting
+	// the egress selector configuration into the kube-apiserver static pod
+	EgressSelectorConfigurationVolumeName = "egress-selector-config"
+
+	// KonnectivityServerVolumeName specifies the name of the volume mounting the
+	// konnectivity-server UDS directory into the kube-apiserver static pod
+	KonnectivityServerVolumeName = "konnectivity-uds"
+
+	// PreflightExternalChecksDir specifies the directory where external preflight check plugin executables are discovered
+	PreflightExternalChecksDir = "/etc/kubernetes/preflight.d"
+
 	// KubeletEnvFileVariableName specifies the shell script variable name "kubeadm init" should write a value to in KubeletEnvFile
 	KubeletEnvFileVariableName = "KUBELET_KUBEADM_ARGS"
 
@@ -311,6 +382,10 @@ const (
 	// KubeDNSDeploymentName specifies the name of the Deployment for kube-dns add-on
 	KubeDNSDeploymentName = "kube-dns"
 
+	// ExternallyManagedAnnotationKey marks an addon workload as managed by a tool other
+	// than kubeadm, so the addon phases leave it alone
+	ExternallyManagedAnnotationKey = "kubeadm.kubernetes.io/externally-managed"
+
 	// KubeDNSKubeDNSImageName specifies the name of the image for the kubedns container in the kube-dns add-on
 	KubeDNSKubeDNSImageName = "k8s-dns-kube-dns"
 
@@ -33,6 +33,12 @@ const (
 	CoreDNS = "CoreDNS"
 	// IPv6DualStack is expected to be alpha in v1.16
 	IPv6DualStack = "IPv6DualStack"
+	// SelfHosting pivots the control plane from static pods to workloads managed by the
+	// cluster itself at the end of init
+	SelfHosting = "SelfHosting"
+	// ServerTLSBootstrap enables serverTLSBootstrap in the generated kubelet
+	// configuration, making kubelets request their serving certificates via CSRs
+	ServerTLSBootstrap = "ServerTLSBootstrap"
 )
 
 var coreDNSMessage = "featureGates:CoreDNS has been removed in v1.13\n" +
@@ -40,8 +46,25 @@ var coreDNSMessage = "featureGates:CoreDNS has been removed in v1.13\n" +
 
 // InitFeatureGates are the default feature gates for the init command
 var InitFeatureGates = FeatureList{
-	CoreDNS:       {FeatureSpec: featuregate.FeatureSpec{Default: true, PreRelease: featuregate.Deprecated}, HiddenInHelpText: true, DeprecationMessage: coreDNSMessage},
-	IPv6DualStack: {FeatureSpec: featuregate.FeatureSpec{Default: false, PreRelease: featuregate.Alpha}},
+	CoreDNS: {
+		FeatureSpec:            featuregate.FeatureSpec{Default: true, PreRelease: featuregate.Deprecated, LockToDefault: true},
+		HiddenInHelpText:       true,
+		DeprecationMessage:     coreDNSMessage,
+		Description:            "Installs CoreDNS instead of kube-dns as the cluster DNS add-on",
+		DeprecatedSinceVersion: version.MustParseSemantic("v1.13.0"),
+	},
+	IPv6DualStack: {
+		FeatureSpec: featuregate.FeatureSpec{Default: false, PreRelease: featuregate.Alpha},
+		Description: "Enables dual-stack (IPv4 and IPv6) networking support",
+	},
+	SelfHosting: {
+		FeatureSpec: featuregate.FeatureSpec{Default: false, PreRelease: featuregate.Alpha},
+		Description: "Pivots the control plane from static Pods to self-hosted DaemonSets after init, bootstrapped by the static Pods",
+	},
+	ServerTLSBootstrap: {
+		FeatureSpec: featuregate.FeatureSpec{Default: false, PreRelease: featuregate.Alpha},
+		Description: "Makes kubelets request their serving certificates via CSRs; the CSRs must be approved, e.g. with 'kubeadm alpha certs approve-kubelet-serving-csrs'",
+	},
 }
 
 // Feature represents a feature being gated
@@ -50,6 +73,16 @@ type Feature struct {
 	MinimumVersion     *version.Version
 	HiddenInHelpText   bool
 	DeprecationMessage string
+
+	// Description explains what the gate controls, surfaced by
+	// 'kubeadm config print feature-gates'
+	Description string
+
+	// DeprecatedSinceVersion is the release the gate was deprecated in, if any
+	DeprecatedSinceVersion *version.Version
+
+	// RemovalVersion is the release the gate is scheduled to be removed in, if any
+	RemovalVersion *version.Version
 }
 
 // FeatureList represents a list of feature gates
@@ -152,6 +185,10 @@ func NewFeatureGate(f *FeatureList, value string) (map[string]bool, error) {
 		if err != nil {
 			return nil, errors.Errorf("invalid value %v for feature-gate key: %s, use true|false instead", v, k)
 		}
+
+		if featureSpec.LockToDefault && boolValue != featureSpec.Default {
+			return nil, errors.Errorf("feature-gate key is locked to its default value %t and cannot be toggled: %s", featureSpec.Default, k)
+		}
 		featureGate[k] = boolValue
 	}
 
@@ -180,3 +217,61 @@ func CheckDeprecatedFlags(f *FeatureList, features map[string]bool) map[string]s
 
 	return deprecatedMsg
 }
+
+// GateInfo is the machine readable description of a feature gate, as printed by
+// 'kubeadm config print feature-gates'
+type GateInfo struct {
+	// Name of the feature gate
+	Name string `json:"name"`
+
+	// Maturity is the pre-release stage of the gate: ALPHA, BETA, GA or DEPRECATED
+	Maturity string `json:"maturity"`
+
+	// Default is the value used when the gate is not set explicitly
+	Default bool `json:"default"`
+
+	// Locked is true when the gate cannot be toggled away from its default
+	Locked bool `json:"locked"`
+
+	// Description explains what the gate controls
+	Description string `json:"description,omitempty"`
+
+	// MinimumVersion is the oldest Kubernetes version the gate may be used with
+	MinimumVersion string `json:"minimumVersion,omitempty"`
+
+	// DeprecatedSince is the release the gate was deprecated in
+	DeprecatedSince string `json:"deprecatedSince,omitempty"`
+
+	// RemovalVersion is the release the gate is scheduled to be removed in
+	RemovalVersion string `json:"removalVersion,omitempty"`
+}
+
+// ListGates returns the machine readable descriptions of all gates in the feature list,
+// sorted by name
+func ListGates(f *FeatureList) []GateInfo {
+	gates := []GateInfo{}
+	for name, feature := range *f {
+		gate := GateInfo{
+			Name:        name,
+			Maturity:    string(feature.PreRelease),
+			Default:     feature.Default,
+			Locked:      feature.LockToDefault,
+			Description: feature.Description,
+		}
+		if feature.PreRelease == featuregate.GA {
+			gate.Maturity = "GA"
+		}
+		if feature.MinimumVersion != nil {
+			gate.MinimumVersion = feature.MinimumVersion.String()
+		}
+		if feature.DeprecatedSinceVersion != nil {
+			gate.DeprecatedSince = feature.DeprecatedSinceVersion.String()
+		}
+		if feature.RemovalVersion != nil {
+			gate.RemovalVersion = feature.RemovalVersion.String()
+		}
+		gates = append(gates, gate)
+	}
+	sort.Slice(gates, func(i, j int) bool { return gates[i].Name < gates[j].Name })
+	return gates
+}
@@ -217,3 +217,38 @@ func TestCheckDeprecatedFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFeatureGateLockedGates(t *testing.T) {
+	var someFeatures = FeatureList{
+		"locked": {FeatureSpec: featuregate.FeatureSpec{Default: true, PreRelease: featuregate.GA, LockToDefault: true}},
+	}
+
+	// setting a locked gate to its default is accepted
+	if _, err := NewFeatureGate(&someFeatures, "locked=true"); err != nil {
+		t.Errorf("unexpected error for a locked gate set to its default: %v", err)
+	}
+
+	// toggling a locked gate away from its default is rejected
+	if _, err := NewFeatureGate(&someFeatures, "locked=false"); err == nil {
+		t.Error("expected an error when toggling a locked gate, got none")
+	}
+}
+
+func TestListGates(t *testing.T) {
+	var someFeatures = FeatureList{
+		"beta":  {FeatureSpec: featuregate.FeatureSpec{Default: false, PreRelease: featuregate.Beta}, Description: "a beta feature"},
+		"alpha": {FeatureSpec: featuregate.FeatureSpec{Default: false, PreRelease: featuregate.Alpha}},
+	}
+
+	gates := ListGates(&someFeatures)
+	if len(gates) != 2 {
+		t.Fatalf("expected 2 gates, got %d", len(gates))
+	}
+	// the list must be sorted by name
+	if gates[0].Name != "alpha" || gates[1].Name != "beta" {
+		t.Errorf("unexpected gate order: %v", gates)
+	}
+	if gates[1].Maturity != "BETA" || gates[1].Description != "a beta feature" {
+		t.Errorf("unexpected gate info: %+v", gates[1])
+	}
+}
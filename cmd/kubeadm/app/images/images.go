@@ -41,7 +41,49 @@ func GetKubernetesImage(image string, cfg *kubeadmapi.ClusterConfiguration) stri
 	}
 	repoPrefix := cfg.GetControlPlaneImageRepository()
 	kubernetesImageTag := kubeadmutil.KubernetesVersionToImageTag(cfg.KubernetesVersion)
-	return GetGenericImage(repoPrefix, image, kubernetesImageTag)
+
+	// honor the per-component image overrides
+	if override := componentImageMeta(image, cfg); override != nil {
+		if override.ImageRepository != "" {
+			repoPrefix = override.ImageRepository
+		}
+		if override.ImageTag != "" {
+			kubernetesImageTag = override.ImageTag
+		}
+	}
+	return applyRegistryMirrors(cfg, GetGenericImage(repoPrefix, image, kubernetesImageTag))
+}
+
+// applyRegistryMirrors rewrites the registry of the image when a mirror is configured
+// for it, so kubeadm pulls from the mirror without manual runtime configuration
+func applyRegistryMirrors(cfg *kubeadmapi.ClusterConfiguration, image string) string {
+	if cfg.ImagePull == nil || len(cfg.ImagePull.Mirrors) == 0 {
+		return image
+	}
+	registry, repository, tag := SplitImageName(image)
+	mirror, ok := cfg.ImagePull.Mirrors[registry]
+	if !ok {
+		return image
+	}
+	return GetGenericImage(mirror, repository, tag)
+}
+
+// componentImageMeta returns the image customization for the named component, or nil
+// when the component has none
+func componentImageMeta(image string, cfg *kubeadmapi.ClusterConfiguration) *kubeadmapi.ImageMeta {
+	switch image {
+	case constants.KubeAPIServer:
+		return &cfg.APIServer.ImageMeta
+	case constants.KubeControllerManager:
+		return &cfg.ControllerManager.ImageMeta
+	case constants.KubeScheduler:
+		return &cfg.Scheduler.ImageMeta
+	case constants.KubeProxy:
+		if cfg.Proxy != nil {
+			return &cfg.Proxy.ImageMeta
+		}
+	}
+	return nil
 }
 
 // GetDNSImage generates and returns the image for the DNS, that can be CoreDNS or kube-dns.
@@ -60,7 +102,7 @@ func GetDNSImage(cfg *kubeadmapi.ClusterConfiguration, imageName string) string
 	if cfg.DNS.ImageTag != "" {
 		dnsImageTag = cfg.DNS.ImageTag
 	}
-	return GetGenericImage(dnsImageRepository, imageName, dnsImageTag)
+	return applyRegistryMirrors(cfg, GetGenericImage(dnsImageRepository, imageName, dnsImageTag))
 }
 
 // GetEtcdImage generates and returns the image for etcd
@@ -81,12 +123,22 @@ func GetEtcdImage(cfg *kubeadmapi.ClusterConfiguration) string {
 	if cfg.Etcd.Local != nil && cfg.Etcd.Local.ImageTag != "" {
 		etcdImageTag = cfg.Etcd.Local.ImageTag
 	}
-	return GetGenericImage(etcdImageRepository, constants.Etcd, etcdImageTag)
+	return applyRegistryMirrors(cfg, GetGenericImage(etcdImageRepository, constants.Etcd, etcdImageTag))
 }
 
 // GetPauseImage returns the image for the "pause" container
 func GetPauseImage(cfg *kubeadmapi.ClusterConfiguration) string {
-	return GetGenericImage(cfg.ImageRepository, "pause", constants.PauseVersion)
+	pauseImageRepository := cfg.ImageRepository
+	pauseImageTag := constants.PauseVersion
+	if cfg.PauseImage != nil {
+		if cfg.PauseImage.ImageRepository != "" {
+			pauseImageRepository = cfg.PauseImage.ImageRepository
+		}
+		if cfg.PauseImage.ImageTag != "" {
+			pauseImageTag = cfg.PauseImage.ImageTag
+		}
+	}
+	return applyRegistryMirrors(cfg, GetGenericImage(pauseImageRepository, "pause", pauseImageTag))
 }
 
 // GetControlPlaneImages returns a list of container images kubeadm expects to use on a control plane node
@@ -112,10 +164,10 @@ func GetControlPlaneImages(cfg *kubeadmapi.ClusterConfiguration) []string {
 		imgs = append(imgs, GetEtcdImage(cfg))
 	}
 
-	// Append the appropriate DNS images
+	// Append the appropriate DNS images, unless the DNS add-on is managed externally
 	if cfg.DNS.Type == kubeadmapi.CoreDNS {
 		imgs = append(imgs, GetDNSImage(cfg, constants.CoreDNSImageName))
-	} else {
+	} else if cfg.DNS.Type == kubeadmapi.KubeDNS {
 		imgs = append(imgs, GetDNSImage(cfg, constants.KubeDNSKubeDNSImageName))
 		imgs = append(imgs, GetDNSImage(cfg, constants.KubeDNSSidecarImageName))
 		imgs = append(imgs, GetDNSImage(cfg, constants.KubeDNSDnsMasqNannyImageName))
@@ -264,3 +264,40 @@ func TestGetAllImages(t *testing.T) {
 		})
 	}
 }
+
+func TestGetKubernetesImageWithOverrides(t *testing.T) {
+	cfg := &kubeadmapi.ClusterConfiguration{
+		ImageRepository:   "real.repo",
+		KubernetesVersion: testversion,
+		APIServer: kubeadmapi.APIServer{
+			ControlPlaneComponent: kubeadmapi.ControlPlaneComponent{
+				ImageMeta: kubeadmapi.ImageMeta{ImageRepository: "other.repo", ImageTag: "override"},
+			},
+		},
+	}
+
+	actualImage := GetKubernetesImage(constants.KubeAPIServer, cfg)
+	expectedImage := "other.repo/kube-apiserver:override"
+	if actualImage != expectedImage {
+		t.Errorf("failed GetKubernetesImage with overrides:\n\texpected: %s\n\t  actual: %s", expectedImage, actualImage)
+	}
+
+	// components without an override keep using the cluster-wide repository
+	actualImage = GetKubernetesImage(constants.KubeScheduler, cfg)
+	if actualImage != GetGenericImage("real.repo", constants.KubeScheduler, expected) {
+		t.Errorf("unexpected image for a component without overrides: %s", actualImage)
+	}
+}
+
+func TestGetPauseImageWithOverrides(t *testing.T) {
+	cfg := &kubeadmapi.ClusterConfiguration{
+		ImageRepository: "real.repo",
+		PauseImage:      &kubeadmapi.ImageMeta{ImageRepository: "other.repo", ImageTag: "3.42"},
+	}
+
+	actualImage := GetPauseImage(cfg)
+	expectedImage := "other.repo/pause:3.42"
+	if actualImage != expectedImage {
+		t.Errorf("failed GetPauseImage with overrides:\n\texpected: %s\n\t  actual: %s", expectedImage, actualImage)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ImageDigest holds the digest resolution result for a single image
+type ImageDigest struct {
+	// Image is the image name including the tag
+	Image string `json:"image"`
+
+	// Digest is the manifest digest the registry serves for the image
+	Digest string `json:"digest,omitempty"`
+
+	// Error describes why the digest could not be resolved, empty on success
+	Error string `json:"error,omitempty"`
+}
+
+// SplitImageName splits an image name into its registry, repository and tag parts.
+// Images without an explicit registry default to the Docker Hub registry, images
+// without a tag default to "latest".
+func SplitImageName(image string) (registry, repository, tag string) {
+	tag = "latest"
+	if i := strings.LastIndex(image, ":"); i != -1 && !strings.Contains(image[i:], "/") {
+		image, tag = image[:i], image[i+1:]
+	}
+	registry = "registry-1.docker.io"
+	if i := strings.Index(image, "/"); i != -1 && (strings.ContainsAny(image[:i], ".:") || image[:i] == "localhost") {
+		registry, image = image[:i], image[i+1:]
+	}
+	return registry, image, tag
+}
+
+// ResolveImageDigests queries the registry of every image for the digest of the manifest
+// served for its tag, so images can be pinned and verified by digest. Failures are
+// reported per image instead of aborting the whole resolution.
+func ResolveImageDigests(images []string) []ImageDigest {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	digests := []ImageDigest{}
+	for _, image := range images {
+		digests = append(digests, resolveImageDigest(client, image))
+	}
+	return digests
+}
+
+// resolveImageDigest fetches the manifest digest of a single image from its registry
+func resolveImageDigest(client *http.Client, image string) ImageDigest {
+	result := ImageDigest{Image: image}
+
+	registry, repository, tag := SplitImageName(image)
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repository, tag)
+	req, err := http.NewRequest("HEAD", url, nil)
+	if err != nil {
+		result.Error = fmt.Sprintf("could not build a manifest request: %v", err)
+		return result
+	}
+	req.Header.Set("Accept", strings.Join([]string{
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}, ","))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Error = fmt.Sprintf("could not reach the registry: %v", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		result.Error = fmt.Sprintf("the registry returned status %d for %s", resp.StatusCode, url)
+		return result
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		result.Error = "the registry did not return a manifest digest"
+		return result
+	}
+
+	result.Digest = digest
+	return result
+}
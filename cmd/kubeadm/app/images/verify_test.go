@@ -0,0 +1,42 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"testing"
+)
+
+func TestSplitImageName(t *testing.T) {
+	var tests = []struct {
+		image              string
+		expectedRegistry   string
+		expectedRepository string
+		expectedTag        string
+	}{
+		{"k8s.gcr.io/pause:3.1", "k8s.gcr.io", "pause", "3.1"},
+		{"k8s.gcr.io/kube-apiserver:v1.17.0", "k8s.gcr.io", "kube-apiserver", "v1.17.0"},
+		{"localhost:5000/pause:3.1", "localhost:5000", "pause", "3.1"},
+		{"myorg/myimage", "registry-1.docker.io", "myorg/myimage", "latest"},
+	}
+	for _, rt := range tests {
+		registry, repository, tag := SplitImageName(rt.image)
+		if registry != rt.expectedRegistry || repository != rt.expectedRepository || tag != rt.expectedTag {
+			t.Errorf("failed SplitImageName for %q:\n\texpected: %s %s %s\n\t  actual: %s %s %s",
+				rt.image, rt.expectedRegistry, rt.expectedRepository, rt.expectedTag, registry, repository, tag)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeadmclient exposes the kubeadm workflows (init, join, upgrade) as a
+// programmatic API, so installers like cluster-api bootstrap providers can run them
+// in-process instead of exec-ing the kubeadm binary and parsing its stdout.
+//
+// The workflows execute with the same semantics as the CLI commands; errors are
+// returned as Go errors (including the typed kinds from app/util/errors) instead of
+// process exit codes. Deeper injection points (filesystem, exec, clientset) still
+// follow the code paths of the respective phases.
+package kubeadmclient
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	cmd "k8s.io/kubernetes/cmd/kubeadm/app/cmd"
+	"k8s.io/kubernetes/cmd/kubeadm/app/cmd/upgrade"
+)
+
+// Options control the execution of the kubeadm workflows
+type Options struct {
+	// ConfigPath is the path of the kubeadm configuration file to use
+	ConfigPath string
+
+	// SkipPhases lists phases to skip
+	SkipPhases []string
+
+	// IgnorePreflightErrors lists preflight check names whose errors are downgraded
+	IgnorePreflightErrors []string
+
+	// DryRun makes the workflow only print the actions it would perform
+	DryRun bool
+
+	// Writer receives the workflow output; discarded when nil
+	Writer io.Writer
+}
+
+// commonArgs renders the flags shared by the workflows
+func (o *Options) commonArgs() []string {
+	args := []string{}
+	if o.ConfigPath != "" {
+		args = append(args, "--config", o.ConfigPath)
+	}
+	if len(o.SkipPhases) > 0 {
+		args = append(args, "--skip-phases", strings.Join(o.SkipPhases, ","))
+	}
+	if len(o.IgnorePreflightErrors) > 0 {
+		args = append(args, "--ignore-preflight-errors", strings.Join(o.IgnorePreflightErrors, ","))
+	}
+	if o.DryRun {
+		args = append(args, "--dry-run")
+	}
+	return args
+}
+
+// writer returns the configured output writer, defaulting to discarding the output
+func (o *Options) writer() io.Writer {
+	if o.Writer != nil {
+		return o.Writer
+	}
+	return ioutil.Discard
+}
+
+// InitCluster runs the kubeadm init workflow in-process
+func InitCluster(opts Options) error {
+	initCmd := cmd.NewCmdInit(opts.writer(), nil)
+	initCmd.SetArgs(opts.commonArgs())
+	initCmd.SetOutput(opts.writer())
+	if err := initCmd.Execute(); err != nil {
+		return errors.Wrap(err, "kubeadm init failed")
+	}
+	return nil
+}
+
+// JoinNode runs the kubeadm join workflow in-process. For token based discovery without
+// a configuration file, pass the API server endpoint via opts.ConfigPath-free extraArgs.
+func JoinNode(opts Options, extraArgs ...string) error {
+	joinCmd := cmd.NewCmdJoin(opts.writer(), nil)
+	joinCmd.SetArgs(append(opts.commonArgs(), extraArgs...))
+	joinCmd.SetOutput(opts.writer())
+	if err := joinCmd.Execute(); err != nil {
+		return errors.Wrap(err, "kubeadm join failed")
+	}
+	return nil
+}
+
+// UpgradeControlPlane runs the kubeadm upgrade apply workflow in-process against the
+// given target version
+func UpgradeControlPlane(version string, opts Options) error {
+	if version == "" {
+		return errors.New("a target version is required")
+	}
+
+	applyFlags := []string{version, "--force", "--yes"}
+	if opts.DryRun {
+		applyFlags = append(applyFlags, "--dry-run")
+	}
+	if opts.ConfigPath != "" {
+		applyFlags = append(applyFlags, "--config", opts.ConfigPath)
+	}
+
+	upgradeCmd := upgrade.NewCmdUpgrade(opts.writer())
+	upgradeCmd.SetArgs(append([]string{"apply"}, applyFlags...))
+	upgradeCmd.SetOutput(opts.writer())
+	if err := upgradeCmd.Execute(); err != nil {
+		return errors.Wrapf(err, "kubeadm upgrade apply %s failed", version)
+	}
+	return nil
+}
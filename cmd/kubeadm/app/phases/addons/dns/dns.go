@@ -32,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kuberuntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientset "k8s.io/client-go/kubernetes"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog"
@@ -41,6 +42,7 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/images"
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/kustomize"
 	utilsnet "k8s.io/utils/net"
 )
 
@@ -98,23 +100,115 @@ func deployedDNSReplicas(client clientset.Interface, replicas int32) (*int32, er
 	}
 }
 
-// EnsureDNSAddon creates the kube-dns or CoreDNS addon
-func EnsureDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface) error {
-	if cfg.DNS.Type == kubeadmapi.CoreDNS {
-		replicas, err := deployedDNSReplicas(client, coreDNSReplicas)
-		if err != nil {
-			return err
-		}
-		return coreDNSAddon(cfg, client, replicas)
+// AddonHandler manages the manifests of a specific DNS add-on implementation.
+// Alternative DNS implementations can register a handler for their own
+// DNSAddOnType with RegisterAddonHandler.
+type AddonHandler interface {
+	// Type returns the DNS add-on type this handler manages
+	Type() kubeadmapi.DNSAddOnType
+
+	// EnsureDNSAddon deploys or updates the manifests of the managed DNS add-on,
+	// applying any user supplied patches from kustomizeDir
+	EnsureDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface, kustomizeDir string) error
+}
+
+// addonHandlers holds the registered DNS add-on handlers, keyed by add-on type
+var addonHandlers = map[kubeadmapi.DNSAddOnType]AddonHandler{}
+
+// RegisterAddonHandler adds a DNS add-on handler to the registry, making its type
+// selectable via ClusterConfiguration.DNS.Type. The type must not be registered already.
+func RegisterAddonHandler(h AddonHandler) error {
+	if _, ok := addonHandlers[h.Type()]; ok {
+		return errors.Errorf("there is already a DNS add-on handler for type %q", h.Type())
 	}
+	addonHandlers[h.Type()] = h
+	return nil
+}
+
+func init() {
+	utilruntime.Must(RegisterAddonHandler(coreDNSHandler{}))
+	utilruntime.Must(RegisterAddonHandler(kubeDNSHandler{}))
+	utilruntime.Must(RegisterAddonHandler(externalDNSHandler{}))
+}
+
+// coreDNSHandler manages the CoreDNS add-on deployed by kubeadm
+type coreDNSHandler struct{}
+
+func (coreDNSHandler) Type() kubeadmapi.DNSAddOnType {
+	return kubeadmapi.CoreDNS
+}
+
+func (coreDNSHandler) EnsureDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface, kustomizeDir string) error {
+	replicas, err := deployedDNSReplicas(client, coreDNSReplicas)
+	if err != nil {
+		return err
+	}
+	return coreDNSAddon(cfg, client, replicas, kustomizeDir)
+}
+
+// kubeDNSHandler manages the kube-dns add-on deployed by kubeadm
+type kubeDNSHandler struct{}
+
+func (kubeDNSHandler) Type() kubeadmapi.DNSAddOnType {
+	return kubeadmapi.KubeDNS
+}
+
+func (kubeDNSHandler) EnsureDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface, kustomizeDir string) error {
 	replicas, err := deployedDNSReplicas(client, kubeDNSReplicas)
 	if err != nil {
 		return err
 	}
-	return kubeDNSAddon(cfg, client, replicas)
+	return kubeDNSAddon(cfg, client, replicas, kustomizeDir)
+}
+
+// externalDNSHandler is used when the DNS add-on is managed outside of kubeadm;
+// manifest management is delegated to the external manager
+type externalDNSHandler struct{}
+
+func (externalDNSHandler) Type() kubeadmapi.DNSAddOnType {
+	return kubeadmapi.ExternalDNS
+}
+
+func (externalDNSHandler) EnsureDNSAddon(_ *kubeadmapi.ClusterConfiguration, _ clientset.Interface, _ string) error {
+	fmt.Println("[addons] The DNS addon is externally managed; skipping it")
+	return nil
 }
 
-func kubeDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface, replicas *int32) error {
+// EnsureDNSAddon creates or updates the DNS addon selected in the cluster configuration
+func EnsureDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface, kustomizeDir string) error {
+	if cfg.AddonIsExternallyManaged(kubeadmconstants.CoreDNSDeploymentName) {
+		fmt.Println("[addons] The DNS addon is marked as externally managed in the cluster configuration; leaving it alone")
+		return markDeploymentExternallyManaged(client)
+	}
+
+	handler, ok := addonHandlers[cfg.DNS.Type]
+	if !ok {
+		return errors.Errorf("no DNS add-on handler registered for type %q", cfg.DNS.Type)
+	}
+	return handler.EnsureDNSAddon(cfg, client, kustomizeDir)
+}
+
+// markDeploymentExternallyManaged stamps the ownership annotation on the deployed DNS
+// workload, so other tools can tell kubeadm will not touch it. A missing deployment is
+// not an error - the external manager may not have deployed it yet.
+func markDeploymentExternallyManaged(client clientset.Interface) error {
+	for _, name := range []string{kubeadmconstants.CoreDNSDeploymentName, kubeadmconstants.KubeDNSDeploymentName} {
+		deployment, err := client.AppsV1().Deployments(metav1.NamespaceSystem).Get(name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if deployment.Annotations[kubeadmconstants.ExternallyManagedAnnotationKey] == "true" {
+			continue
+		}
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"true"}}}`, kubeadmconstants.ExternallyManagedAnnotationKey)
+		if _, err := client.AppsV1().Deployments(metav1.NamespaceSystem).Patch(name, types.StrategicMergePatchType, []byte(patch)); err != nil {
+			return errors.Wrapf(err, "failed to annotate the %q deployment as externally managed", name)
+		}
+	}
+	return nil
+}
+
+func kubeDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface, replicas *int32, kustomizeDir string) error {
 	if err := CreateServiceAccount(client); err != nil {
 		return err
 	}
@@ -159,6 +253,16 @@ func kubeDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interfa
 		return errors.Wrap(err, "error when parsing kube-proxy configmap template")
 	}
 
+	// apply user supplied patches to the addon manifests
+	dnsDeploymentBytes, err = kustomize.ApplyPatches(dnsDeploymentBytes, kustomizeDir)
+	if err != nil {
+		return err
+	}
+	dnsServiceBytes, err = kustomize.ApplyPatches(dnsServiceBytes, kustomizeDir)
+	if err != nil {
+		return err
+	}
+
 	if err := createKubeDNSAddon(dnsDeploymentBytes, dnsServiceBytes, client); err != nil {
 		return err
 	}
@@ -192,7 +296,7 @@ func createKubeDNSAddon(deploymentBytes, serviceBytes []byte, client clientset.I
 	return createDNSService(kubednsService, serviceBytes, client)
 }
 
-func coreDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface, replicas *int32) error {
+func coreDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface, replicas *int32, kustomizeDir string) error {
 	// Get the YAML manifest
 	coreDNSDeploymentBytes, err := kubeadmutil.ParseTemplate(CoreDNSDeployment, struct {
 		DeploymentName, Image, ControlPlaneTaintKey string
@@ -252,6 +356,16 @@ func coreDNSAddon(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interfa
 		return errors.Wrap(err, "error when parsing CoreDNS service template")
 	}
 
+	// apply user supplied patches to the addon manifests
+	coreDNSDeploymentBytes, err = kustomize.ApplyPatches(coreDNSDeploymentBytes, kustomizeDir)
+	if err != nil {
+		return err
+	}
+	coreDNSServiceBytes, err = kustomize.ApplyPatches(coreDNSServiceBytes, kustomizeDir)
+	if err != nil {
+		return err
+	}
+
 	if err := createCoreDNSAddon(coreDNSDeploymentBytes, coreDNSServiceBytes, coreDNSConfigMapBytes, client); err != nil {
 		return err
 	}
@@ -29,10 +29,42 @@ import (
 	clientsetfake "k8s.io/client-go/kubernetes/fake"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
 	core "k8s.io/client-go/testing"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	testutil "k8s.io/kubernetes/cmd/kubeadm/test"
 )
 
+func TestRegisterAddonHandler(t *testing.T) {
+	// re-registering a known add-on type must fail
+	if err := RegisterAddonHandler(coreDNSHandler{}); err == nil {
+		t.Fatal("expected an error when re-registering an already registered add-on type, got none")
+	}
+}
+
+func TestEnsureDNSAddonUnknownType(t *testing.T) {
+	cfg := &kubeadmapi.ClusterConfiguration{
+		DNS: kubeadmapi.DNS{Type: "unknown"},
+	}
+	client := clientsetfake.NewSimpleClientset()
+	if err := EnsureDNSAddon(cfg, client, ""); err == nil {
+		t.Fatal("expected an error for an unknown DNS add-on type, got none")
+	}
+}
+
+func TestEnsureDNSAddonExternal(t *testing.T) {
+	cfg := &kubeadmapi.ClusterConfiguration{
+		DNS: kubeadmapi.DNS{Type: kubeadmapi.ExternalDNS},
+	}
+	client := clientsetfake.NewSimpleClientset()
+	if err := EnsureDNSAddon(cfg, client, ""); err != nil {
+		t.Fatalf("EnsureDNSAddon failed for an externally managed DNS add-on: %v", err)
+	}
+	if actions := client.Actions(); len(actions) != 0 {
+		t.Fatalf("expected no API actions for an externally managed DNS add-on, got: %v", actions)
+	}
+}
+
 func TestCreateServiceAccount(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -773,3 +805,22 @@ func createClientAndCoreDNSManifest(t *testing.T, corefile, coreDNSVersion strin
 	}
 	return client
 }
+
+func TestGoldenCoreDNSDeployment(t *testing.T) {
+	deploymentBytes, err := kubeadmutil.ParseTemplate(CoreDNSDeployment, struct {
+		DeploymentName, Image, ControlPlaneTaintKey string
+		Replicas                                    *int32
+	}{
+		DeploymentName:       "coredns",
+		Image:                "k8s.gcr.io/coredns:1.6.5",
+		ControlPlaneTaintKey: kubeadmconstants.LabelNodeRoleMaster,
+		Replicas:             &testReplicas,
+	})
+	if err != nil {
+		t.Fatalf("couldn't render the CoreDNS deployment: %v", err)
+	}
+
+	testutil.AssertGoldenFile(t, "coredns-deployment.yaml", deploymentBytes)
+}
+
+var testReplicas = int32(2)
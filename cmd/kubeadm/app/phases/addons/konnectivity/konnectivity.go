@@ -0,0 +1,189 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package konnectivity implements the optional deployment of the konnectivity service:
+// the konnectivity-server runs as a static pod next to the kube-apiserver, the
+// konnectivity-agent as a DaemonSet on the nodes, and the API server is pointed at the
+// generated egress selector configuration - for clusters where the control plane cannot
+// reach the node network directly.
+package konnectivity
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kuberuntime "k8s.io/apimachinery/pkg/runtime"
+	clientset "k8s.io/client-go/kubernetes"
+	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+	staticpodutil "k8s.io/kubernetes/cmd/kubeadm/app/util/staticpod"
+)
+
+const (
+	// konnectivityServerName is the name of the konnectivity-server static pod
+	konnectivityServerName = "konnectivity-server"
+
+	// egressSelectorConfiguration is the content of the egress selector configuration
+	// pointing the API server at the konnectivity-server UDS
+	egressSelectorConfiguration = `apiVersion: apiserver.k8s.io/v1beta1
+kind: EgressSelectorConfiguration
+egressSelections:
+- name: cluster
+  connection:
+    proxyProtocol: GRPC
+    transport:
+      uds:
+        udsName: /etc/kubernetes/konnectivity-server/konnectivity-server.socket
+`
+
+	// KonnectivityAgentDaemonSet is the konnectivity-agent DaemonSet manifest
+	KonnectivityAgentDaemonSet = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: konnectivity-agent
+  namespace: kube-system
+  labels:
+    k8s-app: konnectivity-agent
+spec:
+  selector:
+    matchLabels:
+      k8s-app: konnectivity-agent
+  template:
+    metadata:
+      labels:
+        k8s-app: konnectivity-agent
+    spec:
+      priorityClassName: system-node-critical
+      tolerations:
+      - operator: Exists
+      containers:
+      - name: konnectivity-agent
+        image: {{ .Image }}
+        command: ["/proxy-agent"]
+        args:
+        - --proxy-server-host={{ .ProxyServerHost }}
+        - --proxy-server-port=8132
+      serviceAccountName: konnectivity-agent
+`
+)
+
+// EgressSelectorConfigurationPath returns the location of the generated egress selector configuration
+func EgressSelectorConfigurationPath() string {
+	return filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.EgressSelectorConfigurationFileName)
+}
+
+// WriteEgressSelectorConfiguration writes the egress selector configuration consumed by
+// the kube-apiserver
+func WriteEgressSelectorConfiguration(cfg *kubeadmapi.ClusterConfiguration) error {
+	if cfg.Konnectivity == nil {
+		return nil
+	}
+	path := EgressSelectorConfigurationPath()
+	fmt.Printf("[konnectivity] Writing egress selector configuration to %q\n", path)
+	return ioutil.WriteFile(path, []byte(egressSelectorConfiguration), 0600)
+}
+
+// CreateServerStaticPodManifest writes the konnectivity-server static pod manifest
+func CreateServerStaticPodManifest(manifestDir string, cfg *kubeadmapi.ClusterConfiguration) error {
+	if cfg.Konnectivity == nil {
+		return nil
+	}
+
+	hostPathDirectoryOrCreate := v1.HostPathDirectoryOrCreate
+	udsDir := filepath.Join(kubeadmconstants.KubernetesDir, "konnectivity-server")
+	pod := staticpodutil.ComponentPod(v1.Container{
+		Name:            konnectivityServerName,
+		Image:           cfg.Konnectivity.ServerImage,
+		ImagePullPolicy: v1.PullIfNotPresent,
+		Command:         []string{"/proxy-server"},
+		Args: []string{
+			"--uds-name", filepath.Join(udsDir, "konnectivity-server.socket"),
+			"--cluster-cert", filepath.Join(cfg.CertificatesDir, kubeadmconstants.APIServerCertName),
+			"--cluster-key", filepath.Join(cfg.CertificatesDir, kubeadmconstants.APIServerKeyName),
+			"--mode", "grpc",
+			"--server-port", "0",
+			"--agent-port", "8132",
+		},
+		VolumeMounts: []v1.VolumeMount{
+			staticpodutil.NewVolumeMount("konnectivity-uds", udsDir, false),
+			staticpodutil.NewVolumeMount(kubeadmconstants.KubeCertificatesVolumeName, cfg.CertificatesDir, true),
+		},
+	}, map[string]v1.Volume{
+		"konnectivity-uds":                          staticpodutil.NewVolume("konnectivity-uds", udsDir, &hostPathDirectoryOrCreate),
+		kubeadmconstants.KubeCertificatesVolumeName: staticpodutil.NewVolume(kubeadmconstants.KubeCertificatesVolumeName, cfg.CertificatesDir, &hostPathDirectoryOrCreate),
+	})
+
+	if err := staticpodutil.WriteStaticPodToDisk(konnectivityServerName, manifestDir, pod); err != nil {
+		return errors.Wrap(err, "failed to write the konnectivity-server manifest")
+	}
+	fmt.Printf("[konnectivity] Wrote the %q static Pod manifest\n", konnectivityServerName)
+	return nil
+}
+
+// EnsureKonnectivityAddon deploys the konnectivity-agent DaemonSet and its ServiceAccount
+func EnsureKonnectivityAddon(cfg *kubeadmapi.ClusterConfiguration, localEndpoint *kubeadmapi.APIEndpoint, client clientset.Interface) error {
+	if cfg.Konnectivity == nil {
+		return nil
+	}
+
+	if err := apiclient.CreateOrUpdateServiceAccount(client, &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "konnectivity-agent",
+			Namespace: metav1.NamespaceSystem,
+		},
+	}); err != nil {
+		return err
+	}
+
+	proxyServerHost := localEndpoint.AdvertiseAddress
+	if cfg.ControlPlaneEndpoint != "" {
+		host, _, err := kubeadmutil.ParseHostPort(cfg.ControlPlaneEndpoint)
+		if err == nil {
+			proxyServerHost = host
+		}
+	}
+
+	daemonSetBytes, err := kubeadmutil.ParseTemplate(KonnectivityAgentDaemonSet, struct {
+		Image           string
+		ProxyServerHost string
+	}{
+		Image:           cfg.Konnectivity.AgentImage,
+		ProxyServerHost: proxyServerHost,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error when parsing the konnectivity-agent daemonset template")
+	}
+
+	daemonSet := &apps.DaemonSet{}
+	if err := kuberuntime.DecodeInto(clientsetscheme.Codecs.UniversalDecoder(), daemonSetBytes, daemonSet); err != nil {
+		return errors.Wrap(err, "unable to decode the konnectivity-agent daemonset")
+	}
+	if err := apiclient.CreateOrUpdateDaemonSet(client, daemonSet); err != nil {
+		return err
+	}
+
+	fmt.Println("[addons] Applied addon: konnectivity-agent")
+	return nil
+}
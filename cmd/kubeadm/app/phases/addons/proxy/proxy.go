@@ -26,6 +26,7 @@ import (
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kuberuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientset "k8s.io/client-go/kubernetes"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
@@ -34,6 +35,8 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/images"
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/kustomize"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -45,12 +48,42 @@ const (
 	KubeProxyServiceAccountName = "kube-proxy"
 )
 
+// markDaemonSetExternallyManaged stamps the ownership annotation on the deployed
+// kube-proxy DaemonSet, so other tools can tell kubeadm will not touch it. A missing
+// DaemonSet is not an error - the external manager may not have deployed one.
+func markDaemonSetExternallyManaged(client clientset.Interface) error {
+	daemonSet, err := client.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(constants.KubeProxy, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	if daemonSet.Annotations[constants.ExternallyManagedAnnotationKey] == "true" {
+		return nil
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"true"}}}`, constants.ExternallyManagedAnnotationKey)
+	if _, err := client.AppsV1().DaemonSets(metav1.NamespaceSystem).Patch(constants.KubeProxy, types.StrategicMergePatchType, []byte(patch)); err != nil {
+		return errors.Wrap(err, "failed to annotate the kube-proxy daemonset as externally managed")
+	}
+	return nil
+}
+
 // EnsureProxyAddon creates the kube-proxy addons
-func EnsureProxyAddon(cfg *kubeadmapi.ClusterConfiguration, localEndpoint *kubeadmapi.APIEndpoint, client clientset.Interface) error {
+func EnsureProxyAddon(cfg *kubeadmapi.ClusterConfiguration, localEndpoint *kubeadmapi.APIEndpoint, client clientset.Interface, kustomizeDir string) error {
+	if cfg.Proxy != nil && cfg.Proxy.Disabled {
+		fmt.Println("[addons] The kube-proxy addon is disabled in the cluster configuration; skipping it")
+		return nil
+	}
+
+	if cfg.AddonIsExternallyManaged(constants.KubeProxy) {
+		fmt.Println("[addons] The kube-proxy addon is marked as externally managed in the cluster configuration; leaving it alone")
+		return markDaemonSetExternallyManaged(client)
+	}
+
 	if err := CreateServiceAccount(client); err != nil {
 		return errors.Wrap(err, "error when creating kube-proxy service account")
 	}
 
+	warnOnProxyModeChange(cfg, client)
+
 	// Generate ControlPlane Enpoint kubeconfig file
 	controlPlaneEndpoint, err := kubeadmutil.GetControlPlaneEndpoint(cfg.ControlPlaneEndpoint, localEndpoint)
 	if err != nil {
@@ -92,6 +125,16 @@ func EnsureProxyAddon(cfg *kubeadmapi.ClusterConfiguration, localEndpoint *kubea
 	if err != nil {
 		return errors.Wrap(err, "error when parsing kube-proxy daemonset template")
 	}
+	// apply user supplied patches to the addon manifests
+	proxyConfigMapBytes, err = kustomize.ApplyPatches(proxyConfigMapBytes, kustomizeDir)
+	if err != nil {
+		return err
+	}
+	proxyDaemonSetBytes, err = kustomize.ApplyPatches(proxyDaemonSetBytes, kustomizeDir)
+	if err != nil {
+		return err
+	}
+
 	if err := createKubeProxyAddon(proxyConfigMapBytes, proxyDaemonSetBytes, client); err != nil {
 		return err
 	}
@@ -103,6 +146,42 @@ func EnsureProxyAddon(cfg *kubeadmapi.ClusterConfiguration, localEndpoint *kubea
 	return nil
 }
 
+// warnOnProxyModeChange prints a warning when the proxy mode in the new component config
+// differs from the mode of the kube-proxy instance deployed in the cluster, as the rules
+// programmed by the previous mode are left behind on the nodes and must be flushed manually
+// (e.g. when migrating from the iptables mode to the nftables mode during upgrade)
+func warnOnProxyModeChange(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface) {
+	deployedMode, err := deployedKubeProxyMode(client)
+	if err != nil || deployedMode == "" {
+		return
+	}
+	newMode := componentconfigs.GetKubeProxyMode(cfg)
+	if newMode != "" && newMode != deployedMode {
+		fmt.Printf("[addons] WARNING: The kube-proxy mode is changing from %q to %q. "+
+			"Rules programmed by the %q mode are not cleaned up automatically; "+
+			"flush them on every node (e.g. using the %q tooling) to avoid stale rules interfering with the new mode\n",
+			deployedMode, newMode, deployedMode, deployedMode)
+	}
+}
+
+// deployedKubeProxyMode reads the proxy mode of the kube-proxy instance currently deployed in the cluster
+func deployedKubeProxyMode(client clientset.Interface) (string, error) {
+	configMap, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(constants.KubeProxyConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	proxyConfig := struct {
+		Mode string `json:"mode"`
+	}{}
+	if err := yaml.Unmarshal([]byte(configMap.Data[constants.KubeProxyConfigMapKey]), &proxyConfig); err != nil {
+		return "", err
+	}
+	if proxyConfig.Mode == "" {
+		return constants.KubeProxyModeIPTables, nil
+	}
+	return proxyConfig.Mode, nil
+}
+
 // CreateServiceAccount creates the necessary serviceaccounts that kubeadm uses/might use, if they don't already exist.
 func CreateServiceAccount(client clientset.Interface) error {
 
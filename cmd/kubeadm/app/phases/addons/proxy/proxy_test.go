@@ -202,7 +202,7 @@ func TestEnsureProxyAddon(t *testing.T) {
 				t.Errorf("test failed to convert external to internal version")
 				return
 			}
-			err = EnsureProxyAddon(&intControlPlane.ClusterConfiguration, &intControlPlane.LocalAPIEndpoint, client)
+			err = EnsureProxyAddon(&intControlPlane.ClusterConfiguration, &intControlPlane.LocalAPIEndpoint, client, "")
 
 			// Compare actual to expected errors
 			actErr := "No error"
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admission renders the AdmissionConfiguration file consumed by the
+// kube-apiserver from the plugins declared in the ClusterConfiguration.
+package admission
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/yaml"
+)
+
+// knownPlugins holds the admission plugins that accept a configuration file entry
+// in this Kubernetes version
+var knownPlugins = map[string]bool{
+	"AlwaysPullImages":           true,
+	"EventRateLimit":             true,
+	"ImagePolicyWebhook":         true,
+	"LimitRanger":                true,
+	"NamespaceLifecycle":         true,
+	"PodNodeSelector":            true,
+	"PodTolerationRestriction":   true,
+	"ResourceQuota":              true,
+	"ValidatingAdmissionWebhook": true,
+	"MutatingAdmissionWebhook":   true,
+}
+
+// admissionConfiguration mirrors the apiserver AdmissionConfiguration file structure
+type admissionConfiguration struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Plugins    []admissionPlugin `json:"plugins"`
+}
+
+type admissionPlugin struct {
+	Name          string      `json:"name"`
+	Configuration interface{} `json:"configuration,omitempty"`
+}
+
+// GetAdmissionConfigPath returns the location of the admission configuration file
+func GetAdmissionConfigPath() string {
+	return filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.AdmissionConfigurationFileName)
+}
+
+// ValidatePlugins verifies every declared plugin name is known to accept configuration
+func ValidatePlugins(cfg *kubeadmapi.AdmissionConfiguration) error {
+	if cfg == nil {
+		return nil
+	}
+	for name := range cfg.Plugins {
+		if !knownPlugins[name] {
+			return errors.Errorf("unknown admission plugin %q declared in the admission configuration", name)
+		}
+	}
+	return nil
+}
+
+// CreateAdmissionConfiguration renders and writes the AdmissionConfiguration file for
+// the plugins declared in the ClusterConfiguration. The file is regenerated from the
+// cluster configuration on every run, so it is preserved across upgrades.
+func CreateAdmissionConfiguration(cfg *kubeadmapi.ClusterConfiguration) error {
+	if cfg.APIServer.Admission == nil {
+		return nil
+	}
+	if err := ValidatePlugins(cfg.APIServer.Admission); err != nil {
+		return err
+	}
+
+	names := []string{}
+	for name := range cfg.APIServer.Admission.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	config := &admissionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "AdmissionConfiguration",
+	}
+	for _, name := range names {
+		plugin := admissionPlugin{Name: name}
+		if pluginConfig := cfg.APIServer.Admission.Plugins[name]; pluginConfig != "" {
+			parsed := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(pluginConfig), &parsed); err != nil {
+				return errors.Wrapf(err, "the configuration of admission plugin %q does not parse", name)
+			}
+			plugin.Configuration = parsed
+		}
+		config.Plugins = append(config.Plugins, plugin)
+	}
+
+	b, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the admission configuration")
+	}
+
+	path := GetAdmissionConfigPath()
+	fmt.Printf("[admission] Writing admission configuration to %q\n", path)
+	return ioutil.WriteFile(path, b, 0600)
+}
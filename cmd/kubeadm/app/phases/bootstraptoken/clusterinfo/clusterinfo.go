@@ -18,17 +18,22 @@ package clusterinfo
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apiserver/pkg/authentication/user"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+	jws "k8s.io/cluster-bootstrap/token/jws"
 	"k8s.io/klog"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	nodebootstraptokenphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/bootstraptoken/node"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 )
 
@@ -74,6 +79,128 @@ func CreateBootstrapConfigMapIfNotExists(client clientset.Interface, file string
 	})
 }
 
+// RefreshBootstrapConfigMap re-publishes the cluster-info ConfigMap from the current admin
+// kubeconfig, refreshing the embedded CA bundle and the server URL after a CA rotation or
+// an endpoint change. The bootstrap-signer controller in the kube-controller-manager
+// re-signs the updated content with the current signing tokens afterwards.
+func RefreshBootstrapConfigMap(client clientset.Interface, file string) error {
+	return CreateBootstrapConfigMapIfNotExists(client, file)
+}
+
+// VerifyBootstrapConfigMapSignatures validates that the published cluster-info ConfigMap
+// carries a valid JWS signature for every bootstrap token with the signing usage, so a
+// stale or tampered publication can be detected before nodes fail to join.
+func VerifyBootstrapConfigMapSignatures(client clientset.Interface) error {
+	configMap, err := client.CoreV1().ConfigMaps(metav1.NamespacePublic).Get(bootstrapapi.ConfigMapClusterInfo, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the %q ConfigMap", bootstrapapi.ConfigMapClusterInfo)
+	}
+	content, ok := configMap.Data[bootstrapapi.KubeConfigKey]
+	if !ok {
+		return errors.Errorf("the %q ConfigMap does not contain a %q entry", bootstrapapi.ConfigMapClusterInfo, bootstrapapi.KubeConfigKey)
+	}
+
+	tokenSelector := fields.SelectorFromSet(
+		map[string]string{
+			"type": string(bootstrapapi.SecretTypeBootstrapToken),
+		},
+	)
+	secrets, err := client.CoreV1().Secrets(metav1.NamespaceSystem).List(metav1.ListOptions{FieldSelector: tokenSelector.String()})
+	if err != nil {
+		return errors.Wrap(err, "failed to list bootstrap tokens")
+	}
+
+	signingTokens := 0
+	for _, secret := range secrets.Items {
+		token, err := kubeadmapi.BootstrapTokenFromSecret(&secret)
+		if err != nil {
+			continue
+		}
+		hasSigningUsage := false
+		for _, usage := range token.Usages {
+			if usage == "signing" {
+				hasSigningUsage = true
+			}
+		}
+		if !hasSigningUsage {
+			continue
+		}
+		signingTokens++
+
+		signature, ok := configMap.Data[bootstrapapi.JWSSignatureKeyPrefix+token.Token.ID]
+		if !ok {
+			return errors.Errorf("no JWS signature published for token %q; the bootstrap-signer may not have caught up yet", token.Token.ID)
+		}
+		if !jws.DetachedTokenIsValid(signature, content, token.Token.ID, token.Token.Secret) {
+			return errors.Errorf("the JWS signature published for token %q does not match the published kubeconfig", token.Token.ID)
+		}
+	}
+
+	if signingTokens == 0 {
+		fmt.Println("[bootstrap-token] No bootstrap tokens with the signing usage found; nothing to verify")
+		return nil
+	}
+
+	fmt.Printf("[bootstrap-token] Verified the JWS signatures of %d signing token(s) in the %q ConfigMap\n", signingTokens, bootstrapapi.ConfigMapClusterInfo)
+	return nil
+}
+
+// RotateBootstrapTokenSigning rotates the mechanism protecting the cluster-info
+// signatures in one step: it deletes every existing bootstrap token, strips the stale
+// JWS signatures from the published ConfigMap, creates the given fresh token and signs
+// the publication with it - so after a token leak a single command leaves no credential
+// or signature from before the rotation behind.
+func RotateBootstrapTokenSigning(client clientset.Interface, newToken *kubeadmapi.BootstrapToken) error {
+	// 1. invalidate all previous bootstrap tokens
+	tokenSelector := fields.SelectorFromSet(
+		map[string]string{
+			"type": string(bootstrapapi.SecretTypeBootstrapToken),
+		},
+	)
+	secrets, err := client.CoreV1().Secrets(metav1.NamespaceSystem).List(metav1.ListOptions{FieldSelector: tokenSelector.String()})
+	if err != nil {
+		return errors.Wrap(err, "failed to list bootstrap tokens")
+	}
+	for _, secret := range secrets.Items {
+		if err := client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(secret.Name, &metav1.DeleteOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to delete the bootstrap token %q", secret.Name)
+		}
+	}
+	fmt.Printf("[bootstrap-token] Deleted %d previous bootstrap token(s)\n", len(secrets.Items))
+
+	// 2. strip the now stale JWS signatures from the published ConfigMap and sign the
+	// content with the fresh token; the bootstrap-signer reconciles it afterwards
+	configMap, err := client.CoreV1().ConfigMaps(metav1.NamespacePublic).Get(bootstrapapi.ConfigMapClusterInfo, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the %q ConfigMap", bootstrapapi.ConfigMapClusterInfo)
+	}
+	content, ok := configMap.Data[bootstrapapi.KubeConfigKey]
+	if !ok {
+		return errors.Errorf("the %q ConfigMap does not contain a %q entry", bootstrapapi.ConfigMapClusterInfo, bootstrapapi.KubeConfigKey)
+	}
+	for key := range configMap.Data {
+		if strings.HasPrefix(key, bootstrapapi.JWSSignatureKeyPrefix) {
+			delete(configMap.Data, key)
+		}
+	}
+	signature, err := jws.ComputeDetachedSignature(content, newToken.Token.ID, newToken.Token.Secret)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign the cluster-info content with the new token")
+	}
+	configMap.Data[bootstrapapi.JWSSignatureKeyPrefix+newToken.Token.ID] = signature
+	if err := apiclient.CreateOrUpdateConfigMap(client, configMap); err != nil {
+		return err
+	}
+
+	// 3. create the fresh token last, so a failure above does not leave a new usable
+	// credential next to the old signatures
+	if err := nodebootstraptokenphase.CreateNewTokens(client, []kubeadmapi.BootstrapToken{*newToken}); err != nil {
+		return errors.Wrap(err, "failed to create the new bootstrap token")
+	}
+	fmt.Printf("[bootstrap-token] Re-signed the %q ConfigMap and created the new bootstrap token %q\n", bootstrapapi.ConfigMapClusterInfo, newToken.Token.ID)
+	return nil
+}
+
 // CreateClusterInfoRBACRules creates the RBAC rules for exposing the cluster-info ConfigMap in the kube-public namespace to unauthenticated users
 func CreateClusterInfoRBACRules(client clientset.Interface) error {
 	klog.V(1).Infoln("creating the RBAC rules for exposing the cluster-info ConfigMap in the kube-public namespace")
@@ -22,11 +22,15 @@ import (
 	"testing"
 	"text/template"
 
+	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientsetfake "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+	jws "k8s.io/cluster-bootstrap/token/jws"
 )
 
 var testConfigTempl = template.Must(template.New("test").Parse(`apiVersion: v1
@@ -113,3 +117,69 @@ func TestCreateBootstrapConfigMapIfNotExists(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyBootstrapConfigMapSignatures(t *testing.T) {
+	content := "apiVersion: v1\nkind: Config\n"
+	tokenID := "abcdef"
+	tokenSecret := "abcdef0123456789"
+
+	validSignature, err := jws.ComputeDetachedSignature(content, tokenID, tokenSecret)
+	if err != nil {
+		t.Fatalf("couldn't compute a detached signature: %v", err)
+	}
+
+	tokenSecretObj := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bootstrap-token-" + tokenID,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Type: v1.SecretType(bootstrapapi.SecretTypeBootstrapToken),
+		Data: map[string][]byte{
+			bootstrapapi.BootstrapTokenIDKey:                   []byte(tokenID),
+			bootstrapapi.BootstrapTokenSecretKey:               []byte(tokenSecret),
+			bootstrapapi.BootstrapTokenUsagePrefix + "signing": []byte("true"),
+		},
+	}
+
+	var tests = []struct {
+		name          string
+		signature     string
+		expectedError bool
+	}{
+		{
+			name:      "valid signature",
+			signature: validSignature,
+		},
+		{
+			name:          "invalid signature",
+			signature:     "eyJhbGciOiJIUzI1NiIsImtpZCI6ImFiY2RlZiJ9..invalid",
+			expectedError: true,
+		},
+		{
+			name:          "missing signature",
+			expectedError: true,
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			data := map[string]string{
+				bootstrapapi.KubeConfigKey: content,
+			}
+			if rt.signature != "" {
+				data[bootstrapapi.JWSSignatureKeyPrefix+tokenID] = rt.signature
+			}
+			client := clientsetfake.NewSimpleClientset(tokenSecretObj, &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      bootstrapapi.ConfigMapClusterInfo,
+					Namespace: metav1.NamespacePublic,
+				},
+				Data: data,
+			})
+
+			err := VerifyBootstrapConfigMapSignatures(client)
+			if (err != nil) != rt.expectedError {
+				t.Errorf("unexpected result:\n\texpected error: %t\n\t  actual: %v", rt.expectedError, err)
+			}
+		})
+	}
+}
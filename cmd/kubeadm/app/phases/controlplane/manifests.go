@@ -58,7 +58,7 @@ func GetStaticPodSpecs(cfg *kubeadmapi.ClusterConfiguration, endpoint *kubeadmap
 			VolumeMounts:    staticpodutil.VolumeMountMapToSlice(mounts.GetVolumeMounts(kubeadmconstants.KubeAPIServer)),
 			LivenessProbe:   staticpodutil.LivenessProbe(staticpodutil.GetAPIServerProbeAddress(endpoint), "/healthz", int(endpoint.BindPort), v1.URISchemeHTTPS),
 			Resources:       staticpodutil.ComponentResources("250m"),
-			Env:             kubeadmutil.GetProxyEnvVars(),
+			Env:             kubeadmutil.GetProxyEnvVarsFromConfig(cfg.HTTPProxy),
 		}, mounts.GetVolumes(kubeadmconstants.KubeAPIServer)),
 		kubeadmconstants.KubeControllerManager: staticpodutil.ComponentPod(v1.Container{
 			Name:            kubeadmconstants.KubeControllerManager,
@@ -68,7 +68,7 @@ func GetStaticPodSpecs(cfg *kubeadmapi.ClusterConfiguration, endpoint *kubeadmap
 			VolumeMounts:    staticpodutil.VolumeMountMapToSlice(mounts.GetVolumeMounts(kubeadmconstants.KubeControllerManager)),
 			LivenessProbe:   staticpodutil.LivenessProbe(staticpodutil.GetControllerManagerProbeAddress(cfg), "/healthz", kubeadmconstants.KubeControllerManagerPort, v1.URISchemeHTTPS),
 			Resources:       staticpodutil.ComponentResources("200m"),
-			Env:             kubeadmutil.GetProxyEnvVars(),
+			Env:             kubeadmutil.GetProxyEnvVarsFromConfig(cfg.HTTPProxy),
 		}, mounts.GetVolumes(kubeadmconstants.KubeControllerManager)),
 		kubeadmconstants.KubeScheduler: staticpodutil.ComponentPod(v1.Container{
 			Name:            kubeadmconstants.KubeScheduler,
@@ -78,19 +78,68 @@ func GetStaticPodSpecs(cfg *kubeadmapi.ClusterConfiguration, endpoint *kubeadmap
 			VolumeMounts:    staticpodutil.VolumeMountMapToSlice(mounts.GetVolumeMounts(kubeadmconstants.KubeScheduler)),
 			LivenessProbe:   staticpodutil.LivenessProbe(staticpodutil.GetSchedulerProbeAddress(cfg), "/healthz", kubeadmconstants.KubeSchedulerPort, v1.URISchemeHTTPS),
 			Resources:       staticpodutil.ComponentResources("100m"),
-			Env:             kubeadmutil.GetProxyEnvVars(),
+			Env:             kubeadmutil.GetProxyEnvVarsFromConfig(cfg.HTTPProxy),
 		}, mounts.GetVolumes(kubeadmconstants.KubeScheduler)),
 	}
+
+	// apply the per-component container overrides from the configuration
+	applyComponentOverrides(staticPodSpecs, kubeadmconstants.KubeAPIServer, &cfg.APIServer.ControlPlaneComponent)
+	applyComponentOverrides(staticPodSpecs, kubeadmconstants.KubeControllerManager, &cfg.ControllerManager)
+	applyComponentOverrides(staticPodSpecs, kubeadmconstants.KubeScheduler, &cfg.Scheduler)
+
 	return staticPodSpecs
 }
 
-// CreateStaticPodFiles creates all the requested static pod files.
+// applyComponentOverrides applies the structured resource and security context
+// overrides of a component onto its static pod spec, so these routine customizations
+// don't require patches
+func applyComponentOverrides(specs map[string]v1.Pod, componentName string, component *kubeadmapi.ControlPlaneComponent) {
+	pod, ok := specs[componentName]
+	if !ok {
+		return
+	}
+	if component.Resources != nil {
+		pod.Spec.Containers[0].Resources = *component.Resources
+	}
+	if component.SecurityContext != nil {
+		pod.Spec.Containers[0].SecurityContext = component.SecurityContext
+	}
+	specs[componentName] = pod
+}
+
+// RenderStaticPodManifests returns the YAML encoded static pod manifests kubeadm would
+// write for the requested control plane components, keyed by component name. It is the
+// library entry point for tooling that wants to render the manifests without writing
+// them to disk.
+func RenderStaticPodManifests(cfg *kubeadmapi.ClusterConfiguration, endpoint *kubeadmapi.APIEndpoint, componentNames ...string) (map[string][]byte, error) {
+	specs := GetStaticPodSpecs(cfg, endpoint)
+
+	manifests := map[string][]byte{}
+	for _, componentName := range componentNames {
+		spec, exists := specs[componentName]
+		if !exists {
+			return nil, errors.Errorf("couldn't retrieve StaticPodSpec for %q", componentName)
+		}
+		serialized, err := kubeadmutil.MarshalToYaml(&spec, v1.SchemeGroupVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal manifest for %q to YAML", componentName)
+		}
+		manifests[componentName] = serialized
+	}
+	return manifests, nil
+}
+
+// CreateStaticPodFiles creates all the requested static pod files. The writes are staged
+// in a file transaction and swapped into place together, so an interruption can not
+// leave a half-written set of manifests behind.
 func CreateStaticPodFiles(manifestDir, kustomizeDir string, cfg *kubeadmapi.ClusterConfiguration, endpoint *kubeadmapi.APIEndpoint, componentNames ...string) error {
 	// gets the StaticPodSpecs, actualized for the current ClusterConfiguration
 	klog.V(1).Infoln("[control-plane] getting StaticPodSpecs")
 	specs := GetStaticPodSpecs(cfg, endpoint)
 
-	// creates required static pod specs
+	// stages the required static pod specs
+	transaction := kubeadmutil.NewFileTransaction()
+	defer transaction.Rollback()
 	for _, componentName := range componentNames {
 		// retrieves the StaticPodSpec for given component
 		spec, exists := specs[componentName]
@@ -107,11 +156,22 @@ func CreateStaticPodFiles(manifestDir, kustomizeDir string, cfg *kubeadmapi.Clus
 			spec = *kustomizedSpec
 		}
 
-		// writes the StaticPodSpec to disk
-		if err := staticpodutil.WriteStaticPodToDisk(componentName, manifestDir, spec); err != nil {
-			return errors.Wrapf(err, "failed to create static pod manifest file for %q", componentName)
+		// stages the StaticPodSpec write
+		serialized, err := kubeadmutil.MarshalToYaml(&spec, v1.SchemeGroupVersion)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal manifest for %q to YAML", componentName)
 		}
+		if err := transaction.WriteFile(kubeadmconstants.GetStaticPodFilepath(componentName, manifestDir), serialized, 0600); err != nil {
+			return errors.Wrapf(err, "failed to stage static pod manifest file for %q", componentName)
+		}
+	}
 
+	// swaps all staged manifests into place together
+	if err := transaction.Commit(); err != nil {
+		return err
+	}
+
+	for _, componentName := range componentNames {
 		klog.V(1).Infof("[control-plane] wrote static Pod manifest for component %q to %q\n", componentName, kubeadmconstants.GetStaticPodFilepath(componentName, manifestDir))
 	}
 
@@ -186,6 +246,38 @@ func getAPIServerCommand(cfg *kubeadmapi.ClusterConfiguration, localAPIEndpoint
 		defaultArguments["feature-gates"] = fmt.Sprintf("%s=%t", features.IPv6DualStack, enabled)
 	}
 
+	if cfg.APIServer.EncryptionAtRest != nil {
+		defaultArguments["encryption-provider-config"] = filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.EncryptionConfigurationFileName)
+	}
+
+	if cfg.APIServer.Admission != nil {
+		defaultArguments["admission-control-config-file"] = filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.AdmissionConfigurationFileName)
+	}
+
+	if cfg.Konnectivity != nil {
+		defaultArguments["egress-selector-config-file"] = filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.EgressSelectorConfigurationFileName)
+	}
+
+	if cfg.APIServer.Audit != nil {
+		audit := cfg.APIServer.Audit
+		policyFile := audit.PolicyFile
+		if audit.PolicyContent != "" {
+			policyFile = filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.AuditPolicyFileName)
+		}
+		defaultArguments["audit-policy-file"] = policyFile
+		if audit.LogDir != "" {
+			defaultArguments["audit-log-path"] = filepath.Join(audit.LogDir, "audit.log")
+			if audit.LogMaxAge != nil {
+				defaultArguments["audit-log-maxage"] = fmt.Sprintf("%d", *audit.LogMaxAge)
+			}
+		}
+		if audit.WebhookConfigFile != "" {
+			defaultArguments["audit-webhook-config-file"] = audit.WebhookConfigFile
+		}
+	}
+
+	applyTLSConfiguration(cfg, defaultArguments)
+
 	if cfg.APIServer.ExtraArgs == nil {
 		cfg.APIServer.ExtraArgs = map[string]string{}
 	}
@@ -195,6 +287,14 @@ func getAPIServerCommand(cfg *kubeadmapi.ClusterConfiguration, localAPIEndpoint
 	return command
 }
 
+// TODO: once the kube-apiserver of the supported versions accepts the structured
+// AuthenticationConfiguration and AuthorizationConfiguration files (replacing the
+// discrete OIDC/JWT authenticator and authorizer chain flags), generate and mount those
+// files from dedicated ClusterConfiguration sections like the admission and audit
+// configurations above. The flag surface of this Kubernetes version only supports
+// authorization-mode and the individual authentication flags, so there is nothing to
+// render yet.
+
 // getAuthzModes gets the authorization-related parameters to the api server
 // Node,RBAC is the default mode if nothing is passed to kubeadm. User provided modes override
 // the default.
@@ -244,6 +344,19 @@ func isValidAuthzMode(authzMode string) bool {
 	return false
 }
 
+// applyTLSConfiguration renders the cluster wide TLS policy into component arguments
+func applyTLSConfiguration(cfg *kubeadmapi.ClusterConfiguration, defaultArguments map[string]string) {
+	if cfg.TLS == nil {
+		return
+	}
+	if cfg.TLS.MinVersion != "" {
+		defaultArguments["tls-min-version"] = cfg.TLS.MinVersion
+	}
+	if len(cfg.TLS.CipherSuites) > 0 {
+		defaultArguments["tls-cipher-suites"] = strings.Join(cfg.TLS.CipherSuites, ",")
+	}
+}
+
 // calcNodeCidrSize determines the size of the subnets used on each node, based
 // on the pod subnet provided.  For IPv4, we assume that the pod subnet will
 // be /16 and use /24. If the pod subnet cannot be parsed, the IPv4 value will
@@ -297,7 +410,7 @@ func getControllerManagerCommand(cfg *kubeadmapi.ClusterConfiguration) []string
 	caFile := filepath.Join(cfg.CertificatesDir, kubeadmconstants.CACertName)
 
 	defaultArguments := map[string]string{
-		"bind-address":                     "127.0.0.1",
+		"bind-address":                     staticpodutil.GetDefaultBindAddress(cfg),
 		"leader-elect":                     "true",
 		"kubeconfig":                       kubeconfigFile,
 		"authentication-kubeconfig":        kubeconfigFile,
@@ -319,6 +432,8 @@ func getControllerManagerCommand(cfg *kubeadmapi.ClusterConfiguration) []string
 		defaultArguments["cluster-signing-cert-file"] = ""
 	}
 
+	applyTLSConfiguration(cfg, defaultArguments)
+
 	// Let the controller-manager allocate Node CIDRs for the Pod network.
 	// Each node will get a subspace of the address CIDR provided with --pod-network-cidr.
 	if cfg.Networking.PodSubnet != "" {
@@ -367,13 +482,15 @@ func getControllerManagerCommand(cfg *kubeadmapi.ClusterConfiguration) []string
 func getSchedulerCommand(cfg *kubeadmapi.ClusterConfiguration) []string {
 	kubeconfigFile := filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.SchedulerKubeConfigFileName)
 	defaultArguments := map[string]string{
-		"bind-address":              "127.0.0.1",
+		"bind-address":              staticpodutil.GetDefaultBindAddress(cfg),
 		"leader-elect":              "true",
 		"kubeconfig":                kubeconfigFile,
 		"authentication-kubeconfig": kubeconfigFile,
 		"authorization-kubeconfig":  kubeconfigFile,
 	}
 
+	applyTLSConfiguration(cfg, defaultArguments)
+
 	// TODO: The following code should be remvoved after dual-stack is GA.
 	// Note: The user still retains the ability to explicitly set feature-gates and that value will overwrite this base value.
 	if enabled, present := cfg.FeatureGates[features.IPv6DualStack]; present {
@@ -54,6 +54,43 @@ func getHostPathVolumesForTheControlPlane(cfg *kubeadmapi.ClusterConfiguration)
 	// Read-only mount for the ca certs (/etc/ssl/certs) directory
 	mounts.NewHostPathMount(kubeadmconstants.KubeAPIServer, caCertsVolumeName, caCertsVolumePath, caCertsVolumePath, true, &hostPathDirectoryOrCreate)
 
+	// If encryption at rest is enabled, mount the generated encryption configuration
+	if cfg.APIServer.EncryptionAtRest != nil {
+		encryptionConfigFile := filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.EncryptionConfigurationFileName)
+		mounts.NewHostPathMount(kubeadmconstants.KubeAPIServer, kubeadmconstants.EncryptionConfigurationVolumeName, encryptionConfigFile, encryptionConfigFile, true, &hostPathFileOrCreate)
+	}
+
+	// If admission plugin configuration is declared, mount the generated admission configuration
+	if cfg.APIServer.Admission != nil {
+		admissionConfigFile := filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.AdmissionConfigurationFileName)
+		mounts.NewHostPathMount(kubeadmconstants.KubeAPIServer, kubeadmconstants.AdmissionConfigurationVolumeName, admissionConfigFile, admissionConfigFile, true, &hostPathFileOrCreate)
+	}
+
+	// If audit is configured, mount the policy, the log directory and the webhook kubeconfig
+	if cfg.APIServer.Audit != nil {
+		audit := cfg.APIServer.Audit
+		policyFile := audit.PolicyFile
+		if audit.PolicyContent != "" {
+			policyFile = filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.AuditPolicyFileName)
+		}
+		mounts.NewHostPathMount(kubeadmconstants.KubeAPIServer, kubeadmconstants.AuditPolicyVolumeName, policyFile, policyFile, true, &hostPathFileOrCreate)
+		if audit.LogDir != "" {
+			mounts.NewHostPathMount(kubeadmconstants.KubeAPIServer, kubeadmconstants.AuditLogVolumeName, audit.LogDir, audit.LogDir, false, &hostPathDirectoryOrCreate)
+		}
+		if audit.WebhookConfigFile != "" {
+			mounts.NewHostPathMount(kubeadmconstants.KubeAPIServer, kubeadmconstants.AuditWebhookVolumeName, audit.WebhookConfigFile, audit.WebhookConfigFile, true, &hostPathFileOrCreate)
+		}
+	}
+
+	// If konnectivity is enabled, mount the egress selector configuration and the
+	// directory holding the konnectivity-server UDS
+	if cfg.Konnectivity != nil {
+		egressConfigFile := filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.EgressSelectorConfigurationFileName)
+		mounts.NewHostPathMount(kubeadmconstants.KubeAPIServer, kubeadmconstants.EgressSelectorConfigurationVolumeName, egressConfigFile, egressConfigFile, true, &hostPathFileOrCreate)
+		konnectivityUDSDir := filepath.Join(kubeadmconstants.KubernetesDir, "konnectivity-server")
+		mounts.NewHostPathMount(kubeadmconstants.KubeAPIServer, kubeadmconstants.KonnectivityServerVolumeName, konnectivityUDSDir, konnectivityUDSDir, false, &hostPathDirectoryOrCreate)
+	}
+
 	// If external etcd is specified, mount the directories needed for accessing the CA/serving certs and the private key
 	if cfg.Etcd.External != nil {
 		etcdVols, etcdVolMounts := getEtcdCertVolumes(cfg.Etcd.External, cfg.CertificatesDir)
@@ -147,6 +184,14 @@ func (c *controlPlaneHostPathMounts) AddExtraHostPathMounts(component string, ex
 		fmt.Printf("[control-plane] Adding extra host path mount %q to %q\n", extraVol.Name, component)
 		hostPathType := extraVol.PathType
 		c.NewHostPathMount(component, extraVol.Name, extraVol.HostPath, extraVol.MountPath, extraVol.ReadOnly, &hostPathType)
+
+		// apply the requested mount propagation to the volume mount just added
+		if extraVol.Propagation != "" {
+			propagation := extraVol.Propagation
+			volumeMount := c.volumeMounts[component][extraVol.Name]
+			volumeMount.MountPropagation = &propagation
+			c.volumeMounts[component][extraVol.Name] = volumeMount
+		}
 	}
 }
 
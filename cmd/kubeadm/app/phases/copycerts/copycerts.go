@@ -23,6 +23,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -49,9 +50,18 @@ const (
 	externalEtcdKey  = "external-etcd.key"
 )
 
+// certTokenTTL returns the time to live for the token protecting the kubeadm-certs
+// Secret, honoring the value configured in the ClusterConfiguration
+func certTokenTTL(cfg *kubeadmapi.InitConfiguration) time.Duration {
+	if cfg != nil && cfg.ClusterConfiguration.CertificateKeyTTL != nil {
+		return cfg.ClusterConfiguration.CertificateKeyTTL.Duration
+	}
+	return kubeadmconstants.DefaultCertTokenDuration
+}
+
 // createShortLivedBootstrapToken creates the token used to manager kubeadm-certs
 // and return the tokenID
-func createShortLivedBootstrapToken(client clientset.Interface) (string, error) {
+func createShortLivedBootstrapToken(client clientset.Interface, ttl time.Duration) (string, error) {
 	tokenStr, err := bootstraputil.GenerateBootstrapToken()
 	if err != nil {
 		return "", errors.Wrap(err, "error generating token to upload certs")
@@ -64,7 +74,7 @@ func createShortLivedBootstrapToken(client clientset.Interface) (string, error)
 		Token:       token,
 		Description: "Proxy for managing TTL for the kubeadm-certs secret",
 		TTL: &metav1.Duration{
-			Duration: kubeadmconstants.DefaultCertTokenDuration,
+			Duration: ttl,
 		},
 	}}
 
@@ -74,7 +84,7 @@ func createShortLivedBootstrapToken(client clientset.Interface) (string, error)
 	return tokens[0].Token.ID, nil
 }
 
-//CreateCertificateKey returns a cryptographically secure random key
+// CreateCertificateKey returns a cryptographically secure random key
 func CreateCertificateKey() (string, error) {
 	randBytes, err := cryptoutil.CreateRandBytes(kubeadmconstants.CertificateKeySize)
 	if err != nil {
@@ -83,14 +93,14 @@ func CreateCertificateKey() (string, error) {
 	return hex.EncodeToString(randBytes), nil
 }
 
-//UploadCerts save certs needs to join a new control-plane on kubeadm-certs sercret.
+// UploadCerts save certs needs to join a new control-plane on kubeadm-certs sercret.
 func UploadCerts(client clientset.Interface, cfg *kubeadmapi.InitConfiguration, key string) error {
 	fmt.Printf("[upload-certs] Storing the certificates in Secret %q in the %q Namespace\n", kubeadmconstants.KubeadmCertsSecret, metav1.NamespaceSystem)
 	decodedKey, err := hex.DecodeString(key)
 	if err != nil {
 		return errors.Wrap(err, "error decoding certificate key")
 	}
-	tokenID, err := createShortLivedBootstrapToken(client)
+	tokenID, err := createShortLivedBootstrapToken(client, certTokenTTL(cfg))
 	if err != nil {
 		return err
 	}
@@ -292,3 +302,144 @@ func getDataFromSecret(secret *v1.Secret, key []byte) (map[string][]byte, error)
 func certOrKeyNameToSecretName(certOrKeyName string) string {
 	return strings.Replace(certOrKeyName, "/", "-", -1)
 }
+
+// UploadedCertsInfo describes the state of the uploaded certificates Secret
+type UploadedCertsInfo struct {
+	// Created is the creation timestamp of the kubeadm-certs Secret
+	Created metav1.Time `json:"created"`
+
+	// Expires is the point in time the Secret will be cleaned up, taken from the
+	// expiration of the bootstrap token owning it; nil if it cannot be determined
+	Expires *metav1.Time `json:"expires,omitempty"`
+}
+
+// GetUploadedCertsInfo returns the state of the uploaded certificates Secret, or an
+// error when no certificates are currently uploaded
+func GetUploadedCertsInfo(client clientset.Interface) (*UploadedCertsInfo, error) {
+	secret, err := client.CoreV1().Secrets(metav1.NamespaceSystem).Get(kubeadmconstants.KubeadmCertsSecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the %q Secret", kubeadmconstants.KubeadmCertsSecret)
+	}
+
+	info := &UploadedCertsInfo{Created: secret.CreationTimestamp}
+	for _, owner := range secret.OwnerReferences {
+		tokenSecret, err := client.CoreV1().Secrets(metav1.NamespaceSystem).Get(owner.Name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		token, err := kubeadmapi.BootstrapTokenFromSecret(tokenSecret)
+		if err != nil {
+			continue
+		}
+		info.Expires = token.Expires
+	}
+	return info, nil
+}
+
+// RevokeCerts deletes the uploaded certificates Secret and the bootstrap token managing
+// its TTL, invalidating all previously issued certificate keys immediately
+func RevokeCerts(client clientset.Interface) error {
+	secret, err := client.CoreV1().Secrets(metav1.NamespaceSystem).Get(kubeadmconstants.KubeadmCertsSecret, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		fmt.Printf("[upload-certs] No %q Secret found; nothing to revoke\n", kubeadmconstants.KubeadmCertsSecret)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the %q Secret", kubeadmconstants.KubeadmCertsSecret)
+	}
+
+	for _, owner := range secret.OwnerReferences {
+		if err := client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(owner.Name, nil); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the token Secret %q owning the uploaded certificates", owner.Name)
+		}
+	}
+
+	if err := client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(kubeadmconstants.KubeadmCertsSecret, nil); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete the %q Secret", kubeadmconstants.KubeadmCertsSecret)
+	}
+
+	fmt.Printf("[upload-certs] Revoked the uploaded certificates in Secret %q\n", kubeadmconstants.KubeadmCertsSecret)
+	return nil
+}
+
+// RotateCerts revokes the currently uploaded certificates and re-uploads them encrypted
+// with a freshly generated certificate key, which is returned
+func RotateCerts(client clientset.Interface, cfg *kubeadmapi.InitConfiguration) (string, error) {
+	if err := RevokeCerts(client); err != nil {
+		return "", err
+	}
+
+	key, err := CreateCertificateKey()
+	if err != nil {
+		return "", err
+	}
+
+	if err := UploadCerts(client, cfg, key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// UploadEncryptedArtifacts generalizes the certificate sharing mechanism: the passed
+// artifacts are encrypted with the hex encoded key and stored in a Secret with the
+// given name, whose lifetime is bound to a freshly created short-lived bootstrap token
+// - so any per-node artifact (konnectivity agent certs, custom kubeconfigs) can be
+// distributed to joining nodes the same way the control-plane certificates are.
+func UploadEncryptedArtifacts(client clientset.Interface, cfg *kubeadmapi.InitConfiguration, secretName, key string, artifacts map[string][]byte) error {
+	decodedKey, err := hex.DecodeString(key)
+	if err != nil {
+		return errors.Wrap(err, "error decoding the artifact encryption key")
+	}
+
+	secretData := map[string][]byte{}
+	for name, artifact := range artifacts {
+		encrypted, err := cryptoutil.EncryptBytes(artifact, decodedKey)
+		if err != nil {
+			return errors.Wrapf(err, "error encrypting artifact %q", name)
+		}
+		secretData[name] = encrypted
+	}
+
+	tokenID, err := createShortLivedBootstrapToken(client, certTokenTTL(cfg))
+	if err != nil {
+		return err
+	}
+	ref, err := getSecretOwnerRef(client, tokenID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("[upload-artifacts] Storing %d encrypted artifact(s) in Secret %q in the %q Namespace\n", len(artifacts), secretName, metav1.NamespaceSystem)
+	return apiclient.CreateOrUpdateSecret(client, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            secretName,
+			Namespace:       metav1.NamespaceSystem,
+			OwnerReferences: ref,
+		},
+		Data: secretData,
+	})
+}
+
+// DownloadEncryptedArtifacts retrieves and decrypts the artifacts stored with
+// UploadEncryptedArtifacts
+func DownloadEncryptedArtifacts(client clientset.Interface, secretName, key string) (map[string][]byte, error) {
+	decodedKey, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding the artifact encryption key")
+	}
+
+	secret, err := client.CoreV1().Secrets(metav1.NamespaceSystem).Get(secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error downloading the artifact Secret %q", secretName)
+	}
+
+	artifacts := map[string][]byte{}
+	for name, encrypted := range secret.Data {
+		artifact, err := cryptoutil.DecryptBytes(encrypted, decodedKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decrypting artifact %q; the key may be wrong", name)
+		}
+		artifacts[name] = artifact
+	}
+	return artifacts, nil
+}
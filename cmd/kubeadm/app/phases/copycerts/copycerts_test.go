@@ -278,3 +278,44 @@ func createKubeadmCertsSecret(t *testing.T, cfg *kubeadmapi.InitConfiguration, s
 		Data: secretData,
 	}
 }
+
+func TestUploadDownloadEncryptedArtifacts(t *testing.T) {
+	initConfiguration := &kubeadmapi.InitConfiguration{}
+	client := fakeclient.NewSimpleClientset()
+
+	key, err := CreateCertificateKey()
+	if err != nil {
+		t.Fatalf("could not create a key: %v", err)
+	}
+
+	artifacts := map[string][]byte{
+		"agent.kubeconfig": []byte("kubeconfig-contents"),
+		"agent.crt":        []byte("certificate-contents"),
+	}
+	if err := UploadEncryptedArtifacts(client, initConfiguration, "kubeadm-test-artifacts", key, artifacts); err != nil {
+		t.Fatalf("UploadEncryptedArtifacts failed: %v", err)
+	}
+
+	// the stored data must not be plaintext
+	secret, err := client.CoreV1().Secrets(metav1.NamespaceSystem).Get("kubeadm-test-artifacts", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("the artifact Secret was not created: %v", err)
+	}
+	if string(secret.Data["agent.kubeconfig"]) == "kubeconfig-contents" {
+		t.Fatal("the stored artifact is not encrypted")
+	}
+
+	downloaded, err := DownloadEncryptedArtifacts(client, "kubeadm-test-artifacts", key)
+	if err != nil {
+		t.Fatalf("DownloadEncryptedArtifacts failed: %v", err)
+	}
+	if string(downloaded["agent.kubeconfig"]) != "kubeconfig-contents" || string(downloaded["agent.crt"]) != "certificate-contents" {
+		t.Errorf("unexpected artifacts after the round trip: %v", downloaded)
+	}
+
+	// a wrong key must not decrypt
+	otherKey, _ := CreateCertificateKey()
+	if _, err := DownloadEncryptedArtifacts(client, "kubeadm-test-artifacts", otherKey); err == nil {
+		t.Error("expected an error when downloading with the wrong key, got none")
+	}
+}
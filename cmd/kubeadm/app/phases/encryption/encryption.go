@@ -0,0 +1,221 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryption implements the bootstrap of encryption of API data at rest: it
+// generates the EncryptionConfiguration file consumed by the kube-apiserver and supports
+// rotating the encryption keys and re-encrypting the stored Secrets.
+package encryption
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	cryptoutil "k8s.io/kubernetes/cmd/kubeadm/app/util/crypto"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// encryptionKeySize is the size in bytes of the generated encryption keys; all
+	// supported providers use 32 byte keys
+	encryptionKeySize = 32
+)
+
+// encryptionConfiguration mirrors the apiserver EncryptionConfiguration file structure
+// for the subset kubeadm manages
+type encryptionConfiguration struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Resources  []encryptionResource `json:"resources"`
+}
+
+type encryptionResource struct {
+	Resources []string             `json:"resources"`
+	Providers []encryptionProvider `json:"providers"`
+}
+
+type encryptionProvider struct {
+	AESCBC    *encryptionKeys `json:"aescbc,omitempty"`
+	AESGCM    *encryptionKeys `json:"aesgcm,omitempty"`
+	Secretbox *encryptionKeys `json:"secretbox,omitempty"`
+	Identity  *struct{}       `json:"identity,omitempty"`
+}
+
+type encryptionKeys struct {
+	Keys []encryptionKey `json:"keys"`
+}
+
+type encryptionKey struct {
+	Name   string `json:"name"`
+	Secret string `json:"secret"`
+}
+
+// GetEncryptionConfigPath returns the location of the encryption configuration file
+func GetEncryptionConfigPath() string {
+	return filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.EncryptionConfigurationFileName)
+}
+
+// CreateEncryptionConfiguration writes the EncryptionConfiguration file for the provider
+// configured in the ClusterConfiguration, generating a fresh key. An already existing
+// file is left untouched, so the keys encrypting the current data are never lost.
+func CreateEncryptionConfiguration(cfg *kubeadmapi.ClusterConfiguration) error {
+	if cfg.APIServer.EncryptionAtRest == nil {
+		return nil
+	}
+
+	path := GetEncryptionConfigPath()
+	if _, err := os.Stat(path); err == nil {
+		klog.V(1).Infof("[encryption] the encryption configuration %q already exists; not overwriting it", path)
+		return nil
+	}
+
+	key, err := generateKey("key1")
+	if err != nil {
+		return err
+	}
+
+	config := &encryptionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "EncryptionConfiguration",
+		Resources: []encryptionResource{
+			{
+				Resources: []string{"secrets"},
+				Providers: []encryptionProvider{
+					providerWithKeys(cfg.APIServer.EncryptionAtRest.Provider, encryptionKeys{Keys: []encryptionKey{key}}),
+					{Identity: &struct{}{}},
+				},
+			},
+		},
+	}
+
+	fmt.Printf("[encryption] Writing encryption configuration to %q\n", path)
+	return writeConfig(path, config)
+}
+
+// RotateEncryptionKey generates a fresh encryption key and prepends it to the key list of
+// the configured provider, so new data is encrypted with the new key while the old keys
+// remain available for decryption until the stored data is re-encrypted.
+func RotateEncryptionKey() error {
+	path := GetEncryptionConfigPath()
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the encryption configuration %q", path)
+	}
+
+	config := &encryptionConfiguration{}
+	if err := yaml.Unmarshal(b, config); err != nil {
+		return errors.Wrapf(err, "failed to parse the encryption configuration %q", path)
+	}
+
+	rotated := false
+	for i := range config.Resources {
+		for j := range config.Resources[i].Providers {
+			keys := providerKeys(&config.Resources[i].Providers[j])
+			if keys == nil {
+				continue
+			}
+			key, err := generateKey(fmt.Sprintf("key%d", len(keys.Keys)+1))
+			if err != nil {
+				return err
+			}
+			keys.Keys = append([]encryptionKey{key}, keys.Keys...)
+			rotated = true
+		}
+	}
+	if !rotated {
+		return errors.Errorf("no keyed encryption provider found in %q", path)
+	}
+
+	fmt.Printf("[encryption] Prepended a fresh key to the encryption configuration %q\n", path)
+	fmt.Println("[encryption] Restart the kube-apiserver and run the re-encryption to complete the rotation")
+	return writeConfig(path, config)
+}
+
+// ReEncryptSecrets rewrites every Secret in the cluster, so all of them get encrypted
+// with the newest key of the configured provider
+func ReEncryptSecrets(client clientset.Interface) error {
+	secrets, err := client.CoreV1().Secrets(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the Secrets in the cluster")
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if _, err := client.CoreV1().Secrets(secret.Namespace).Update(secret); err != nil {
+			return errors.Wrapf(err, "failed to rewrite the Secret %s/%s", secret.Namespace, secret.Name)
+		}
+	}
+
+	fmt.Printf("[encryption] Rewrote %d Secret(s) with the current encryption key\n", len(secrets.Items))
+	return nil
+}
+
+// providerWithKeys builds an encryptionProvider for the named provider
+func providerWithKeys(provider string, keys encryptionKeys) encryptionProvider {
+	switch provider {
+	case "aesgcm":
+		return encryptionProvider{AESGCM: &keys}
+	case "secretbox":
+		return encryptionProvider{Secretbox: &keys}
+	default:
+		return encryptionProvider{AESCBC: &keys}
+	}
+}
+
+// providerKeys returns the key list of a keyed provider, or nil for identity providers
+func providerKeys(provider *encryptionProvider) *encryptionKeys {
+	switch {
+	case provider.AESCBC != nil:
+		return provider.AESCBC
+	case provider.AESGCM != nil:
+		return provider.AESGCM
+	case provider.Secretbox != nil:
+		return provider.Secretbox
+	}
+	return nil
+}
+
+// generateKey returns a named, freshly generated encryption key
+func generateKey(name string) (encryptionKey, error) {
+	randBytes, err := cryptoutil.CreateRandBytes(encryptionKeySize)
+	if err != nil {
+		return encryptionKey{}, errors.Wrap(err, "failed to generate an encryption key")
+	}
+	return encryptionKey{
+		Name:   name,
+		Secret: base64.StdEncoding.EncodeToString(randBytes),
+	}, nil
+}
+
+// writeConfig marshals the encryption configuration and writes it with tight permissions
+func writeConfig(path string, config *encryptionConfiguration) error {
+	b, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the encryption configuration")
+	}
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the encryption configuration to %q", path)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestProviderRoundTrip(t *testing.T) {
+	for _, provider := range []string{"aescbc", "aesgcm", "secretbox"} {
+		key, err := generateKey("key1")
+		if err != nil {
+			t.Fatalf("generateKey failed: %v", err)
+		}
+
+		config := &encryptionConfiguration{
+			APIVersion: "apiserver.config.k8s.io/v1",
+			Kind:       "EncryptionConfiguration",
+			Resources: []encryptionResource{
+				{
+					Resources: []string{"secrets"},
+					Providers: []encryptionProvider{
+						providerWithKeys(provider, encryptionKeys{Keys: []encryptionKey{key}}),
+						{Identity: &struct{}{}},
+					},
+				},
+			},
+		}
+
+		b, err := yaml.Marshal(config)
+		if err != nil {
+			t.Fatalf("failed to marshal the configuration for provider %q: %v", provider, err)
+		}
+
+		parsed := &encryptionConfiguration{}
+		if err := yaml.Unmarshal(b, parsed); err != nil {
+			t.Fatalf("failed to unmarshal the configuration for provider %q: %v", provider, err)
+		}
+
+		keys := providerKeys(&parsed.Resources[0].Providers[0])
+		if keys == nil || len(keys.Keys) != 1 || keys.Keys[0].Name != "key1" {
+			t.Errorf("unexpected keys after round trip for provider %q: %+v", provider, keys)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package endpointprovider implements the optional deployment of a virtual IP provider
+// static pod for the controlPlaneEndpoint, so single-datacenter HA clusters do not need
+// an out-of-band load balancer to be provisioned before init.
+package endpointprovider
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	staticpodutil "k8s.io/kubernetes/cmd/kubeadm/app/util/staticpod"
+)
+
+const (
+	// ProviderKubeVIP is the supported virtual IP provider type
+	ProviderKubeVIP = "kube-vip"
+
+	// kubeVIPManifestName is the name of the written static pod (and its manifest file)
+	kubeVIPManifestName = "kube-vip"
+
+	// defaultKubeVIPImage is used when the configuration does not name an image
+	defaultKubeVIPImage = "ghcr.io/kube-vip/kube-vip:v0.1.0"
+)
+
+// CreateEndpointProviderManifest writes the static pod manifest of the configured
+// control plane endpoint provider, if any
+func CreateEndpointProviderManifest(manifestDir string, cfg *kubeadmapi.ClusterConfiguration) error {
+	provider := cfg.ControlPlaneEndpointProvider
+	if provider == nil {
+		return nil
+	}
+	if provider.Type != ProviderKubeVIP {
+		return errors.Errorf("unsupported control plane endpoint provider type %q; only %q is supported", provider.Type, ProviderKubeVIP)
+	}
+	if cfg.ControlPlaneEndpoint == "" {
+		return errors.New("a control plane endpoint provider requires controlPlaneEndpoint to be set")
+	}
+
+	host, _, err := kubeadmutil.ParseHostPort(cfg.ControlPlaneEndpoint)
+	if err != nil {
+		return errors.Wrap(err, "cannot parse the controlPlaneEndpoint")
+	}
+
+	image := provider.Image
+	if image == "" {
+		image = defaultKubeVIPImage
+	}
+
+	pod := staticpodutil.ComponentPod(v1.Container{
+		Name:            kubeVIPManifestName,
+		Image:           image,
+		ImagePullPolicy: v1.PullIfNotPresent,
+		Args: []string{
+			"start",
+			"--interface", provider.Interface,
+			"--vip", host,
+			"--arp",
+			"--leaderElection",
+		},
+		SecurityContext: &v1.SecurityContext{
+			Capabilities: &v1.Capabilities{
+				Add: []v1.Capability{"NET_ADMIN", "SYS_TIME"},
+			},
+		},
+	}, map[string]v1.Volume{})
+
+	if err := staticpodutil.WriteStaticPodToDisk(kubeVIPManifestName, manifestDir, pod); err != nil {
+		return errors.Wrap(err, "failed to write the control plane endpoint provider manifest")
+	}
+
+	fmt.Printf("[endpoint-provider] Wrote the %q static Pod manifest announcing the virtual IP %q on interface %q\n", kubeVIPManifestName, host, provider.Interface)
+	return nil
+}
@@ -119,6 +119,14 @@ func RemoveStackedEtcdMemberFromCluster(client clientset.Interface, cfg *kubeadm
 	}
 	klog.V(1).Infof("[etcd] Updated etcd member list: %v", members)
 
+	// clean up any stale learners as well, so the cluster does not lose the ability to
+	// add members after an interrupted join
+	if removed, err := etcdClient.RemoveStaleLearners(); err != nil {
+		klog.Warningf("[etcd] failed to remove stale learner members: %v", err)
+	} else if len(removed) > 0 {
+		klog.V(1).Infof("[etcd] removed %d stale learner member(s)", len(removed))
+	}
+
 	return nil
 }
 
@@ -133,6 +141,14 @@ func CreateStackedEtcdStaticPodManifestFile(client clientset.Interface, manifest
 		return err
 	}
 
+	// clean up any stale learner left behind by an interrupted previous join, as it
+	// would prevent adding this member
+	if removed, err := etcdClient.RemoveStaleLearners(); err != nil {
+		klog.Warningf("failed to remove stale learner members: %v", err)
+	} else if len(removed) > 0 {
+		klog.V(1).Infof("removed %d stale learner member(s)", len(removed))
+	}
+
 	// notifies the other members of the etcd cluster about the joining member
 	etcdPeerAddress := etcdutil.GetPeerURL(endpoint)
 
@@ -181,6 +197,10 @@ func GetEtcdPodSpec(cfg *kubeadmapi.ClusterConfiguration, endpoint *kubeadmapi.A
 	}
 	// probeHostname returns the correct localhost IP address family based on the endpoint AdvertiseAddress
 	probeHostname, probePort, probeScheme := staticpodutil.GetEtcdProbeEndpoint(&cfg.Etcd, utilsnet.IsIPv6String(endpoint.AdvertiseAddress))
+	resources := v1.ResourceRequirements{}
+	if cfg.Etcd.Local.Resources != nil {
+		resources = *cfg.Etcd.Local.Resources
+	}
 	return staticpodutil.ComponentPod(v1.Container{
 		Name:            kubeadmconstants.Etcd,
 		Command:         getEtcdCommand(cfg, endpoint, nodeName, initialCluster),
@@ -192,6 +212,7 @@ func GetEtcdPodSpec(cfg *kubeadmapi.ClusterConfiguration, endpoint *kubeadmapi.A
 			staticpodutil.NewVolumeMount(certsVolumeName, cfg.CertificatesDir+"/etcd", false),
 		},
 		LivenessProbe: staticpodutil.LivenessProbe(probeHostname, "/health", probePort, probeScheme),
+		Resources:     resources,
 	}, etcdMounts)
 }
 
@@ -221,6 +242,25 @@ func getEtcdCommand(cfg *kubeadmapi.ClusterConfiguration, endpoint *kubeadmapi.A
 		"listen-metrics-urls":         fmt.Sprintf("http://%s", net.JoinHostPort(etcdLocalhostAddress, strconv.Itoa(kubeadmconstants.EtcdMetricsPort))),
 	}
 
+	// Apply the structured tuning fields; explicit extraArgs still take precedence
+	if cfg.Etcd.Local.QuotaBackendBytes != nil {
+		defaultArguments["quota-backend-bytes"] = strconv.FormatInt(*cfg.Etcd.Local.QuotaBackendBytes, 10)
+	}
+	if cfg.Etcd.Local.HeartbeatInterval != nil {
+		defaultArguments["heartbeat-interval"] = strconv.FormatInt(cfg.Etcd.Local.HeartbeatInterval.Milliseconds(), 10)
+	}
+	if cfg.Etcd.Local.ElectionTimeout != nil {
+		defaultArguments["election-timeout"] = strconv.FormatInt(cfg.Etcd.Local.ElectionTimeout.Milliseconds(), 10)
+	}
+	if cfg.Etcd.Local.AutoCompactionRetention != "" {
+		defaultArguments["auto-compaction-retention"] = cfg.Etcd.Local.AutoCompactionRetention
+	}
+
+	// etcd only accepts a cipher suite list; it derives the minimum version from it
+	if cfg.TLS != nil && len(cfg.TLS.CipherSuites) > 0 {
+		defaultArguments["cipher-suites"] = strings.Join(cfg.TLS.CipherSuites, ",")
+	}
+
 	if len(initialCluster) == 0 {
 		defaultArguments["initial-cluster"] = fmt.Sprintf("%s=%s", nodeName, etcdutil.GetPeerURL(endpoint))
 	} else {
@@ -0,0 +1,88 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package extrarbac applies the additional RBAC manifests declared in the
+// ClusterConfiguration, so cluster provisioning glue (CCM bootstrap permissions, CSI or
+// monitoring agents) can be owned and reconciled by kubeadm instead of racing it.
+package extrarbac
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	rbac "k8s.io/api/rbac/v1"
+	kuberuntime "k8s.io/apimachinery/pkg/runtime"
+	clientset "k8s.io/client-go/kubernetes"
+	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+)
+
+// EnsureExtraRBAC decodes and applies the RBAC manifests declared in the
+// ClusterConfiguration. Supported kinds are ClusterRole, ClusterRoleBinding, Role and
+// RoleBinding; anything else is rejected.
+func EnsureExtraRBAC(cfg *kubeadmapi.ClusterConfiguration, client clientset.Interface) error {
+	if len(cfg.ExtraRBAC) == 0 {
+		return nil
+	}
+
+	for i, manifest := range cfg.ExtraRBAC {
+		obj, err := decodeRBACManifest([]byte(manifest))
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode extraRBAC entry %d", i)
+		}
+
+		switch typedObj := obj.(type) {
+		case *rbac.ClusterRole:
+			err = apiclient.CreateOrUpdateClusterRole(client, typedObj)
+		case *rbac.ClusterRoleBinding:
+			err = apiclient.CreateOrUpdateClusterRoleBinding(client, typedObj)
+		case *rbac.Role:
+			err = apiclient.CreateOrUpdateRole(client, typedObj)
+		case *rbac.RoleBinding:
+			err = apiclient.CreateOrUpdateRoleBinding(client, typedObj)
+		default:
+			return errors.Errorf("extraRBAC entry %d has unsupported kind %T; only RBAC kinds are allowed", i, obj)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to apply extraRBAC entry %d", i)
+		}
+	}
+
+	fmt.Printf("[extra-rbac] Applied %d additional RBAC manifest(s)\n", len(cfg.ExtraRBAC))
+	return nil
+}
+
+// ValidateExtraRBAC verifies every declared manifest decodes into a supported RBAC kind
+func ValidateExtraRBAC(manifests []string) error {
+	for i, manifest := range manifests {
+		obj, err := decodeRBACManifest([]byte(manifest))
+		if err != nil {
+			return errors.Wrapf(err, "extraRBAC entry %d does not decode", i)
+		}
+		switch obj.(type) {
+		case *rbac.ClusterRole, *rbac.ClusterRoleBinding, *rbac.Role, *rbac.RoleBinding:
+		default:
+			return errors.Errorf("extraRBAC entry %d has unsupported kind %T; only RBAC kinds are allowed", i, obj)
+		}
+	}
+	return nil
+}
+
+// decodeRBACManifest decodes a single YAML manifest with the client-go scheme
+func decodeRBACManifest(manifest []byte) (kuberuntime.Object, error) {
+	return kuberuntime.Decode(clientsetscheme.Codecs.UniversalDeserializer(), manifest)
+}
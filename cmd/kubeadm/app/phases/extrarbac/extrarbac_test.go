@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extrarbac
+
+import (
+	"testing"
+
+	"github.com/lithammer/dedent"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+var clusterRoleManifest = dedent.Dedent(`
+	apiVersion: rbac.authorization.k8s.io/v1
+	kind: ClusterRole
+	metadata:
+	    name: extra-test-role
+	rules:
+	- apiGroups: [""]
+	  resources: ["nodes"]
+	  verbs: ["get"]
+	`)
+
+func TestEnsureExtraRBAC(t *testing.T) {
+	client := clientsetfake.NewSimpleClientset()
+	cfg := &kubeadmapi.ClusterConfiguration{ExtraRBAC: []string{clusterRoleManifest}}
+
+	if err := EnsureExtraRBAC(cfg, client); err != nil {
+		t.Fatalf("EnsureExtraRBAC failed: %v", err)
+	}
+
+	if _, err := client.RbacV1().ClusterRoles().Get("extra-test-role", metav1.GetOptions{}); err != nil {
+		t.Errorf("the declared ClusterRole was not created: %v", err)
+	}
+
+	// re-applying must reconcile without error
+	if err := EnsureExtraRBAC(cfg, client); err != nil {
+		t.Fatalf("EnsureExtraRBAC failed on reconciliation: %v", err)
+	}
+}
+
+func TestValidateExtraRBAC(t *testing.T) {
+	if err := ValidateExtraRBAC([]string{clusterRoleManifest}); err != nil {
+		t.Errorf("unexpected error for a valid manifest: %v", err)
+	}
+	if err := ValidateExtraRBAC([]string{"apiVersion: v1\nkind: Pod\nmetadata:\n    name: nope\n"}); err == nil {
+		t.Error("expected an error for a non-RBAC manifest, got none")
+	}
+	if err := ValidateExtraRBAC([]string{"not a manifest"}); err == nil {
+		t.Error("expected an error for garbage, got none")
+	}
+}
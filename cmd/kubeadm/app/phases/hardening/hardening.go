@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hardening implements the CIS Kubernetes Benchmark profile of kubeadm: a
+// configuration transform applying the benchmark's control plane settings, and an audit
+// verifying a node against the file related controls.
+package hardening
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+// Finding is the structured result of one audited control
+type Finding struct {
+	// Control names the audited CIS control
+	Control string `json:"control"`
+
+	// Status is "pass", "fail" or "skipped"
+	Status string `json:"status"`
+
+	// Detail explains the finding
+	Detail string `json:"detail,omitempty"`
+}
+
+// ApplyCISProfile adjusts a ClusterConfiguration to satisfy the CIS Kubernetes
+// Benchmark controls that are expressed through component flags. The transform only
+// sets values the user has not overridden explicitly.
+func ApplyCISProfile(cfg *kubeadmapi.ClusterConfiguration) {
+	setIfUnset := func(args *map[string]string, key, value string) {
+		if *args == nil {
+			*args = map[string]string{}
+		}
+		if _, ok := (*args)[key]; !ok {
+			(*args)[key] = value
+		}
+	}
+
+	// CIS 1.2.21: disable profiling on the API server
+	setIfUnset(&cfg.APIServer.ExtraArgs, "profiling", "false")
+	// CIS 1.2.16: deny service account token auto-mounts from being abused
+	setIfUnset(&cfg.APIServer.ExtraArgs, "service-account-lookup", "true")
+	// CIS 1.2.11: keep admission of images deterministic
+	setIfUnset(&cfg.APIServer.ExtraArgs, "enable-admission-plugins", "NodeRestriction,AlwaysPullImages")
+
+	// CIS 1.3.2: disable profiling on the controller-manager
+	setIfUnset(&cfg.ControllerManager.ExtraArgs, "profiling", "false")
+	// CIS 1.3.1: garbage collect terminated pods
+	setIfUnset(&cfg.ControllerManager.ExtraArgs, "terminated-pod-gc-threshold", "10")
+
+	// CIS 1.4.1: disable profiling on the scheduler
+	setIfUnset(&cfg.Scheduler.ExtraArgs, "profiling", "false")
+}
+
+// AuditNode verifies the file related CIS controls on this node: the static pod
+// manifests, the kubeconfig files and the PKI directory must not be world-writable or
+// group-writable and the private keys must be readable only by their owner
+func AuditNode(certificatesDir string) []Finding {
+	findings := []Finding{}
+
+	// CIS 1.1.1 - 1.1.8: manifests and kubeconfig files with 0644 or stricter
+	paths := []string{}
+	manifests, _ := filepath.Glob(filepath.Join(constants.GetStaticPodDirectory(), "*.yaml"))
+	paths = append(paths, manifests...)
+	for _, kubeconfig := range []string{
+		constants.AdminKubeConfigFileName,
+		constants.KubeletKubeConfigFileName,
+		constants.ControllerManagerKubeConfigFileName,
+		constants.SchedulerKubeConfigFileName,
+	} {
+		paths = append(paths, filepath.Join(constants.KubernetesDir, kubeconfig))
+	}
+	for _, path := range paths {
+		findings = append(findings, auditFileMode(path, 0644))
+	}
+
+	// CIS 1.1.21: private keys readable only by their owner
+	keys, _ := filepath.Glob(filepath.Join(certificatesDir, "*.key"))
+	for _, key := range keys {
+		findings = append(findings, auditFileMode(key, 0600))
+	}
+
+	return findings
+}
+
+// auditFileMode checks that a file is not more permissive than maxMode
+func auditFileMode(path string, maxMode os.FileMode) Finding {
+	finding := Finding{Control: fmt.Sprintf("permissions of %s", path)}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		finding.Status = "skipped"
+		finding.Detail = "the file does not exist on this node"
+		return finding
+	}
+	if err != nil {
+		finding.Status = "fail"
+		finding.Detail = err.Error()
+		return finding
+	}
+
+	if info.Mode().Perm()&^maxMode != 0 {
+		finding.Status = "fail"
+		finding.Detail = fmt.Sprintf("mode %04o is more permissive than the required %04o", info.Mode().Perm(), maxMode)
+		return finding
+	}
+	finding.Status = "pass"
+	return finding
+}
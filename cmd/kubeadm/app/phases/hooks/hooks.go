@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hooks runs the local commands a user declared in the hooks section of the
+// init or join configuration at the workflow points they are bound to.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+// The points of the init and join workflows a hook can be bound to
+const (
+	PreInit  = "preInit"
+	PostInit = "postInit"
+	PreJoin  = "preJoin"
+	PostJoin = "postJoin"
+)
+
+// The policies deciding what a failing hook does
+const (
+	FailurePolicyAbort  = "abort"
+	FailurePolicyIgnore = "ignore"
+)
+
+// defaultTimeout bounds a hook whose configuration does not set one
+const defaultTimeout = 5 * time.Minute
+
+// Phases returns the valid hook phase names
+func Phases() []string {
+	return []string{PreInit, PostInit, PreJoin, PostJoin}
+}
+
+// RunHooks runs the hooks bound to the given workflow point in their declared order,
+// streaming their combined output to out. A failing hook aborts with an error unless
+// its failure policy is "ignore".
+func RunHooks(out io.Writer, hooks []kubeadmapi.Hook, phase string) error {
+	for _, hook := range hooks {
+		if hook.Phase != phase {
+			continue
+		}
+
+		timeout := defaultTimeout
+		if hook.Timeout != nil {
+			timeout = hook.Timeout.Duration
+		}
+
+		fmt.Fprintf(out, "[hooks] Running the %s hook %v (timeout %v)\n", phase, hook.Command, timeout)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		cmd := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...)
+		output, err := cmd.CombinedOutput()
+		cancel()
+		if len(output) != 0 {
+			out.Write(output)
+		}
+		if err == nil {
+			continue
+		}
+
+		if ctx.Err() == context.DeadlineExceeded {
+			err = errors.Errorf("the hook did not complete within %v", timeout)
+		}
+		if hook.FailurePolicy == FailurePolicyIgnore {
+			fmt.Fprintf(out, "[hooks] The %s hook %v failed (%v); its failure policy is %q, continuing\n", phase, hook.Command, err, FailurePolicyIgnore)
+			continue
+		}
+		return errors.Wrapf(err, "the %s hook %v failed", phase, hook.Command)
+	}
+	return nil
+}
@@ -59,6 +59,12 @@ type kubeConfigSpec struct {
 	ClientCertAuth *clientCertAuth
 }
 
+// TODO: split the standing cluster-admin credential out of admin.conf into a dedicated,
+// optionally generated super-admin credential, so organizations that forbid a permanent
+// break-glass credential on disk can demote admin.conf to a scoped user and regenerate
+// the super admin credential on demand. This requires admin.conf to stop being the
+// credential kubeadm itself operates with first, which is not the case in this tree yet.
+
 // CreateJoinControlPlaneKubeConfigFiles will create and write to disk the kubeconfig files required by kubeadm
 // join --control-plane workflow, plus the admin kubeconfig file used by the administrator and kubeadm itself; the
 // kubelet.conf file must not be created because it will be created and signed by the kubelet TLS bootstrap process.
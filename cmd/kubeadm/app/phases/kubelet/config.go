@@ -18,8 +18,6 @@ package kubelet
 
 import (
 	"fmt"
-	"io/ioutil"
-	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -34,16 +32,25 @@ import (
 	"k8s.io/kubernetes/cmd/kubeadm/app/componentconfigs"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/fsys"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/kustomize"
 )
 
-// WriteConfigToDisk writes the kubelet config object down to a file
+// WriteConfigToDisk writes the kubelet config object down to a file,
+// applying any user supplied patches from kustomizeDir first.
 // Used at "kubeadm init" and "kubeadm upgrade" time
-func WriteConfigToDisk(kubeletCfg kubeadmapi.ComponentConfig, kubeletDir string) error {
+func WriteConfigToDisk(kubeletCfg kubeadmapi.ComponentConfig, kubeletDir, kustomizeDir string) error {
 
 	kubeletBytes, err := kubeletCfg.Marshal()
 	if err != nil {
 		return err
 	}
+
+	kubeletBytes, err = kustomize.ApplyPatches(kubeletBytes, kustomizeDir)
+	if err != nil {
+		return err
+	}
+
 	return writeConfigBytesToDisk(kubeletBytes, kubeletDir)
 }
 
@@ -157,17 +164,23 @@ func configMapRBACName(k8sVersion *version.Version) string {
 	return fmt.Sprintf("%s%d.%d", kubeadmconstants.KubeletBaseConfigMapRolePrefix, k8sVersion.Major(), k8sVersion.Minor())
 }
 
-// writeConfigBytesToDisk writes a byte slice down to disk at the specific location of the kubelet config file
+// writeConfigBytesToDisk writes a byte slice down to disk at the specific location of the kubelet config file,
+// merging any instance specific configuration drop-ins over it first
 func writeConfigBytesToDisk(b []byte, kubeletDir string) error {
+	b, err := applyInstanceConfiguration(b, kubeletDir)
+	if err != nil {
+		return err
+	}
+
 	configFile := filepath.Join(kubeletDir, kubeadmconstants.KubeletConfigurationFileName)
 	fmt.Printf("[kubelet-start] Writing kubelet configuration to file %q\n", configFile)
 
 	// creates target folder if not already exists
-	if err := os.MkdirAll(kubeletDir, 0700); err != nil {
+	if err := fsys.Default.MkdirAll(kubeletDir, 0700); err != nil {
 		return errors.Wrapf(err, "failed to create directory %q", kubeletDir)
 	}
 
-	if err := ioutil.WriteFile(configFile, b, 0644); err != nil {
+	if err := fsys.Default.WriteFile(configFile, b, 0644); err != nil {
 		return errors.Wrapf(err, "failed to write kubelet configuration to the file %q", configFile)
 	}
 	return nil
@@ -47,9 +47,18 @@ type kubeletFlagsOpts struct {
 // WriteKubeletDynamicEnvFile writes an environment file with dynamic flags to the kubelet.
 // Used at "kubeadm init" and "kubeadm join" time.
 func WriteKubeletDynamicEnvFile(cfg *kubeadmapi.ClusterConfiguration, nodeReg *kubeadmapi.NodeRegistrationOptions, registerTaintsUsingFlags bool, kubeletDir string) error {
+	_, err := ReconcileKubeletDynamicEnvFile(cfg, nodeReg, registerTaintsUsingFlags, kubeletDir)
+	return err
+}
+
+// ReconcileKubeletDynamicEnvFile regenerates the environment file with dynamic flags for
+// the kubelet and writes it only when its content differs from what is on disk. It
+// returns whether the file changed, so callers can skip an unnecessary kubelet restart
+// during upgrades.
+func ReconcileKubeletDynamicEnvFile(cfg *kubeadmapi.ClusterConfiguration, nodeReg *kubeadmapi.NodeRegistrationOptions, registerTaintsUsingFlags bool, kubeletDir string) (bool, error) {
 	hostName, err := kubeadmutil.GetHostname("")
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	flagOpts := kubeletFlagsOpts{
@@ -71,7 +80,14 @@ func WriteKubeletDynamicEnvFile(cfg *kubeadmapi.ClusterConfiguration, nodeReg *k
 	argList := kubeadmutil.BuildArgumentListFromMap(stringMap, nodeReg.KubeletExtraArgs)
 	envFileContent := fmt.Sprintf("%s=%q\n", constants.KubeletEnvFileVariableName, strings.Join(argList, " "))
 
-	return writeKubeletFlagBytesToDisk([]byte(envFileContent), kubeletDir)
+	// don't touch the file (and don't make callers restart the kubelet) when nothing changed
+	currentContent, err := ioutil.ReadFile(filepath.Join(kubeletDir, constants.KubeletEnvFileName))
+	if err == nil && string(currentContent) == envFileContent {
+		klog.V(1).Infof("[kubelet-start] the kubelet environment file in %q is up to date", kubeletDir)
+		return false, nil
+	}
+
+	return true, writeKubeletFlagBytesToDisk([]byte(envFileContent), kubeletDir)
 }
 
 // buildKubeletArgMap takes a kubeletFlagsOpts object and builds based on that a string-string map with flags
@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+	kubeletconfig "k8s.io/kubelet/config/v1beta1"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/initsystem"
+	utilsexec "k8s.io/utils/exec"
+	"sigs.k8s.io/yaml"
+)
+
+// WriteInstanceConfigToDisk writes a kubelet configuration drop-in file holding the
+// configuration fields that must differ per node (such as cgroupDriver, resolvConf and
+// providerID). The drop-in is merged over the cluster-wide kubelet configuration every
+// time the kubelet configuration file is written, so upgrades reconcile rather than
+// overwrite the per-node settings.
+// Used at "kubeadm init" and "kubeadm join" time.
+func WriteInstanceConfigToDisk(nodeReg *kubeadmapi.NodeRegistrationOptions, kubeletDir string) error {
+	isServiceActiveFunc := func(name string) (bool, error) {
+		initSystem, err := initsystem.GetInitSystem()
+		if err != nil {
+			return false, err
+		}
+		return initSystem.ServiceIsActive(name), nil
+	}
+
+	instanceCfg := buildKubeletInstanceConfig(nodeReg, utilsexec.New(), isServiceActiveFunc)
+	if len(instanceCfg) == 0 {
+		klog.V(1).Infoln("[kubelet-start] no instance specific kubelet configuration fields detected; not writing a drop-in file")
+		return nil
+	}
+
+	doc := map[string]interface{}{
+		"apiVersion": kubeletconfig.SchemeGroupVersion.String(),
+		"kind":       "KubeletConfiguration",
+	}
+	for field, value := range instanceCfg {
+		doc[field] = value
+	}
+
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the instance specific kubelet configuration")
+	}
+
+	return writeInstanceConfigBytesToDisk(b, kubeletDir)
+}
+
+// buildKubeletInstanceConfig detects the kubelet configuration fields that must differ per
+// node and returns them as a field name to value map, suitable for use as a drop-in document
+func buildKubeletInstanceConfig(nodeReg *kubeadmapi.NodeRegistrationOptions, execer utilsexec.Interface, isServiceActiveFunc func(string) (bool, error)) map[string]interface{} {
+	instanceCfg := map[string]interface{}{}
+
+	if nodeReg.CRISocket == constants.DefaultDockerCRISocket {
+		driver, err := kubeadmutil.GetCgroupDriverDocker(execer)
+		if err != nil {
+			klog.Warningf("cannot automatically assign a 'cgroupDriver' value when starting the Kubelet: %v\n", err)
+		} else {
+			instanceCfg["cgroupDriver"] = driver
+		}
+	}
+
+	ok, err := isServiceActiveFunc("systemd-resolved")
+	if err != nil {
+		klog.Warningf("cannot determine if systemd-resolved is active: %v\n", err)
+	}
+	if ok {
+		instanceCfg["resolvConf"] = "/run/systemd/resolve/resolv.conf"
+	}
+
+	if providerID := nodeReg.KubeletExtraArgs["provider-id"]; providerID != "" {
+		instanceCfg["providerID"] = providerID
+	}
+
+	return instanceCfg
+}
+
+// applyInstanceConfiguration merges all drop-in files found in the config.d directory under
+// kubeletDir over the passed cluster-wide kubelet configuration, in lexical file order
+func applyInstanceConfiguration(b []byte, kubeletDir string) ([]byte, error) {
+	dropInDir := filepath.Join(kubeletDir, constants.KubeletInstanceConfigurationDirectoryName)
+	files, err := ioutil.ReadDir(dropInDir)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the kubelet configuration drop-in directory %q", dropInDir)
+	}
+
+	baseJSON, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the kubelet configuration")
+	}
+
+	for _, file := range files {
+		fileName := file.Name()
+		if file.IsDir() || (!strings.HasSuffix(fileName, ".yaml") && !strings.HasSuffix(fileName, ".yml")) {
+			continue
+		}
+
+		dropInFile := filepath.Join(dropInDir, fileName)
+		dropIn, err := ioutil.ReadFile(dropInFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the kubelet configuration drop-in file %q", dropInFile)
+		}
+
+		dropInJSON, err := yaml.YAMLToJSON(dropIn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the kubelet configuration drop-in file %q", dropInFile)
+		}
+
+		baseJSON, err = jsonpatch.MergePatch(baseJSON, dropInJSON)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to merge the kubelet configuration drop-in file %q", dropInFile)
+		}
+	}
+
+	return yaml.JSONToYAML(baseJSON)
+}
+
+// writeInstanceConfigBytesToDisk writes a byte slice down to disk at the location of the
+// instance specific kubelet configuration drop-in file
+func writeInstanceConfigBytesToDisk(b []byte, kubeletDir string) error {
+	dropInDir := filepath.Join(kubeletDir, constants.KubeletInstanceConfigurationDirectoryName)
+	dropInFile := filepath.Join(dropInDir, constants.KubeletInstanceConfigurationFileName)
+	fmt.Printf("[kubelet-start] Writing instance specific kubelet configuration to file %q\n", dropInFile)
+
+	// creates target folder if not already exists
+	if err := os.MkdirAll(dropInDir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory %q", dropInDir)
+	}
+
+	if err := ioutil.WriteFile(dropInFile, b, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write instance specific kubelet configuration to the file %q", dropInFile)
+	}
+	return nil
+}
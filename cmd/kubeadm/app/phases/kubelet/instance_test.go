@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lithammer/dedent"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+func TestBuildKubeletInstanceConfig(t *testing.T) {
+	var tests = []struct {
+		name     string
+		opts     kubeletFlagsOpts
+		expected map[string]interface{}
+	}{
+		{
+			name: "docker runtime with systemd cgroup driver",
+			opts: kubeletFlagsOpts{
+				nodeRegOpts: &kubeadmapi.NodeRegistrationOptions{
+					CRISocket: constants.DefaultDockerCRISocket,
+				},
+				execer:              systemdCgroupExecer,
+				isServiceActiveFunc: serviceIsNotActiveFunc,
+			},
+			expected: map[string]interface{}{
+				"cgroupDriver": "systemd",
+			},
+		},
+		{
+			name: "external runtime with active systemd-resolved",
+			opts: kubeletFlagsOpts{
+				nodeRegOpts: &kubeadmapi.NodeRegistrationOptions{
+					CRISocket: "/var/run/containerd/containerd.sock",
+				},
+				execer:              errCgroupExecer,
+				isServiceActiveFunc: serviceIsActiveFunc,
+			},
+			expected: map[string]interface{}{
+				"resolvConf": "/run/systemd/resolve/resolv.conf",
+			},
+		},
+		{
+			name: "provider-id is taken from the kubelet extra args",
+			opts: kubeletFlagsOpts{
+				nodeRegOpts: &kubeadmapi.NodeRegistrationOptions{
+					CRISocket:        "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{"provider-id": "aws:///us-east-1a/i-012345"},
+				},
+				execer:              errCgroupExecer,
+				isServiceActiveFunc: serviceIsNotActiveFunc,
+			},
+			expected: map[string]interface{}{
+				"providerID": "aws:///us-east-1a/i-012345",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := buildKubeletInstanceConfig(test.opts.nodeRegOpts, test.opts.execer, test.opts.isServiceActiveFunc)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf(
+					"failed buildKubeletInstanceConfig:\n\texpected: %v\n\t  actual: %v",
+					test.expected,
+					actual,
+				)
+			}
+		})
+	}
+}
+
+func TestApplyInstanceConfiguration(t *testing.T) {
+	base := dedent.Dedent(`
+		apiVersion: kubelet.config.k8s.io/v1beta1
+		kind: KubeletConfiguration
+		clusterDomain: cluster.local
+		resolvConf: /etc/resolv.conf
+	`)
+
+	var tests = []struct {
+		name     string
+		dropIns  map[string]string
+		expected []string
+	}{
+		{
+			name:     "no drop-in directory leaves the config untouched",
+			dropIns:  nil,
+			expected: []string{"clusterDomain: cluster.local", "resolvConf: /etc/resolv.conf"},
+		},
+		{
+			name: "drop-in overrides and extends the base config",
+			dropIns: map[string]string{
+				constants.KubeletInstanceConfigurationFileName: dedent.Dedent(`
+					apiVersion: kubelet.config.k8s.io/v1beta1
+					kind: KubeletConfiguration
+					resolvConf: /run/systemd/resolve/resolv.conf
+					cgroupDriver: systemd
+				`),
+			},
+			expected: []string{"clusterDomain: cluster.local", "resolvConf: /run/systemd/resolve/resolv.conf", "cgroupDriver: systemd"},
+		},
+		{
+			name: "drop-ins are applied in lexical order",
+			dropIns: map[string]string{
+				"10-first.yaml":  "cgroupDriver: cgroupfs\n",
+				"20-second.yaml": "cgroupDriver: systemd\n",
+			},
+			expected: []string{"cgroupDriver: systemd"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeletDir, err := ioutil.TempDir("", "kubelet-instance-config-test")
+			if err != nil {
+				t.Fatalf("couldn't create a temporary directory: %v", err)
+			}
+			defer os.RemoveAll(kubeletDir)
+
+			if test.dropIns != nil {
+				dropInDir := filepath.Join(kubeletDir, constants.KubeletInstanceConfigurationDirectoryName)
+				if err := os.MkdirAll(dropInDir, 0700); err != nil {
+					t.Fatalf("couldn't create the drop-in directory: %v", err)
+				}
+				for name, contents := range test.dropIns {
+					if err := ioutil.WriteFile(filepath.Join(dropInDir, name), []byte(contents), 0644); err != nil {
+						t.Fatalf("couldn't write drop-in file %q: %v", name, err)
+					}
+				}
+			}
+
+			merged, err := applyInstanceConfiguration([]byte(base), kubeletDir)
+			if err != nil {
+				t.Fatalf("applyInstanceConfiguration failed: %v", err)
+			}
+
+			for _, expected := range test.expected {
+				if !strings.Contains(string(merged), expected) {
+					t.Errorf("expected the merged configuration to contain %q, got:\n%s", expected, merged)
+				}
+			}
+		})
+	}
+}
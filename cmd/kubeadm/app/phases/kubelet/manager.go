@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/initsystem"
+)
+
+// Manager abstracts the lifecycle and flag-file operations kubeadm performs on the
+// kubelet service, so distros without systemd (OpenRC based ones like Alpine, or
+// Windows services) are handled through the same code paths.
+type Manager interface {
+	// Start starts the kubelet service
+	Start() error
+
+	// Stop stops the kubelet service
+	Stop() error
+
+	// Restart restarts the kubelet service, reloading its configuration
+	Restart() error
+
+	// IsActive returns whether the kubelet service is running
+	IsActive() bool
+
+	// EnableCommand returns the command a user must run to enable the kubelet service
+	EnableCommand() string
+
+	// WriteEnvFile writes the dynamic environment file holding the kubelet flags
+	WriteEnvFile(contents []byte, kubeletDir string) error
+
+	// ReadEnvFile reads the dynamic environment file holding the kubelet flags
+	ReadEnvFile(kubeletDir string) ([]byte, error)
+}
+
+// initSystemManager implements Manager on top of the init system detected on the node
+type initSystemManager struct {
+	initSystem initsystem.InitSystem
+}
+
+// NewManager returns the kubelet Manager for this node, selecting the init system
+// automatically. An error is returned when no supported init system is detected.
+func NewManager() (Manager, error) {
+	initSystem, err := initsystem.GetInitSystem()
+	if err != nil {
+		return nil, errors.Wrap(err, "no supported init system detected")
+	}
+	if !initSystem.ServiceExists("kubelet") {
+		return nil, errors.New("no kubelet service is defined in the detected init system")
+	}
+	return &initSystemManager{initSystem: initSystem}, nil
+}
+
+func (m *initSystemManager) Start() error {
+	return m.initSystem.ServiceStart("kubelet")
+}
+
+func (m *initSystemManager) Stop() error {
+	return m.initSystem.ServiceStop("kubelet")
+}
+
+func (m *initSystemManager) Restart() error {
+	return m.initSystem.ServiceRestart("kubelet")
+}
+
+func (m *initSystemManager) IsActive() bool {
+	return m.initSystem.ServiceIsActive("kubelet")
+}
+
+func (m *initSystemManager) EnableCommand() string {
+	return m.initSystem.EnableCommand("kubelet")
+}
+
+func (m *initSystemManager) WriteEnvFile(contents []byte, kubeletDir string) error {
+	return writeKubeletFlagBytesToDisk(contents, kubeletDir)
+}
+
+func (m *initSystemManager) ReadEnvFile(kubeletDir string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(kubeletDir, constants.KubeletEnvFileName))
+}
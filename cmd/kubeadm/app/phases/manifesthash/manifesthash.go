@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifesthash tracks the expected hashes of the control-plane static pod
+// manifests in node annotations, so manual tampering with the manifests or partially
+// applied upgrades can be detected later.
+package manifesthash
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+)
+
+// annotationPrefix prefixes the node annotations storing the expected manifest hashes
+const annotationPrefix = "kubeadm.kubernetes.io/expected-manifest-hash-"
+
+// Mismatch describes one manifest whose current hash differs from the stored expectation
+type Mismatch struct {
+	// Component is the control-plane component the manifest belongs to
+	Component string `json:"component"`
+
+	// ExpectedHash is the stored hash of the manifest
+	ExpectedHash string `json:"expectedHash"`
+
+	// CurrentHash is the hash of the manifest currently on disk; empty when the
+	// manifest is missing
+	CurrentHash string `json:"currentHash,omitempty"`
+}
+
+// ComputeManifestHashes returns the sha256 hashes of the control-plane static pod
+// manifests below manifestDir, keyed by component name. Missing manifests are skipped.
+func ComputeManifestHashes(manifestDir string) (map[string]string, error) {
+	hashes := map[string]string{}
+	for _, component := range constants.ControlPlaneComponents {
+		hash, err := manifestHash(constants.GetStaticPodFilepath(component, manifestDir))
+		if os.IsNotExist(errors.Cause(err)) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		hashes[component] = hash
+	}
+	return hashes, nil
+}
+
+// StoreManifestHashes records the expected manifest hashes as annotations on the node
+func StoreManifestHashes(client clientset.Interface, nodeName, manifestDir string) error {
+	hashes, err := ComputeManifestHashes(manifestDir)
+	if err != nil {
+		return err
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	klog.V(1).Infof("[manifest-hash] Storing the expected manifest hashes on the Node API object %q", nodeName)
+	return apiclient.PatchNode(client, nodeName, func(n *v1.Node) {
+		if n.ObjectMeta.Annotations == nil {
+			n.ObjectMeta.Annotations = make(map[string]string)
+		}
+		for component, hash := range hashes {
+			n.ObjectMeta.Annotations[annotationPrefix+component] = hash
+		}
+	})
+}
+
+// VerifyManifestHashes compares the stored expected hashes with the manifests currently
+// on disk and returns one Mismatch per differing or missing manifest
+func VerifyManifestHashes(client clientset.Interface, nodeName, manifestDir string) ([]Mismatch, error) {
+	node, err := client.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the Node %q", nodeName)
+	}
+
+	mismatches := []Mismatch{}
+	for _, component := range constants.ControlPlaneComponents {
+		expected, ok := node.ObjectMeta.Annotations[annotationPrefix+component]
+		if !ok {
+			continue
+		}
+		current, err := manifestHash(constants.GetStaticPodFilepath(component, manifestDir))
+		if err != nil && !os.IsNotExist(errors.Cause(err)) {
+			return nil, err
+		}
+		if current != expected {
+			mismatches = append(mismatches, Mismatch{
+				Component:    component,
+				ExpectedHash: expected,
+				CurrentHash:  current,
+			})
+		}
+	}
+	return mismatches, nil
+}
+
+// manifestHash returns the sha256 hash of a manifest file
+func manifestHash(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read the manifest %q", path)
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(b)), nil
+}
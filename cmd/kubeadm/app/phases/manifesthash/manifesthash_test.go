@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifesthash
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+func TestStoreAndVerifyManifestHashes(t *testing.T) {
+	manifestDir, err := ioutil.TempDir("", "manifest-hash-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(manifestDir)
+
+	apiServerManifest := constants.GetStaticPodFilepath(constants.KubeAPIServer, manifestDir)
+	if err := ioutil.WriteFile(apiServerManifest, []byte("manifest-contents"), 0600); err != nil {
+		t.Fatalf("couldn't write the test manifest: %v", err)
+	}
+
+	client := clientsetfake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			// PatchNode waits for the hostname label to be present
+			Labels: map[string]string{v1.LabelHostname: "test-node"},
+		},
+	})
+
+	if err := StoreManifestHashes(client, "test-node", manifestDir); err != nil {
+		t.Fatalf("StoreManifestHashes failed: %v", err)
+	}
+
+	mismatches, err := VerifyManifestHashes(client, "test-node", manifestDir)
+	if err != nil {
+		t.Fatalf("VerifyManifestHashes failed: %v", err)
+	}
+	if len(mismatches) != 0 {
+		t.Fatalf("expected no mismatches for untouched manifests, got %v", mismatches)
+	}
+
+	// tampering with the manifest must be detected
+	if err := ioutil.WriteFile(apiServerManifest, []byte("tampered-contents"), 0600); err != nil {
+		t.Fatalf("couldn't overwrite the test manifest: %v", err)
+	}
+	mismatches, err = VerifyManifestHashes(client, "test-node", manifestDir)
+	if err != nil {
+		t.Fatalf("VerifyManifestHashes failed: %v", err)
+	}
+	if len(mismatches) != 1 || mismatches[0].Component != constants.KubeAPIServer {
+		t.Errorf("expected a mismatch for %s, got %v", constants.KubeAPIServer, mismatches)
+	}
+}
@@ -20,16 +20,22 @@ import (
 	"fmt"
 
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientset "k8s.io/client-go/kubernetes"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 )
 
 // MarkControlPlane taints the control-plane and sets the control-plane label
-func MarkControlPlane(client clientset.Interface, controlPlaneName string, taints []v1.Taint) error {
+func MarkControlPlane(client clientset.Interface, controlPlaneName string, taints []v1.Taint, marking *kubeadmapi.ControlPlaneMarking) error {
 
 	fmt.Printf("[mark-control-plane] Marking the node %s as control-plane by adding the label \"%s=''\"\n", controlPlaneName, constants.LabelNodeRoleMaster)
 
+	if marking != nil && marking.AllowScheduling {
+		taints = removeControlPlaneTaint(taints)
+		fmt.Printf("[mark-control-plane] Scheduling regular workloads on control-plane nodes is allowed; not applying the %q taint\n", constants.ControlPlaneTaint.ToString())
+	}
 	if len(taints) > 0 {
 		taintStrs := []string{}
 		for _, taint := range taints {
@@ -39,8 +45,37 @@ func MarkControlPlane(client clientset.Interface, controlPlaneName string, taint
 	}
 
 	return apiclient.PatchNode(client, controlPlaneName, func(n *v1.Node) {
-		markControlPlaneNode(n, taints)
+		markControlPlaneNode(n, taints, marking)
+	})
+}
+
+// ReconcileControlPlaneMarking re-applies the configured labels and taints to every
+// control-plane node, so a marking changed in the cluster configuration takes effect on
+// upgrade without post-hoc kubectl commands
+func ReconcileControlPlaneMarking(client clientset.Interface, marking *kubeadmapi.ControlPlaneMarking) error {
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: constants.LabelNodeRoleMaster,
 	})
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes.Items {
+		if err := MarkControlPlane(client, node.Name, nil, marking); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeControlPlaneTaint drops the control-plane NoSchedule taint from the list
+func removeControlPlaneTaint(taints []v1.Taint) []v1.Taint {
+	kept := []v1.Taint{}
+	for _, taint := range taints {
+		if taint != constants.ControlPlaneTaint {
+			kept = append(kept, taint)
+		}
+	}
+	return kept
 }
 
 func taintExists(taint v1.Taint, taints []v1.Taint) bool {
@@ -53,14 +88,22 @@ func taintExists(taint v1.Taint, taints []v1.Taint) bool {
 	return false
 }
 
-func markControlPlaneNode(n *v1.Node, taints []v1.Taint) {
+func markControlPlaneNode(n *v1.Node, taints []v1.Taint, marking *kubeadmapi.ControlPlaneMarking) {
 	n.ObjectMeta.Labels[constants.LabelNodeRoleMaster] = ""
+	if marking != nil {
+		for key, value := range marking.Labels {
+			n.ObjectMeta.Labels[key] = value
+		}
+	}
 
 	for _, nt := range n.Spec.Taints {
 		if !taintExists(nt, taints) {
 			taints = append(taints, nt)
 		}
 	}
+	if marking != nil && marking.AllowScheduling {
+		taints = removeControlPlaneTaint(taints)
+	}
 
 	n.Spec.Taints = taints
 }
@@ -164,7 +164,7 @@ func TestMarkControlPlane(t *testing.T) {
 				t.Fatalf("MarkControlPlane(%s): unexpected error building clientset: %v", tc.name, err)
 			}
 
-			if err := MarkControlPlane(cs, hostname, tc.newTaints); err != nil {
+			if err := MarkControlPlane(cs, hostname, tc.newTaints, nil); err != nil {
 				t.Errorf("MarkControlPlane(%s) returned unexpected error: %v", tc.name, err)
 			}
 
@@ -0,0 +1,229 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodepreauth implements pre-authorization of node identities, an
+// alternative to distributing shared bootstrap tokens: an administrator records
+// the name and public key fingerprint of a future node in the cluster, and the
+// kubelet client CSR submitted by that node during join is approved only when
+// both match the recorded identity.
+package nodepreauth
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	certsapi "k8s.io/api/certificates/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+)
+
+const (
+	// nodeUserPrefix is the prefix of the kubelet client certificate subject of a node
+	nodeUserPrefix = "system:node:"
+)
+
+// PreauthorizeNode records the identity (name and public key fingerprint) of a future
+// node in the pre-authorized nodes ConfigMap, so its kubelet client CSR can later be
+// approved with ApproveNodeCSRs without a shared bootstrap token being involved.
+func PreauthorizeNode(client clientset.Interface, nodeName string, publicKeyPEM []byte) error {
+	fingerprint, err := fingerprintFromPublicKeyPEM(publicKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("[preauthorize-node] Recording identity for node %q with public key fingerprint %s\n", nodeName, fingerprint)
+	return apiclient.CreateOrMutateConfigMap(client, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kubeadmconstants.NodePreauthConfigMap,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Data: map[string]string{
+			nodeName: fingerprint,
+		},
+	}, func(cm *v1.ConfigMap) error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[nodeName] = fingerprint
+		return nil
+	})
+}
+
+// ApproveNodeCSRs approves all pending kubelet client CSRs whose node name and public
+// key fingerprint match a pre-authorized identity. It returns the number of approved CSRs.
+func ApproveNodeCSRs(client clientset.Interface) (int, error) {
+	configMap, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(kubeadmconstants.NodePreauthConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the %q ConfigMap", kubeadmconstants.NodePreauthConfigMap)
+	}
+
+	csrs, err := client.CertificatesV1beta1().CertificateSigningRequests().List(metav1.ListOptions{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list certificate signing requests")
+	}
+
+	approved := 0
+	for i := range csrs.Items {
+		csr := &csrs.Items[i]
+		if isCSRResolved(csr) {
+			continue
+		}
+
+		nodeName, fingerprint, err := nodeIdentityFromCSR(csr)
+		if err != nil {
+			klog.V(2).Infof("skipping CSR %q: %v", csr.Name, err)
+			continue
+		}
+		if configMap.Data[nodeName] != fingerprint {
+			klog.V(2).Infof("skipping CSR %q: node %q is not pre-authorized with fingerprint %s", csr.Name, nodeName, fingerprint)
+			continue
+		}
+
+		csr.Status.Conditions = append(csr.Status.Conditions, certsapi.CertificateSigningRequestCondition{
+			Type:    certsapi.CertificateApproved,
+			Reason:  "KubeadmNodePreauthorization",
+			Message: fmt.Sprintf("pre-authorized identity of node %q matched", nodeName),
+		})
+		if _, err := client.CertificatesV1beta1().CertificateSigningRequests().UpdateApproval(csr); err != nil {
+			return approved, errors.Wrapf(err, "failed to approve CSR %q", csr.Name)
+		}
+		fmt.Printf("[preauthorize-node] Approved CSR %q for node %q\n", csr.Name, nodeName)
+		approved++
+	}
+	return approved, nil
+}
+
+// isCSRResolved returns true when the CSR was already approved or denied
+func isCSRResolved(csr *certsapi.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certsapi.CertificateApproved || condition.Type == certsapi.CertificateDenied {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeIdentityFromCSR extracts the node name and the public key fingerprint from a
+// kubelet client CSR. An error is returned for CSRs that are not kubelet client CSRs.
+func nodeIdentityFromCSR(csr *certsapi.CertificateSigningRequest) (string, string, error) {
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil {
+		return "", "", errors.New("the CSR does not contain a PEM encoded certificate request")
+	}
+	certReq, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to parse the certificate request")
+	}
+
+	commonName := certReq.Subject.CommonName
+	if len(commonName) <= len(nodeUserPrefix) || commonName[:len(nodeUserPrefix)] != nodeUserPrefix {
+		return "", "", errors.Errorf("the CSR subject %q is not a node identity", commonName)
+	}
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(certReq.PublicKey)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to marshal the public key of the certificate request")
+	}
+
+	return commonName[len(nodeUserPrefix):], fingerprintFromDER(publicKeyDER), nil
+}
+
+// fingerprintFromPublicKeyPEM computes the fingerprint of a PEM encoded public key
+func fingerprintFromPublicKeyPEM(publicKeyPEM []byte) (string, error) {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return "", errors.New("the file does not contain a PEM encoded public key")
+	}
+	if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return "", errors.Wrap(err, "failed to parse the public key")
+	}
+	return fingerprintFromDER(block.Bytes), nil
+}
+
+// fingerprintFromDER computes the hex encoded SHA-256 digest of a DER encoded public key
+func fingerprintFromDER(der []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(der))
+}
+
+// ApproveKubeletServingCSRs approves all pending kubelet serving certificate CSRs
+// (submitted by node users requesting the server auth usage), which accumulate when
+// serverTLSBootstrap is enabled without an approver. It returns the number approved.
+func ApproveKubeletServingCSRs(client clientset.Interface) (int, error) {
+	csrs, err := client.CertificatesV1beta1().CertificateSigningRequests().List(metav1.ListOptions{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list certificate signing requests")
+	}
+
+	approved := 0
+	for i := range csrs.Items {
+		csr := &csrs.Items[i]
+		if isCSRResolved(csr) || !isKubeletServingCSR(csr) {
+			continue
+		}
+
+		csr.Status.Conditions = append(csr.Status.Conditions, certsapi.CertificateSigningRequestCondition{
+			Type:    certsapi.CertificateApproved,
+			Reason:  "KubeadmKubeletServingApproval",
+			Message: fmt.Sprintf("kubelet serving certificate requested by %q approved", csr.Spec.Username),
+		})
+		if _, err := client.CertificatesV1beta1().CertificateSigningRequests().UpdateApproval(csr); err != nil {
+			return approved, errors.Wrapf(err, "failed to approve CSR %q", csr.Name)
+		}
+		fmt.Printf("[kubelet-serving] Approved CSR %q for %q\n", csr.Name, csr.Spec.Username)
+		approved++
+	}
+	return approved, nil
+}
+
+// PendingKubeletServingCSRs returns the names of pending kubelet serving CSRs, so
+// preflight and upgrade checks can warn about a missing approver
+func PendingKubeletServingCSRs(client clientset.Interface) ([]string, error) {
+	csrs, err := client.CertificatesV1beta1().CertificateSigningRequests().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list certificate signing requests")
+	}
+
+	pending := []string{}
+	for i := range csrs.Items {
+		csr := &csrs.Items[i]
+		if !isCSRResolved(csr) && isKubeletServingCSR(csr) {
+			pending = append(pending, csr.Name)
+		}
+	}
+	return pending, nil
+}
+
+// isKubeletServingCSR detects kubelet serving certificate requests: submitted by a node
+// user and requesting the server auth usage
+func isKubeletServingCSR(csr *certsapi.CertificateSigningRequest) bool {
+	if !strings.HasPrefix(csr.Spec.Username, nodeUserPrefix) {
+		return false
+	}
+	for _, usage := range csr.Spec.Usages {
+		if usage == certsapi.UsageServerAuth {
+			return true
+		}
+	}
+	return false
+}
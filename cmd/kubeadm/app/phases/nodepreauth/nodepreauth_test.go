@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepreauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	certsapi "k8s.io/api/certificates/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+func generateKeyAndCSR(t *testing.T, nodeName string) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("couldn't generate a private key: %v", err)
+	}
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   nodeUserPrefix + nodeName,
+			Organization: []string{"system:nodes"},
+		},
+	}, key)
+	if err != nil {
+		t.Fatalf("couldn't create a certificate request: %v", err)
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+}
+
+func publicKeyPEM(t *testing.T, key *rsa.PrivateKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("couldn't marshal the public key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestPreauthorizeAndApproveNodeCSRs(t *testing.T) {
+	key, csrPEM := generateKeyAndCSR(t, "valid-node")
+	otherKey, _ := generateKeyAndCSR(t, "other-node")
+
+	client := clientsetfake.NewSimpleClientset(&certsapi.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "csr-valid"},
+		Spec: certsapi.CertificateSigningRequestSpec{
+			Request: csrPEM,
+		},
+	})
+
+	// approving with no matching identity recorded must not approve anything
+	if err := PreauthorizeNode(client, "valid-node", publicKeyPEM(t, otherKey)); err != nil {
+		t.Fatalf("PreauthorizeNode failed: %v", err)
+	}
+	approved, err := ApproveNodeCSRs(client)
+	if err != nil {
+		t.Fatalf("ApproveNodeCSRs failed: %v", err)
+	}
+	if approved != 0 {
+		t.Fatalf("expected no approved CSRs for a mismatching fingerprint, got %d", approved)
+	}
+
+	// after recording the right identity the CSR must be approved
+	if err := PreauthorizeNode(client, "valid-node", publicKeyPEM(t, key)); err != nil {
+		t.Fatalf("PreauthorizeNode failed: %v", err)
+	}
+	approved, err = ApproveNodeCSRs(client)
+	if err != nil {
+		t.Fatalf("ApproveNodeCSRs failed: %v", err)
+	}
+	if approved != 1 {
+		t.Fatalf("expected 1 approved CSR, got %d", approved)
+	}
+
+	// the recorded identity must be present in the ConfigMap
+	cm, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(kubeadmconstants.NodePreauthConfigMap, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("couldn't get the %q ConfigMap: %v", kubeadmconstants.NodePreauthConfigMap, err)
+	}
+	if _, ok := cm.Data["valid-node"]; !ok {
+		t.Errorf("no identity recorded for node %q", "valid-node")
+	}
+}
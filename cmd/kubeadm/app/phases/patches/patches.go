@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package patches implements persisting a patch set in the cluster, so every
+// control-plane node and every upgrade applies the same patches without the patch
+// directory having to be distributed to all nodes out of band.
+package patches
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+)
+
+// patchesClusterRoleName is the name of the Role/RoleBinding exposing the patches
+// ConfigMap to bootstrap tokens and nodes
+const patchesClusterRoleName = "kubeadm:patches"
+
+// UploadPatches stores every file of the passed patches directory in the named ConfigMap
+// in the kube-system namespace and exposes the ConfigMap to bootstrap tokens and nodes,
+// so joining control-plane nodes and upgrades can download and apply the same patch set
+func UploadPatches(client clientset.Interface, patchesDir, configMapName string) error {
+	files, err := ioutil.ReadDir(patchesDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the patches directory %q", patchesDir)
+	}
+
+	data := map[string]string{}
+	for _, fileInfo := range files {
+		if fileInfo.IsDir() {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(patchesDir, fileInfo.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the patch file %q", fileInfo.Name())
+		}
+		data[fileInfo.Name()] = string(b)
+	}
+
+	fmt.Printf("[patches] Storing the patches in ConfigMap %q in the %q Namespace\n", configMapName, metav1.NamespaceSystem)
+	err = apiclient.CreateOrUpdateConfigMap(client, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Data: data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return createPatchesRBACRules(client, configMapName)
+}
+
+// DownloadPatches retrieves the named patches ConfigMap and materializes its entries as
+// files in a temporary directory, which can be passed on as the patches directory
+func DownloadPatches(client clientset.Interface, configMapName string) (string, error) {
+	configMap, err := apiclient.GetConfigMapWithRetry(client, metav1.NamespaceSystem, configMapName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get the patches ConfigMap %q", configMapName)
+	}
+
+	patchesDir, err := ioutil.TempDir("", "kubeadm-patches")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create a temporary patches directory")
+	}
+
+	for name, contents := range configMap.Data {
+		if err := ioutil.WriteFile(filepath.Join(patchesDir, name), []byte(contents), 0644); err != nil {
+			return "", errors.Wrapf(err, "failed to write the patch file %q", name)
+		}
+	}
+
+	fmt.Printf("[patches] Downloaded the patches from ConfigMap %q into %q\n", configMapName, patchesDir)
+	return patchesDir, nil
+}
+
+// createPatchesRBACRules exposes the patches ConfigMap to bootstrap tokens and nodes
+func createPatchesRBACRules(client clientset.Interface, configMapName string) error {
+	err := apiclient.CreateOrUpdateRole(client, &rbac.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      patchesClusterRoleName,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Rules: []rbac.PolicyRule{
+			{
+				Verbs:         []string{"get"},
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{configMapName},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return apiclient.CreateOrUpdateRoleBinding(client, &rbac.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      patchesClusterRoleName,
+			Namespace: metav1.NamespaceSystem,
+		},
+		RoleRef: rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     patchesClusterRoleName,
+		},
+		Subjects: []rbac.Subject{
+			{
+				Kind: rbac.GroupKind,
+				Name: kubeadmconstants.NodeBootstrapTokenAuthGroup,
+			},
+			{
+				Kind: rbac.GroupKind,
+				Name: kubeadmconstants.NodesGroup,
+			},
+		},
+	})
+}
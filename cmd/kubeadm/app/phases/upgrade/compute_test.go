@@ -32,6 +32,7 @@ import (
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
+	"k8s.io/kubernetes/cmd/kubeadm/test/fixtures"
 )
 
 type fakeVersionGetter struct {
@@ -154,7 +155,10 @@ func TestGetAvailableUpgrades(t *testing.T) {
 	v1Z0rc1 := v1Z0.WithPreRelease("rc.1")
 	v1Z1 := v1Z0.WithPatch(1)
 
-	etcdClient := fakeEtcdClient{}
+	etcdClient := &fixtures.FakeEtcdCluster{
+		Members: []etcdutil.Member{{Name: "foo", PeerURL: "foo"}},
+		Version: fakeCurrentEtcdVersion,
+	}
 	tests := []struct {
 		name                        string
 		vg                          VersionGetter
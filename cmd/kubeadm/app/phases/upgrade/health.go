@@ -35,6 +35,7 @@ import (
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/images"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/nodepreauth"
 	"k8s.io/kubernetes/cmd/kubeadm/app/preflight"
 	utilpointer "k8s.io/utils/pointer"
 )
@@ -88,6 +89,14 @@ func CheckClusterHealth(client clientset.Interface, cfg *kubeadmapi.ClusterConfi
 			f:      staticPodManifestHealth,
 		},
 	}
+	if cfg.ControlPlaneEndpoint != "" {
+		healthChecks = append(healthChecks, preflight.ControlPlaneEndpointCheck{Endpoint: cfg.ControlPlaneEndpoint})
+	}
+	healthChecks = append(healthChecks, &healthCheck{
+		name:   "PendingKubeletServingCSRs",
+		client: client,
+		f:      pendingKubeletServingCSRs,
+	})
 
 	return preflight.RunChecks(healthChecks, os.Stderr, ignoreChecksErrors)
 }
@@ -301,3 +310,16 @@ func getNotReadyNodes(nodes []v1.Node) []string {
 	}
 	return notReadyNodes
 }
+
+// pendingKubeletServingCSRs fails when kubelet serving CSRs are stuck pending, which
+// breaks components scraping the kubelet (e.g. metrics-server) after the upgrade
+func pendingKubeletServingCSRs(client clientset.Interface, _ *kubeadmapi.ClusterConfiguration) error {
+	pending, err := nodepreauth.PendingKubeletServingCSRs(client)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return errors.Errorf("there are %d pending kubelet serving CSRs (%v); approve them or deploy an approver, e.g. 'kubeadm alpha certs approve-kubelet-serving-csrs'", len(pending), pending)
+	}
+	return nil
+}
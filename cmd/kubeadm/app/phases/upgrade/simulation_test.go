@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/controlplane"
+	testutil "k8s.io/kubernetes/cmd/kubeadm/test"
+)
+
+// simulationClusterConfiguration returns the fixed configuration the upgrade
+// simulation renders artifacts for, pinned per target version
+func simulationClusterConfiguration(version string) *kubeadmapi.ClusterConfiguration {
+	return &kubeadmapi.ClusterConfiguration{
+		KubernetesVersion: version,
+		ImageRepository:   "k8s.gcr.io",
+		CertificatesDir:   "/etc/kubernetes/pki",
+		Networking: kubeadmapi.Networking{
+			ServiceSubnet: "10.96.0.0/12",
+			PodSubnet:     "10.244.0.0/16",
+			DNSDomain:     "cluster.local",
+		},
+		Etcd: kubeadmapi.Etcd{
+			Local: &kubeadmapi.LocalEtcd{DataDir: "/var/lib/etcd"},
+		},
+	}
+}
+
+// renderControlPlaneArtifacts renders a deterministic, textual representation of the
+// control-plane commands a configuration produces
+func renderControlPlaneArtifacts(cfg *kubeadmapi.ClusterConfiguration) string {
+	endpoint := &kubeadmapi.APIEndpoint{AdvertiseAddress: "10.0.0.1", BindPort: 6443}
+	specs := controlplane.GetStaticPodSpecs(cfg, endpoint)
+
+	components := []string{}
+	for component := range specs {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	var builder strings.Builder
+	for _, component := range components {
+		fmt.Fprintf(&builder, "# %s\n", component)
+		for _, arg := range specs[component].Spec.Containers[0].Command {
+			fmt.Fprintf(&builder, "%s\n", arg)
+		}
+		fmt.Fprintln(&builder, "")
+	}
+	return builder.String()
+}
+
+// TestUpgradeArtifactSimulation renders the control-plane artifacts for consecutive
+// supported versions and compares them against recorded fixtures, so unintended
+// upgrade-relevant changes to the generated artifacts are caught before release
+func TestUpgradeArtifactSimulation(t *testing.T) {
+	versions := []string{
+		constants.MinimumControlPlaneVersion.WithPatch(0).String(),
+		constants.CurrentKubernetesVersion.String(),
+	}
+
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			artifacts := renderControlPlaneArtifacts(simulationClusterConfiguration(version))
+			testutil.AssertGoldenFile(t, fmt.Sprintf("upgrade-artifacts-%s.txt", version), []byte(artifacts))
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/version"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	certsphase "k8s.io/kubernetes/cmd/kubeadm/app/phases/certs"
@@ -36,6 +37,7 @@ import (
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 	dryrunutil "k8s.io/kubernetes/cmd/kubeadm/app/util/dryrun"
+	kubeadmerrors "k8s.io/kubernetes/cmd/kubeadm/app/util/errors"
 	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/staticpod"
 )
@@ -260,6 +262,10 @@ func upgradeComponent(component string, certsRenewMgr *renewal.Manager, waiter a
 	return nil
 }
 
+// etcdDefragmentThreshold is the database size starting at which etcd members are
+// defragmented before an upgrade
+const etcdDefragmentThreshold = 100 * 1024 * 1024
+
 // performEtcdStaticPodUpgrade performs upgrade of etcd, it returns bool which indicates fatal error or not and the actual error.
 func performEtcdStaticPodUpgrade(certsRenewMgr *renewal.Manager, client clientset.Interface, waiter apiclient.Waiter, pathMgr StaticPodPathManager, cfg *kubeadmapi.InitConfiguration, recoverManifests map[string]string, oldEtcdClient, newEtcdClient etcdutil.ClusterInterrogator) (bool, error) {
 	// Add etcd static pod spec only if external etcd is not configured
@@ -273,6 +279,14 @@ func performEtcdStaticPodUpgrade(certsRenewMgr *renewal.Manager, client clientse
 		return true, errors.Wrap(err, "etcd cluster is not healthy")
 	}
 
+	// Best-effort maintenance before the upgrade: a large or fragmented database is a
+	// common cause of failed etcd upgrades due to database space alarms
+	if etcdClient, ok := oldEtcdClient.(*etcdutil.Client); ok {
+		if err := etcdClient.CompactAndDefragment(etcdDefragmentThreshold); err != nil {
+			klog.Warningf("[upgrade/etcd] etcd maintenance before the upgrade failed: %v", err)
+		}
+	}
+
 	// Backing up etcd data store
 	backupEtcdDir := pathMgr.BackupEtcdDir()
 	runningEtcdDir := cfg.Etcd.Local.DataDir
@@ -337,7 +351,7 @@ func performEtcdStaticPodUpgrade(certsRenewMgr *renewal.Manager, client clientse
 			fmt.Println("[upgrade/etcd] Rolling back etcd data")
 			if err := rollbackEtcdData(cfg, pathMgr); err != nil {
 				// Even copying back datastore failed, no options for recovery left, bailing out
-				return true, errors.Errorf("fatal error rolling back local etcd cluster datadir: %v, the backup of etcd database is stored here:(%s)", err, backupEtcdDir)
+				return true, kubeadmerrors.NewPartialUpgrade("upgrade/etcd", errors.Errorf("fatal error rolling back local etcd cluster datadir: %v, the backup of etcd database is stored here:(%s)", err, backupEtcdDir))
 			}
 			fmt.Println("[upgrade/etcd] Etcd data rollback successful")
 
@@ -346,7 +360,7 @@ func performEtcdStaticPodUpgrade(certsRenewMgr *renewal.Manager, client clientse
 			if _, err := oldEtcdClient.WaitForClusterAvailable(retries, retryInterval); err != nil {
 				fmt.Printf("[upgrade/etcd] Failed to healthcheck previous etcd: %v\n", err)
 				// Nothing else left to try to recover etcd cluster
-				return true, errors.Wrapf(err, "fatal error rolling back local etcd cluster manifest, the backup of etcd database is stored here:(%s)", backupEtcdDir)
+				return true, kubeadmerrors.NewPartialUpgrade("upgrade/etcd", errors.Wrapf(err, "fatal error rolling back local etcd cluster manifest, the backup of etcd database is stored here:(%s)", backupEtcdDir))
 			}
 
 			// We've recovered to the previous etcd from this case
@@ -375,7 +389,7 @@ func performEtcdStaticPodUpgrade(certsRenewMgr *renewal.Manager, client clientse
 		fmt.Println("[upgrade/etcd] Rolling back etcd data")
 		if err := rollbackEtcdData(cfg, pathMgr); err != nil {
 			// Even copying back datastore failed, no options for recovery left, bailing out
-			return true, errors.Wrapf(err, "fatal error rolling back local etcd cluster datadir, the backup of etcd database is stored here:(%s)", backupEtcdDir)
+			return true, kubeadmerrors.NewPartialUpgrade("upgrade/etcd", errors.Wrapf(err, "fatal error rolling back local etcd cluster datadir, the backup of etcd database is stored here:(%s)", backupEtcdDir))
 		}
 		fmt.Println("[upgrade/etcd] Etcd data rollback successful")
 
@@ -511,9 +525,10 @@ func rollbackOldManifests(oldManifests map[string]string, origErr error, pathMgr
 			errs = append(errs, err)
 		}
 	}
-	// Let the user know there were problems, but we tried to recover
-	return errors.Wrap(utilerrors.NewAggregate(errs),
-		"couldn't upgrade control plane. kubeadm has tried to recover everything into the earlier state. Errors faced")
+	// Let the user know there were problems, but we tried to recover. The upgrade
+	// failed after cluster state was changed, so mark it as partial for the exit code.
+	return kubeadmerrors.NewPartialUpgrade("upgrade/staticpods", errors.Wrap(utilerrors.NewAggregate(errs),
+		"couldn't upgrade control plane. kubeadm has tried to recover everything into the earlier state. Errors faced"))
 }
 
 // rollbackEtcdData rolls back the content of etcd folder if something went wrong.
@@ -115,6 +115,36 @@ func computeKubeletVersions(nodes []v1.Node) map[string]uint16 {
 	return kubeletVersions
 }
 
+// FileVersionGetter wraps a VersionGetter and resolves version labels from a local
+// release metadata file instead of the release buckets, for air-gapped clusters
+type FileVersionGetter struct {
+	VersionGetter
+	labelFile string
+}
+
+// NewFileVersionGetter returns a VersionGetter resolving version labels from the given
+// release metadata file, with one "label version" pair per line
+func NewFileVersionGetter(versionGetter VersionGetter, labelFile string) VersionGetter {
+	return &FileVersionGetter{
+		VersionGetter: versionGetter,
+		labelFile:     labelFile,
+	}
+}
+
+// VersionFromCILabel resolves a version label from the local release metadata file
+func (g *FileVersionGetter) VersionFromCILabel(ciVersionLabel, description string) (string, *versionutil.Version, error) {
+	versionStr, err := kubeadmutil.KubernetesReleaseVersionFromFile(g.labelFile, ciVersionLabel)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "Couldn't resolve %s from the release metadata file", description)
+	}
+
+	ver, err := versionutil.ParseSemantic(versionStr)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "Couldn't parse %s", description)
+	}
+	return versionStr, ver, nil
+}
+
 // OfflineVersionGetter will use the version provided or
 type OfflineVersionGetter struct {
 	VersionGetter
@@ -17,8 +17,6 @@ limitations under the License.
 package uploadconfig
 
 import (
-	"fmt"
-
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
@@ -26,9 +24,12 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/componentconfigs"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/extrarbac"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	logutil "k8s.io/kubernetes/cmd/kubeadm/app/util/log"
 )
 
 const (
@@ -41,7 +42,7 @@ const (
 // ResetClusterStatusForNode removes the APIEndpoint of a given control-plane node
 // from the ClusterStatus and updates the kubeadm ConfigMap
 func ResetClusterStatusForNode(nodeName string, client clientset.Interface) error {
-	fmt.Printf("[reset] Removing info for node %q from the ConfigMap %q in the %q Namespace\n",
+	logutil.Infof("reset", "Removing info for node %q from the ConfigMap %q in the %q Namespace",
 		nodeName, kubeadmconstants.KubeadmConfigConfigMap, metav1.NamespaceSystem)
 
 	return apiclient.MutateConfigMap(client, metav1.ObjectMeta{
@@ -64,7 +65,7 @@ func ResetClusterStatusForNode(nodeName string, client clientset.Interface) erro
 
 // UploadConfiguration saves the InitConfiguration used for later reference (when upgrading for instance)
 func UploadConfiguration(cfg *kubeadmapi.InitConfiguration, client clientset.Interface) error {
-	fmt.Printf("[upload-config] Storing the configuration used in ConfigMap %q in the %q Namespace\n", kubeadmconstants.KubeadmConfigConfigMap, metav1.NamespaceSystem)
+	logutil.Infof("upload-config", "Storing the configuration used in ConfigMap %q in the %q Namespace", kubeadmconstants.KubeadmConfigConfigMap, metav1.NamespaceSystem)
 
 	// Prepare the ClusterConfiguration for upload
 	// The components store their config in their own ConfigMaps, then reset the .ComponentConfig struct;
@@ -141,7 +142,7 @@ func UploadConfiguration(cfg *kubeadmapi.InitConfiguration, client clientset.Int
 	// Binds the NodesKubeadmConfigClusterRoleName to all the bootstrap tokens
 	// that are members of the system:bootstrappers:kubeadm:default-node-token group
 	// and to all nodes
-	return apiclient.CreateOrUpdateRoleBinding(client, &rbac.RoleBinding{
+	err = apiclient.CreateOrUpdateRoleBinding(client, &rbac.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      NodesKubeadmConfigClusterRoleName,
 			Namespace: metav1.NamespaceSystem,
@@ -162,6 +163,18 @@ func UploadConfiguration(cfg *kubeadmapi.InitConfiguration, client clientset.Int
 			},
 		},
 	})
+	if err != nil {
+		return err
+	}
+
+	// Upload the component configs of any registered component that is not covered
+	// by a dedicated kubeadm phase (such as the kubelet and kube-proxy ones)
+	if err := componentconfigs.Upload(&cfg.ClusterConfiguration, client); err != nil {
+		return err
+	}
+
+	// Apply and reconcile the additional RBAC manifests declared in the configuration
+	return extrarbac.EnsureExtraRBAC(&cfg.ClusterConfiguration, client)
 }
 
 func mutateClusterStatus(cm *v1.ConfigMap, mutator func(*kubeadmapi.ClusterStatus) error) error {
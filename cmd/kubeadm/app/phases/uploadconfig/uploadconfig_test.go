@@ -19,18 +19,22 @@ package uploadconfig
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientsetfake "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	kubeadmscheme "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
 	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
 	configutil "k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	"k8s.io/kubernetes/cmd/kubeadm/test/fixtures"
 )
 
 func TestUploadConfiguration(t *testing.T) {
@@ -163,6 +167,49 @@ func TestUploadConfiguration(t *testing.T) {
 	}
 }
 
+func TestUploadConfigurationRetriesOnConflict(t *testing.T) {
+	initialcfg := &kubeadmapiv1beta2.InitConfiguration{
+		LocalAPIEndpoint: kubeadmapiv1beta2.APIEndpoint{
+			AdvertiseAddress: "1.2.3.4",
+		},
+		NodeRegistration: kubeadmapiv1beta2.NodeRegistrationOptions{
+			Name:      "node-foo",
+			CRISocket: "/var/run/custom-cri.sock",
+		},
+	}
+	clustercfg := &kubeadmapiv1beta2.ClusterConfiguration{
+		KubernetesVersion: kubeadmconstants.MinimumControlPlaneVersion.WithPatch(10).String(),
+	}
+	cfg, err := configutil.DefaultedInitConfiguration(initialcfg, clustercfg)
+	if err != nil {
+		t.Fatalf("unexpected error building the configuration: %v", err)
+	}
+	cfg.ComponentConfigs = kubeadmapi.ComponentConfigMap{}
+
+	// keep the conflict retries fast, restoring the documented default afterwards
+	apiclient.SetAPICallRetryBackoff(wait.Backoff{Steps: 5, Duration: time.Millisecond, Factor: 1.0})
+	defer apiclient.SetAPICallRetryBackoff(wait.Backoff{Steps: 20, Duration: 500 * time.Millisecond, Factor: 1.0, Jitter: 0.1})
+
+	// the first upload creates the ConfigMap; the second takes the update path, whose
+	// first two attempts conflict, so the retry-on-conflict behavior of the
+	// idempotency helpers is actually exercised
+	client := fixtures.NewConflictingClient(2)
+	if err := UploadConfiguration(cfg, client); err != nil {
+		t.Fatalf("UploadConfiguration() returned an error on the initial upload: %v", err)
+	}
+	if err := UploadConfiguration(cfg, client); err != nil {
+		t.Fatalf("UploadConfiguration() returned an error despite retries being possible: %v", err)
+	}
+
+	controlPlaneCfg, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(kubeadmconstants.KubeadmConfigConfigMap, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to query the ConfigMap: %v", err)
+	}
+	if controlPlaneCfg.Data[kubeadmconstants.ClusterConfigurationConfigMapKey] == "" {
+		t.Fatal("the ClusterConfiguration key was not uploaded")
+	}
+}
+
 func TestMutateClusterStatus(t *testing.T) {
 	cm := &v1.ConfigMap{
 		Data: map[string]string{
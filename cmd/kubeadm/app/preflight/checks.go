@@ -31,27 +31,48 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/purell"
 	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
 	netutil "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
 	versionutil "k8s.io/apimachinery/pkg/util/version"
 	kubeadmversion "k8s.io/component-base/version"
 	"k8s.io/klog"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmapiv1beta2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/componentconfigs"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/images"
+	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/initsystem"
 	utilruntime "k8s.io/kubernetes/cmd/kubeadm/app/util/runtime"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/skew"
+	warningspkg "k8s.io/kubernetes/cmd/kubeadm/app/util/warnings"
 	system "k8s.io/system-validators/validators"
 	utilsexec "k8s.io/utils/exec"
 	utilsnet "k8s.io/utils/net"
 )
 
 const (
+	// checkTimeout bounds the execution of a single preflight check
+	checkTimeout = 5 * time.Minute
+
+	// minEntropyAvailable is the minimum amount of entropy (in bits) below which EntropyCheck warns
+	minEntropyAvailable = 128
+
+	// minDiskSpaceBytes is the minimum amount of free disk space below which DiskAvailableCheck warns
+	minDiskSpaceBytes = 2 * 1024 * 1024 * 1024
+
+	// minFreeInodes is the minimum number of free inodes below which DiskAvailableCheck warns
+	minFreeInodes = 1000
+
 	bridgenf                    = "/proc/sys/net/bridge/bridge-nf-call-iptables"
 	bridgenf6                   = "/proc/sys/net/bridge/bridge-nf-call-ip6tables"
 	ipv4Forward                 = "/proc/sys/net/ipv4/ip_forward"
@@ -669,6 +690,389 @@ func (swc SwapCheck) Check() (warnings, errorList []error) {
 	return nil, nil
 }
 
+// SandboxImageCheck warns when the sandbox (pause) image the container runtime is
+// configured with differs from the one kubeadm expects; mismatched pause images cause
+// subtle breakage, e.g. pods restarting on kubelet flag changes
+type SandboxImageCheck struct {
+	runtime       utilruntime.ContainerRuntime
+	expectedImage string
+}
+
+// Name will return SandboxImage as name for SandboxImageCheck
+func (SandboxImageCheck) Name() string {
+	return "SandboxImage"
+}
+
+// Check compares the runtime's configured sandbox image with the expected pause image
+func (sic SandboxImageCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infoln("validating the sandbox image of the container runtime")
+	sandboxImage, err := sic.runtime.SandboxImage()
+	if err != nil {
+		// the runtime does not report a sandbox image; nothing meaningful to compare
+		klog.V(2).Infof("skipping the sandbox image check: %v", err)
+		return nil, nil
+	}
+	if sandboxImage != sic.expectedImage {
+		return []error{errors.Errorf("the container runtime is configured with sandbox image %q, while kubeadm expects %q; "+
+			"align the runtime configuration to avoid pods being restarted with a different pause image", sandboxImage, sic.expectedImage)}, nil
+	}
+	return nil, nil
+}
+
+// BinfmtEmulationCheck warns when the kubeadm binary runs under binary emulation
+// (e.g. qemu-user via binfmt_misc), as pulled images will then default to the emulated
+// architecture and all components will run with a heavy performance penalty
+type BinfmtEmulationCheck struct {
+	exec utilsexec.Interface
+}
+
+// Name will return BinfmtEmulation as name for BinfmtEmulationCheck
+func (BinfmtEmulationCheck) Name() string {
+	return "BinfmtEmulation"
+}
+
+// Check compares the architecture of the running binary with the architecture of the kernel
+func (bec BinfmtEmulationCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infoln("validating the binary does not run under binary emulation")
+	out, err := bec.exec.Command("uname", "-m").Output()
+	if err != nil {
+		// the machine architecture cannot be determined, there is nothing meaningful to report
+		return nil, nil
+	}
+
+	kernelArches := map[string]string{
+		"x86_64":  "amd64",
+		"aarch64": "arm64",
+		"armv7l":  "arm",
+		"ppc64le": "ppc64le",
+		"s390x":   "s390x",
+	}
+	kernelArch, ok := kernelArches[strings.TrimSpace(string(out))]
+	if !ok {
+		return nil, nil
+	}
+	if kernelArch != runtime.GOARCH {
+		return []error{errors.Errorf("this %s kubeadm binary appears to run under emulation on a %s kernel; images and components will not match the node architecture", runtime.GOARCH, kernelArch)}, nil
+	}
+	return nil, nil
+}
+
+// ImageArchitectureCheck verifies that the configured image repository serves a manifest
+// for the architecture of this node, so missing per-arch images surface before init
+// instead of as pull errors afterwards
+type ImageArchitectureCheck struct {
+	// Image is the image (registry/repository:tag) whose manifest list is inspected
+	Image string
+
+	// Architecture is the required architecture; the architecture of the running
+	// binary is used when empty
+	Architecture string
+}
+
+// Name returns a label for this ImageArchitectureCheck, based on the image name
+func (iac ImageArchitectureCheck) Name() string {
+	_, repository, _ := images.SplitImageName(iac.Image)
+	parts := strings.Split(repository, "/")
+	return fmt.Sprintf("ImageArchitecture-%s", parts[len(parts)-1])
+}
+
+// Check fetches the manifest list of the image from the registry and looks for the node architecture
+func (iac ImageArchitectureCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infof("validating the architectures served for image %q", iac.Image)
+
+	registry, repository, tag := images.SplitImageName(iac.Image)
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repository, tag)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return []error{errors.Wrapf(err, "could not build a manifest request for image %q", iac.Image)}, nil
+	}
+	req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.list.v2+json")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return []error{errors.Wrapf(err, "could not verify the architectures served for image %q", iac.Image)}, nil
+	}
+	defer resp.Body.Close()
+
+	// registries requiring authentication for anonymous manifest reads cannot be verified here
+	if resp.StatusCode != http.StatusOK {
+		return []error{errors.Errorf("could not verify the architectures served for image %q: %s returned status %d", iac.Image, url, resp.StatusCode)}, nil
+	}
+
+	manifestList := struct {
+		Manifests []struct {
+			Platform struct {
+				Architecture string `json:"architecture"`
+			} `json:"platform"`
+		} `json:"manifests"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&manifestList); err != nil {
+		return []error{errors.Wrapf(err, "could not parse the manifest list of image %q", iac.Image)}, nil
+	}
+	architecture := iac.Architecture
+	if architecture == "" {
+		architecture = runtime.GOARCH
+	}
+
+	// a single-architecture manifest has no manifests entries; assume the image matches
+	if len(manifestList.Manifests) == 0 {
+		return nil, nil
+	}
+	for _, manifest := range manifestList.Manifests {
+		if manifest.Platform.Architecture == architecture {
+			return nil, nil
+		}
+	}
+	return nil, []error{errors.Errorf("image %q does not serve a manifest for the node architecture %q", iac.Image, architecture)}
+}
+
+// ControlPlaneEndpointCheck dials the configured control plane endpoint and verifies that
+// the load balancer in front of it actually routes to a healthy kube-apiserver serving a
+// certificate that is valid for the endpoint - catching misconfigured load balancers
+// before any changes are made to the node
+type ControlPlaneEndpointCheck struct {
+	// Endpoint is the control plane endpoint (host:port) to verify
+	Endpoint string
+}
+
+// Name will return ControlPlaneEndpoint as name for ControlPlaneEndpointCheck
+func (ControlPlaneEndpointCheck) Name() string {
+	return "ControlPlaneEndpoint"
+}
+
+// Check dials the endpoint, validates the served certificate and probes /healthz
+func (cpec ControlPlaneEndpointCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infof("validating the control plane endpoint %q", cpec.Endpoint)
+
+	// the endpoint may come without a port, in which case the default API server port is assumed
+	host, port, err := kubeadmutil.ParseHostPort(cpec.Endpoint)
+	if err != nil {
+		return nil, []error{errors.Wrapf(err, "control plane endpoint %q is not a valid host:port", cpec.Endpoint)}
+	}
+	if port == "" {
+		port = strconv.Itoa(kubeadmapiv1beta2.DefaultAPIBindPort)
+	}
+	endpoint := net.JoinHostPort(host, port)
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", endpoint, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return nil, []error{errors.Wrapf(err, "the control plane endpoint %q is not reachable", cpec.Endpoint)}
+	}
+	peerCerts := conn.ConnectionState().PeerCertificates
+	conn.Close()
+
+	if len(peerCerts) == 0 {
+		return nil, []error{errors.Errorf("the control plane endpoint %q did not serve a certificate", cpec.Endpoint)}
+	}
+	if err := peerCerts[0].VerifyHostname(host); err != nil {
+		errorList = append(errorList, errors.Wrapf(err, "the certificate served at %q is not valid for the control plane endpoint", cpec.Endpoint))
+	}
+
+	// Probe /healthz through the load balancer, so traffic is verified to reach a healthy kube-apiserver
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	url := fmt.Sprintf("https://%s/healthz", endpoint)
+	resp, err := client.Get(url)
+	if err != nil {
+		errorList = append(errorList, errors.Wrapf(err, "the control plane endpoint %q does not route to a kube-apiserver", cpec.Endpoint))
+		return nil, errorList
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errorList = append(errorList, errors.Errorf("the kube-apiserver behind the control plane endpoint %q is not healthy: %s returned status %d", cpec.Endpoint, url, resp.StatusCode))
+	}
+
+	return nil, errorList
+}
+
+// CgroupVersionCheck reports which cgroup version the node runs and warns when the
+// kubelet support for it is not mature yet
+type CgroupVersionCheck struct{}
+
+// Name will return CgroupVersion as name for CgroupVersionCheck
+func (CgroupVersionCheck) Name() string {
+	return "CgroupVersion"
+}
+
+// CgroupControllerCheck verifies that a cgroup controller required by the kubelet is
+// available on the node, for both cgroup v1 and v2 hierarchies. Every controller is a
+// separate check so it gets its own --ignore-preflight-errors key and its own entry in
+// the machine readable preflight report.
+type CgroupControllerCheck struct {
+	// Controller is the name of the required cgroup controller
+	Controller string
+}
+
+// Name returns a label for this CgroupControllerCheck, based on the controller name
+func (ccc CgroupControllerCheck) Name() string {
+	return fmt.Sprintf("CgroupController-%s", ccc.Controller)
+}
+
+// ExternalEtcdClusterCheck validates the Etcd.External configuration end to end: every
+// endpoint must be reachable with the provided CA/cert/key, serve a supported version
+// and report the same cluster ID
+type ExternalEtcdClusterCheck struct {
+	Etcd *kubeadmapi.ExternalEtcd
+}
+
+// Name will return ExternalEtcdCluster as name for ExternalEtcdClusterCheck
+func (ExternalEtcdClusterCheck) Name() string {
+	return "ExternalEtcdCluster"
+}
+
+// Check validates the external etcd cluster and reports one finding per broken endpoint
+func (eecc ExternalEtcdClusterCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infoln("validating the external etcd cluster")
+	statuses, err := etcdutil.ValidateExternalEtcd(eecc.Etcd)
+	if err == nil {
+		return nil, nil
+	}
+	for _, status := range statuses {
+		if status.Error != "" {
+			errorList = append(errorList, errors.Errorf("endpoint %s: %s", status.Endpoint, status.Error))
+		}
+	}
+	if len(errorList) == 0 {
+		errorList = append(errorList, err)
+	}
+	return nil, errorList
+}
+
+// VersionSkewCheck enforces the kubeadm version skew policy against the target
+// Kubernetes version and the kubelet and kubectl binaries found on the node, printing
+// the allowed ranges on violations
+type VersionSkewCheck struct {
+	// KubernetesVersion is the target control plane version
+	KubernetesVersion string
+
+	exec utilsexec.Interface
+}
+
+// Name will return VersionSkew as name for VersionSkewCheck
+func (VersionSkewCheck) Name() string {
+	return "VersionSkew"
+}
+
+// Check validates the versions of kubeadm, the kubelet and kubectl against the policy
+func (vsc VersionSkewCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infoln("validating the version skew policy")
+	targetVersion, err := versionutil.ParseSemantic(vsc.KubernetesVersion)
+	if err != nil {
+		return nil, []error{errors.Wrapf(err, "couldn't parse Kubernetes version %q", vsc.KubernetesVersion)}
+	}
+
+	if kubeadmVersion, err := versionutil.ParseSemantic(kubeadmversion.Get().GitVersion); err == nil {
+		if err := skew.ValidateKubeadm(kubeadmVersion, targetVersion); err != nil {
+			errorList = append(errorList, err)
+		}
+	}
+
+	if kubeletVersion, err := GetKubeletVersion(vsc.exec); err == nil {
+		if err := skew.ValidateKubelet(kubeletVersion, targetVersion); err != nil {
+			errorList = append(errorList, err)
+		}
+	}
+
+	if out, err := vsc.exec.Command("kubectl", "version", "--client", "--short").Output(); err == nil {
+		fields := strings.Fields(string(out))
+		if len(fields) > 0 {
+			if kubectlVersion, err := versionutil.ParseSemantic(fields[len(fields)-1]); err == nil {
+				if err := skew.ValidateKubectl(kubectlVersion, targetVersion); err != nil {
+					warnings = append(warnings, err)
+				}
+			}
+		}
+	}
+
+	return warnings, errorList
+}
+
+// ClockSyncCheck verifies that a time synchronization service is active on the node.
+// Unsynchronized clocks between the cluster nodes are a common cause of certificate
+// validation and leader election problems.
+type ClockSyncCheck struct{}
+
+// Name will return ClockSync as name for ClockSyncCheck
+func (ClockSyncCheck) Name() string {
+	return "ClockSync"
+}
+
+// Check validates whether one of the known time synchronization services is active
+func (ClockSyncCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infoln("validating whether a time synchronization service is active")
+	initSystem, err := initsystem.GetInitSystem()
+	if err != nil {
+		// no init system detected, there is nothing meaningful to probe
+		return nil, nil
+	}
+	services := []string{"chronyd", "chrony", "ntpd", "ntp", "systemd-timesyncd"}
+	for _, service := range services {
+		if initSystem.ServiceIsActive(service) {
+			return nil, nil
+		}
+	}
+	return []error{errors.Errorf("no active time synchronization service was found (looked for %v); unsynchronized clocks can cause TLS and leader election failures", services)}, nil
+}
+
+// EntropyCheck verifies that the node has enough entropy available, as certificate
+// generation can block for a long time on entropy starved machines
+type EntropyCheck struct{}
+
+// Name will return Entropy as name for EntropyCheck
+func (EntropyCheck) Name() string {
+	return "Entropy"
+}
+
+// DiskAvailableCheck verifies that the filesystem holding the passed path has enough
+// free space and free inodes
+type DiskAvailableCheck struct {
+	// Path is the location whose filesystem is verified. If the path does not exist yet,
+	// the closest existing parent directory is used.
+	Path string
+
+	// MinFreeBytes is the minimum amount of free space required
+	MinFreeBytes uint64
+
+	// MinFreeInodes is the minimum number of free inodes required
+	MinFreeInodes uint64
+}
+
+// Name returns a label for this DiskAvailableCheck, based on the checked path
+func (dac DiskAvailableCheck) Name() string {
+	return fmt.Sprintf("DiskAvailable-%s", strings.Replace(strings.Trim(dac.Path, "/"), "/", "-", -1))
+}
+
+// NFTablesCheck verifies that the kernel supports the nf_tables subsystem,
+// which is required when kube-proxy runs in the nftables mode
+type NFTablesCheck struct{}
+
+// Name will return NFTables as name for NFTablesCheck
+func (NFTablesCheck) Name() string {
+	return "NFTables"
+}
+
+// Check validates whether the nf_tables kernel subsystem is available
+func (NFTablesCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infoln("validating the kernel supports nf_tables")
+	if _, err := os.Stat("/sys/module/nf_tables"); err != nil {
+		// The module could still be available but not loaded yet, so only
+		// error out when modprobe cannot find it either
+		if err := utilsexec.New().Command("modprobe", "-n", "nf_tables").Run(); err != nil {
+			return nil, []error{errors.New("the kube-proxy nftables mode requires the nf_tables kernel subsystem, which is not available")}
+		}
+	}
+	if _, err := utilsexec.New().LookPath("nft"); err != nil {
+		return []error{errors.New("nft not found in system path")}, nil
+	}
+	return nil, nil
+}
+
 type etcdVersionResponse struct {
 	Etcdserver  string `json:"etcdserver"`
 	Etcdcluster string `json:"etcdcluster"`
@@ -830,20 +1234,15 @@ func (ImagePullCheck) Name() string {
 	return "ImagePull"
 }
 
-// Check pulls images required by kubeadm. This is a mutating check
+// Check pulls images required by kubeadm. This is a mutating check.
+// Images are pulled in parallel and failed pulls are retried with backoff.
 func (ipc ImagePullCheck) Check() (warnings, errorList []error) {
-	for _, image := range ipc.imageList {
-		ret, err := ipc.runtime.ImageExists(image)
-		if ret && err == nil {
-			klog.V(1).Infof("image exists: %s", image)
-			continue
-		}
-		if err != nil {
-			errorList = append(errorList, errors.Wrapf(err, "failed to check if image %s exists", image))
-		}
-		klog.V(1).Infof("pulling %s", image)
-		if err := ipc.runtime.PullImage(image); err != nil {
-			errorList = append(errorList, errors.Wrapf(err, "failed to pull image %s", image))
+	results := utilruntime.PullImages(ipc.runtime, ipc.imageList, utilruntime.DefaultPullConcurrency, func(result utilruntime.PullResult) {
+		klog.V(1).Infof("image %s: %s", result.Image, result.Status)
+	})
+	for _, result := range results {
+		if result.Status == "failed" {
+			errorList = append(errorList, errors.Errorf("failed to pull image %s: %s", result.Image, result.Error))
 		}
 	}
 	return warnings, errorList
@@ -873,10 +1272,20 @@ func (ncc NumCPUCheck) Check() (warnings, errorList []error) {
 // The boolean flag 'downloadCerts' controls whether we should skip checks on certificates because we are downloading them.
 // If the flag is set to true we should skip checks already executed by RunJoinNodeChecks.
 func RunInitNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.InitConfiguration, ignorePreflightErrors sets.String, isSecondaryControlPlane bool, downloadCerts bool) error {
+	checks, err := InitNodeChecks(execer, cfg, ignorePreflightErrors, isSecondaryControlPlane, downloadCerts)
+	if err != nil {
+		return err
+	}
+	return RunChecks(checks, os.Stderr, ignorePreflightErrors)
+}
+
+// InitNodeChecks returns the preflight checks applicable to a new control-plane node, without running them.
+// Use this together with RunChecksWithReport when the structured results of the run are needed.
+func InitNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.InitConfiguration, ignorePreflightErrors sets.String, isSecondaryControlPlane bool, downloadCerts bool) ([]Checker, error) {
 	if !isSecondaryControlPlane {
 		// First, check if we're root separately from the other preflight checks and fail fast
 		if err := RunRootCheckOnly(ignorePreflightErrors); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -903,8 +1312,49 @@ func RunInitNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.InitConfigura
 		checks = append(checks, HTTPProxyCIDRCheck{Proto: "https", CIDR: cidr})
 	}
 
+	// If kube-proxy is configured to run in the nftables mode, verify the kernel supports it
+	if componentconfigs.GetKubeProxyMode(&cfg.ClusterConfiguration) == kubeadmconstants.KubeProxyModeNFTables {
+		checks = append(checks, NFTablesCheck{})
+	}
+
+	// Verify the configured repositories serve every control plane image for the
+	// architecture of this node, so missing per-arch images surface here instead of as
+	// pull errors halfway through init
+	for _, image := range images.GetControlPlaneImages(&cfg.ClusterConfiguration) {
+		checks = append(checks, ImageArchitectureCheck{Image: image})
+	}
+
+	// A node joining as an additional control-plane instance must be able to reach the
+	// control plane endpoint it will be served under
+	if isSecondaryControlPlane && cfg.ControlPlaneEndpoint != "" {
+		checks = append(checks, ControlPlaneEndpointCheck{Endpoint: cfg.ControlPlaneEndpoint})
+	}
+
+	// Verify the host paths of the declared extra volumes exist, so invalid mounts
+	// surface here instead of as apiserver crash loops
+	for _, component := range []struct {
+		name    string
+		volumes []kubeadmapi.HostPathMount
+	}{
+		{kubeadmconstants.KubeAPIServer, cfg.APIServer.ExtraVolumes},
+		{kubeadmconstants.KubeControllerManager, cfg.ControllerManager.ExtraVolumes},
+		{kubeadmconstants.KubeScheduler, cfg.Scheduler.ExtraVolumes},
+	} {
+		for _, volume := range component.volumes {
+			// path types that create missing paths don't need the path to exist
+			if volume.PathType == v1.HostPathDirectoryOrCreate || volume.PathType == v1.HostPathFileOrCreate {
+				continue
+			}
+			checks = append(checks, FileExistingCheck{
+				Path:  volume.HostPath,
+				Label: fmt.Sprintf("ExtraVolume-%s-%s", component.name, volume.Name),
+			})
+		}
+	}
+
 	if !isSecondaryControlPlane {
-		checks = addCommonChecks(execer, cfg.KubernetesVersion, &cfg.NodeRegistration, checks)
+		checks = addCommonChecks(execer, cfg.KubernetesVersion, &cfg.NodeRegistration, checks, images.GetPauseImage(&cfg.ClusterConfiguration))
+		checks = append(checks, VersionSkewCheck{KubernetesVersion: cfg.KubernetesVersion, exec: execer})
 
 		// Check if Bridge-netfilter and IPv6 relevant flags are set
 		if ip := net.ParseIP(cfg.LocalAPIEndpoint.AdvertiseAddress); ip != nil {
@@ -920,6 +1370,8 @@ func RunInitNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.InitConfigura
 		if cfg.Etcd.External != nil {
 			// Check external etcd version before creating the cluster
 			checks = append(checks, ExternalEtcdVersionCheck{Etcd: cfg.Etcd})
+			// Validate reachability, credentials and cluster ID consistency of every endpoint
+			checks = append(checks, ExternalEtcdClusterCheck{Etcd: cfg.Etcd.External})
 		}
 	}
 
@@ -929,6 +1381,7 @@ func RunInitNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.InitConfigura
 			PortOpenCheck{port: kubeadmconstants.EtcdListenClientPort},
 			PortOpenCheck{port: kubeadmconstants.EtcdListenPeerPort},
 			DirAvailableCheck{Path: cfg.Etcd.Local.DataDir},
+			DiskAvailableCheck{Path: cfg.Etcd.Local.DataDir, MinFreeBytes: minDiskSpaceBytes, MinFreeInodes: minFreeInodes},
 		)
 	}
 
@@ -945,14 +1398,24 @@ func RunInitNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.InitConfigura
 		}
 	}
 
-	return RunChecks(checks, os.Stderr, ignorePreflightErrors)
+	return checks, nil
 }
 
 // RunJoinNodeChecks executes all individual, applicable to node checks.
 func RunJoinNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.JoinConfiguration, ignorePreflightErrors sets.String) error {
+	checks, err := JoinNodeChecks(execer, cfg, ignorePreflightErrors)
+	if err != nil {
+		return err
+	}
+	return RunChecks(checks, os.Stderr, ignorePreflightErrors)
+}
+
+// JoinNodeChecks returns the preflight checks applicable to a joining node, without running them.
+// Use this together with RunChecksWithReport when the structured results of the run are needed.
+func JoinNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.JoinConfiguration, ignorePreflightErrors sets.String) ([]Checker, error) {
 	// First, check if we're root separately from the other preflight checks and fail fast
 	if err := RunRootCheckOnly(ignorePreflightErrors); err != nil {
-		return err
+		return nil, err
 	}
 
 	checks := []Checker{
@@ -960,7 +1423,7 @@ func RunJoinNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.JoinConfigura
 		FileAvailableCheck{Path: filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.KubeletKubeConfigFileName)},
 		FileAvailableCheck{Path: filepath.Join(kubeadmconstants.KubernetesDir, kubeadmconstants.KubeletBootstrapKubeConfigFileName)},
 	}
-	checks = addCommonChecks(execer, "", &cfg.NodeRegistration, checks)
+	checks = addCommonChecks(execer, "", &cfg.NodeRegistration, checks, "")
 	if cfg.ControlPlane == nil {
 		checks = append(checks, FileAvailableCheck{Path: cfg.CACertPath})
 	}
@@ -986,18 +1449,21 @@ func RunJoinNodeChecks(execer utilsexec.Interface, cfg *kubeadmapi.JoinConfigura
 		)
 	}
 
-	return RunChecks(checks, os.Stderr, ignorePreflightErrors)
+	return checks, nil
 }
 
 // addCommonChecks is a helper function to duplicate checks that are common between both the
 // kubeadm init and join commands
-func addCommonChecks(execer utilsexec.Interface, k8sVersion string, nodeReg *kubeadmapi.NodeRegistrationOptions, checks []Checker) []Checker {
+func addCommonChecks(execer utilsexec.Interface, k8sVersion string, nodeReg *kubeadmapi.NodeRegistrationOptions, checks []Checker, pauseImage string) []Checker {
 	containerRuntime, err := utilruntime.NewContainerRuntime(execer, nodeReg.CRISocket)
 	isDocker := false
 	if err != nil {
 		fmt.Printf("[preflight] WARNING: Couldn't create the interface used for talking to the container runtime: %v\n", err)
 	} else {
 		checks = append(checks, ContainerRuntimeCheck{runtime: containerRuntime})
+		if pauseImage != "" {
+			checks = append(checks, SandboxImageCheck{runtime: containerRuntime, expectedImage: pauseImage})
+		}
 		if containerRuntime.IsDocker() {
 			isDocker = true
 			checks = append(checks, ServiceCheck{Service: "docker", CheckIfActive: true})
@@ -1014,6 +1480,11 @@ func addCommonChecks(execer utilsexec.Interface, k8sVersion string, nodeReg *kub
 			checks = append(checks, InPathCheck{executable: "crictl", mandatory: true, exec: execer})
 		}
 		checks = append(checks,
+			BinfmtEmulationCheck{exec: execer},
+			CgroupVersionCheck{},
+			CgroupControllerCheck{Controller: "cpu"},
+			CgroupControllerCheck{Controller: "memory"},
+			CgroupControllerCheck{Controller: "pids"},
 			FileContentCheck{Path: bridgenf, Content: []byte{'1'}},
 			FileContentCheck{Path: ipv4Forward, Content: []byte{'1'}},
 			SwapCheck{},
@@ -1033,7 +1504,14 @@ func addCommonChecks(execer utilsexec.Interface, k8sVersion string, nodeReg *kub
 		HostnameCheck{nodeName: nodeReg.Name},
 		KubeletVersionCheck{KubernetesVersion: k8sVersion, exec: execer},
 		ServiceCheck{Service: "kubelet", CheckIfActive: false},
-		PortOpenCheck{port: kubeadmconstants.KubeletPort})
+		PortOpenCheck{port: kubeadmconstants.KubeletPort},
+		ClockSyncCheck{},
+		EntropyCheck{},
+		DiskAvailableCheck{Path: kubeadmconstants.KubeletRunDirectory, MinFreeBytes: minDiskSpaceBytes, MinFreeInodes: minFreeInodes})
+
+	// Append any checks added to the registry and any external check plugins found on the node
+	checks = addRegisteredChecks(execer, checks)
+
 	return checks
 }
 
@@ -1052,6 +1530,11 @@ func RunPullImagesCheck(execer utilsexec.Interface, cfg *kubeadmapi.InitConfigur
 	if err != nil {
 		return err
 	}
+	if cfg.ImagePull != nil {
+		if err := utilruntime.ApplyImagePullConfiguration(containerRuntime, cfg.ImagePull.CredentialsFile); err != nil {
+			return err
+		}
+	}
 
 	checks := []Checker{
 		ImagePullCheck{runtime: containerRuntime, imageList: images.GetControlPlaneImages(&cfg.ClusterConfiguration)},
@@ -1062,11 +1545,32 @@ func RunPullImagesCheck(execer utilsexec.Interface, cfg *kubeadmapi.InitConfigur
 // RunChecks runs each check, displays it's warnings/errors, and once all
 // are processed will exit if any errors occurred.
 func RunChecks(checks []Checker, ww io.Writer, ignorePreflightErrors sets.String) error {
+	_, err := RunChecksWithReport(checks, ww, ignorePreflightErrors)
+	return err
+}
+
+// RunChecksWithReport behaves like RunChecks, but additionally returns the structured
+// results of every executed check. The report is returned also when checks failed.
+// The checks are independent of each other and are run concurrently, each bounded by
+// checkTimeout, so network dependent checks cannot serialize or stall preflight.
+func RunChecksWithReport(checks []Checker, ww io.Writer, ignorePreflightErrors sets.String) (*Report, error) {
 	var errsBuffer bytes.Buffer
+	report := &Report{}
+
+	outcomes := make([]checkOutcome, len(checks))
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outcomes[i] = runCheckWithTimeout(checks[i])
+		}(i)
+	}
+	wg.Wait()
 
-	for _, c := range checks {
+	for i, c := range checks {
 		name := c.Name()
-		warnings, errs := c.Check()
+		warnings, errs := outcomes[i].warnings, outcomes[i].errs
 
 		if setHasItemOrAll(ignorePreflightErrors, name) {
 			// Decrease severity of errors to warnings for this check
@@ -1074,17 +1578,43 @@ func RunChecks(checks []Checker, ww io.Writer, ignorePreflightErrors sets.String
 			errs = []error{}
 		}
 
+		report.Checks = append(report.Checks, newCheckResult(name, warnings, errs))
+
 		for _, w := range warnings {
 			io.WriteString(ww, fmt.Sprintf("\t[WARNING %s]: %v\n", name, w))
+			warningspkg.Collect("[preflight %s] %v", name, w)
 		}
 		for _, i := range errs {
 			errsBuffer.WriteString(fmt.Sprintf("\t[ERROR %s]: %v\n", name, i.Error()))
 		}
 	}
 	if errsBuffer.Len() > 0 {
-		return &Error{Msg: errsBuffer.String()}
+		return report, &Error{Msg: errsBuffer.String()}
+	}
+	return report, nil
+}
+
+// checkOutcome holds the raw findings of a single check run
+type checkOutcome struct {
+	warnings, errs []error
+}
+
+// runCheckWithTimeout runs a single check, giving up after checkTimeout. The goroutine
+// of a timed out check is left behind, but the process is expected to exit shortly after
+// preflight anyway.
+func runCheckWithTimeout(c Checker) checkOutcome {
+	done := make(chan checkOutcome, 1)
+	go func() {
+		warnings, errs := c.Check()
+		done <- checkOutcome{warnings: warnings, errs: errs}
+	}()
+
+	select {
+	case outcome := <-done:
+		return outcome
+	case <-time.After(checkTimeout):
+		return checkOutcome{errs: []error{errors.Errorf("the check did not complete within %v", checkTimeout)}}
 	}
-	return nil
 }
 
 // setHasItemOrAll is helper function that return true if item is present in the set (case insensitive) or special key 'all' is present
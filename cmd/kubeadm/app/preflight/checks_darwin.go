@@ -25,3 +25,21 @@ package preflight
 func (idsc IsDockerSystemdCheck) Check() (warnings, errorList []error) {
 	return nil, nil
 }
+
+// Check validates that the node has enough entropy available.
+// No-op for Darwin (MacOS).
+func (EntropyCheck) Check() (warnings, errorList []error) {
+	return nil, nil
+}
+
+// Check reports the cgroup version of the node.
+// No-op for Darwin (MacOS).
+func (CgroupVersionCheck) Check() (warnings, errorList []error) {
+	return nil, nil
+}
+
+// Check verifies that the required cgroup controller is available on the node.
+// No-op for Darwin (MacOS).
+func (ccc CgroupControllerCheck) Check() (warnings, errorList []error) {
+	return nil, nil
+}
@@ -19,11 +19,87 @@ limitations under the License.
 package preflight
 
 import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
 	"github.com/pkg/errors"
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
 	"k8s.io/utils/exec"
 )
 
+const (
+	// cgroup2SuperMagic is the filesystem magic of a cgroup v2 unified hierarchy
+	// (CGROUP2_SUPER_MAGIC from the kernel headers)
+	cgroup2SuperMagic = 0x63677270
+)
+
+// isCgroupV2 detects whether /sys/fs/cgroup is a cgroup v2 unified hierarchy
+func isCgroupV2() (bool, error) {
+	stat := syscall.Statfs_t{}
+	if err := syscall.Statfs("/sys/fs/cgroup", &stat); err != nil {
+		return false, errors.Wrap(err, "error getting filesystem information for /sys/fs/cgroup")
+	}
+	return stat.Type == cgroup2SuperMagic, nil
+}
+
+// Check reports the cgroup version of the node
+func (CgroupVersionCheck) Check() (warnings, errorList []error) {
+	v2, err := isCgroupV2()
+	if err != nil {
+		return []error{err}, nil
+	}
+	if v2 {
+		return []error{errors.New("the node is running with the cgroup v2 unified hierarchy, for which kubelet support is still in development")}, nil
+	}
+	return nil, nil
+}
+
+// Check verifies that the required cgroup controller is available on the node
+func (ccc CgroupControllerCheck) Check() (warnings, errorList []error) {
+	v2, err := isCgroupV2()
+	if err != nil {
+		return []error{err}, nil
+	}
+
+	if v2 {
+		b, err := ioutil.ReadFile("/sys/fs/cgroup/cgroup.controllers")
+		if err != nil {
+			return nil, []error{errors.Wrap(err, "error reading the available cgroup v2 controllers")}
+		}
+		for _, controller := range strings.Fields(string(b)) {
+			if controller == ccc.Controller {
+				return nil, nil
+			}
+		}
+		return nil, []error{errors.Errorf("the cgroup controller %q is not enabled in the unified hierarchy", ccc.Controller)}
+	}
+
+	if _, err := os.Stat("/sys/fs/cgroup/" + ccc.Controller); err != nil {
+		return nil, []error{errors.Errorf("the cgroup controller %q is not mounted under /sys/fs/cgroup", ccc.Controller)}
+	}
+	return nil, nil
+}
+
+// Check validates that the node has enough entropy available
+func (EntropyCheck) Check() (warnings, errorList []error) {
+	b, err := ioutil.ReadFile("/proc/sys/kernel/random/entropy_avail")
+	if err != nil {
+		// the entropy estimate is not exposed, there is nothing meaningful to report
+		return nil, nil
+	}
+	entropy, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, []error{errors.Wrap(err, "error parsing /proc/sys/kernel/random/entropy_avail")}
+	}
+	if entropy < minEntropyAvailable {
+		return []error{errors.Errorf("available entropy (%d bits) is below the recommended minimum of %d bits; certificate generation may block", entropy, minEntropyAvailable)}, nil
+	}
+	return nil, nil
+}
+
 // Check validates if Docker is setup to use systemd as the cgroup driver.
 func (idsc IsDockerSystemdCheck) Check() (warnings, errorList []error) {
 	driver, err := kubeadmutil.GetCgroupDriverDocker(exec.New())
@@ -20,6 +20,8 @@ package preflight
 
 import (
 	"os"
+	"path/filepath"
+	"syscall"
 
 	"github.com/pkg/errors"
 )
@@ -32,3 +34,34 @@ func (ipuc IsPrivilegedUserCheck) Check() (warnings, errorList []error) {
 
 	return nil, nil
 }
+
+// Check validates that the filesystem holding the path has enough free space and inodes
+func (dac DiskAvailableCheck) Check() (warnings, errorList []error) {
+	// walk up to the closest existing parent, as the checked path may not exist yet
+	path := dac.Path
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+
+	stat := syscall.Statfs_t{}
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return nil, []error{errors.Wrapf(err, "error getting filesystem information for %q", path)}
+	}
+
+	freeBytes := stat.Bavail * uint64(stat.Bsize)
+	if freeBytes < dac.MinFreeBytes {
+		warnings = append(warnings, errors.Errorf("the filesystem holding %q has only %d MB free, less than the recommended %d MB", dac.Path, freeBytes/(1024*1024), dac.MinFreeBytes/(1024*1024)))
+	}
+	// some filesystems (e.g. btrfs) report zero total inodes; skip the inode check there
+	if stat.Files > 0 && stat.Ffree < dac.MinFreeInodes {
+		warnings = append(warnings, errors.Errorf("the filesystem holding %q has only %d free inodes, less than the recommended %d", dac.Path, stat.Ffree, dac.MinFreeInodes))
+	}
+	return warnings, nil
+}
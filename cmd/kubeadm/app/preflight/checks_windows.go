@@ -54,3 +54,27 @@ func (ipuc IsPrivilegedUserCheck) Check() (warnings, errorList []error) {
 func (idsc IsDockerSystemdCheck) Check() (warnings, errorList []error) {
 	return nil, nil
 }
+
+// Check validates that the filesystem holding the path has enough free space and inodes.
+// No-op for Windows.
+func (dac DiskAvailableCheck) Check() (warnings, errorList []error) {
+	return nil, nil
+}
+
+// Check validates that the node has enough entropy available.
+// No-op for Windows.
+func (EntropyCheck) Check() (warnings, errorList []error) {
+	return nil, nil
+}
+
+// Check reports the cgroup version of the node.
+// No-op for Windows.
+func (CgroupVersionCheck) Check() (warnings, errorList []error) {
+	return nil, nil
+}
+
+// Check verifies that the required cgroup controller is available on the node.
+// No-op for Windows.
+func (ccc CgroupControllerCheck) Check() (warnings, errorList []error) {
+	return nil, nil
+}
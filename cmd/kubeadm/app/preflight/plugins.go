@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	utilsexec "k8s.io/utils/exec"
+)
+
+// registeredChecks holds additional checks registered by name, e.g. by importers of this
+// package embedding kubeadm. They are executed at the end of every node check set and
+// their severity can be controlled per check name via --ignore-preflight-errors, like
+// for the built-in checks.
+var registeredChecks = map[string]Checker{}
+
+// RegisterCheck adds an additional check to the registry. The check's name must not be
+// registered already.
+func RegisterCheck(c Checker) error {
+	name := c.Name()
+	if _, ok := registeredChecks[name]; ok {
+		return errors.Errorf("there is already a preflight check registered with name %q", name)
+	}
+	registeredChecks[name] = c
+	return nil
+}
+
+// externalCheckResult is the JSON document an external preflight check plugin must print
+// on stdout. Status must be one of "pass", "warn" or "fail"; the messages are surfaced
+// as warnings or errors accordingly.
+type externalCheckResult struct {
+	Status   string   `json:"status"`
+	Messages []string `json:"messages,omitempty"`
+}
+
+// ExternalCheck runs a preflight check plugin executable and interprets its JSON output.
+// Plugins are discovered in the directory passed to LoadExternalChecks and allow distros
+// to ship their own mandatory checks without recompiling kubeadm.
+type ExternalCheck struct {
+	// Path is the location of the plugin executable
+	Path string
+
+	exec utilsexec.Interface
+}
+
+// Name returns the base name of the plugin executable
+func (ec ExternalCheck) Name() string {
+	return filepath.Base(ec.Path)
+}
+
+// Check executes the plugin and translates its JSON result into warnings and errors
+func (ec ExternalCheck) Check() (warnings, errorList []error) {
+	klog.V(1).Infof("running external preflight check plugin %q", ec.Path)
+	out, err := ec.exec.Command(ec.Path).Output()
+	if err != nil {
+		return nil, []error{errors.Wrapf(err, "external preflight check plugin %q failed to run", ec.Path)}
+	}
+
+	result := externalCheckResult{}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, []error{errors.Wrapf(err, "external preflight check plugin %q printed an invalid result", ec.Path)}
+	}
+
+	resultErrs := []error{}
+	for _, msg := range result.Messages {
+		resultErrs = append(resultErrs, errors.New(msg))
+	}
+
+	switch result.Status {
+	case "pass":
+		return nil, nil
+	case "warn":
+		return resultErrs, nil
+	case "fail":
+		if len(resultErrs) == 0 {
+			resultErrs = append(resultErrs, errors.New("the external check failed without providing messages"))
+		}
+		return nil, resultErrs
+	default:
+		return nil, []error{errors.Errorf("external preflight check plugin %q reported an unknown status %q", ec.Path, result.Status)}
+	}
+}
+
+// LoadExternalChecks returns a check for every executable file found in the passed
+// directory. A missing directory is not an error - no external checks are loaded then.
+func LoadExternalChecks(dir string, execer utilsexec.Interface) ([]Checker, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the external preflight check directory %q", dir)
+	}
+
+	checks := []Checker{}
+	for _, file := range files {
+		if file.IsDir() || file.Mode()&0111 == 0 {
+			continue
+		}
+		checks = append(checks, ExternalCheck{Path: filepath.Join(dir, file.Name()), exec: execer})
+	}
+	return checks, nil
+}
+
+// addRegisteredChecks appends the registered and the external plugin checks to the passed check set
+func addRegisteredChecks(execer utilsexec.Interface, checks []Checker) []Checker {
+	for _, name := range sortedCheckNames() {
+		checks = append(checks, registeredChecks[name])
+	}
+
+	externalChecks, err := LoadExternalChecks(kubeadmconstants.PreflightExternalChecksDir, execer)
+	if err != nil {
+		klog.Warningf("could not load external preflight checks: %v", err)
+		return checks
+	}
+	return append(checks, externalChecks...)
+}
+
+// sortedCheckNames returns the names of the registered checks in a predictable order
+func sortedCheckNames() []string {
+	names := []string{}
+	for name := range registeredChecks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"testing"
+
+	"k8s.io/utils/exec"
+	fakeexec "k8s.io/utils/exec/testing"
+)
+
+func TestRegisterCheck(t *testing.T) {
+	check := FileAvailableCheck{Path: "/foo/bar", Label: "registered-test-check"}
+	if err := RegisterCheck(check); err != nil {
+		t.Fatalf("failed to register a new check: %v", err)
+	}
+	defer delete(registeredChecks, check.Name())
+
+	if err := RegisterCheck(check); err == nil {
+		t.Fatal("expected an error when re-registering a check with the same name, got none")
+	}
+}
+
+func TestExternalCheck(t *testing.T) {
+	var tests = []struct {
+		name           string
+		output         string
+		err            error
+		expectWarnings bool
+		expectErrors   bool
+	}{
+		{
+			name:   "passing plugin",
+			output: `{"status":"pass"}`,
+		},
+		{
+			name:           "warning plugin",
+			output:         `{"status":"warn","messages":["something looks odd"]}`,
+			expectWarnings: true,
+		},
+		{
+			name:         "failing plugin",
+			output:       `{"status":"fail","messages":["something is broken"]}`,
+			expectErrors: true,
+		},
+		{
+			name:         "failing plugin without messages",
+			output:       `{"status":"fail"}`,
+			expectErrors: true,
+		},
+		{
+			name:         "plugin with unknown status",
+			output:       `{"status":"bogus"}`,
+			expectErrors: true,
+		},
+		{
+			name:         "plugin printing garbage",
+			output:       "not json",
+			expectErrors: true,
+		},
+		{
+			name:         "plugin that fails to run",
+			err:          &fakeexec.FakeExitError{Status: 1},
+			expectErrors: true,
+		},
+	}
+
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			fcmd := fakeexec.FakeCmd{
+				OutputScript: []fakeexec.FakeAction{
+					func() ([]byte, []byte, error) { return []byte(rt.output), nil, rt.err },
+				},
+			}
+			fexec := fakeexec.FakeExec{
+				CommandScript: []fakeexec.FakeCommandAction{
+					func(cmd string, args ...string) exec.Cmd { return fakeexec.InitFakeCmd(&fcmd, cmd, args...) },
+				},
+			}
+
+			check := ExternalCheck{Path: "/etc/kubernetes/preflight.d/check", exec: &fexec}
+			warnings, errs := check.Check()
+			if (len(warnings) > 0) != rt.expectWarnings {
+				t.Errorf("unexpected warnings:\n\texpected: %t\n\t  actual: %v", rt.expectWarnings, warnings)
+			}
+			if (len(errs) > 0) != rt.expectErrors {
+				t.Errorf("unexpected errors:\n\texpected: %t\n\t  actual: %v", rt.expectErrors, errs)
+			}
+		})
+	}
+}
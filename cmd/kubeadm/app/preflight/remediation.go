@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilsexec "k8s.io/utils/exec"
+)
+
+// Remediation is a named action that can fix a specific class of preflight failures.
+// Remediations never run by default; each one must be enabled individually by name
+// (or with the special value "all") via the --fix flag of the preflight phases.
+type Remediation struct {
+	// Name identifies the remediation and is used to enable it via --fix
+	Name string
+
+	// Description states what the remediation does to the node
+	Description string
+
+	// Apply performs the fix
+	Apply func(execer utilsexec.Interface, ports []int) error
+}
+
+// Remediations returns all known remediations
+func Remediations() []Remediation {
+	return []Remediation{
+		{
+			Name:        "load-br-netfilter",
+			Description: "load the br_netfilter kernel module",
+			Apply: func(execer utilsexec.Interface, _ []int) error {
+				return execer.Command("modprobe", "br_netfilter").Run()
+			},
+		},
+		{
+			Name:        "enable-bridge-nf-call-iptables",
+			Description: "set net.bridge.bridge-nf-call-iptables to 1",
+			Apply: func(_ utilsexec.Interface, _ []int) error {
+				return ioutil.WriteFile(bridgenf, []byte{'1'}, 0644)
+			},
+		},
+		{
+			Name:        "enable-ip-forward",
+			Description: "set net.ipv4.ip_forward to 1",
+			Apply: func(_ utilsexec.Interface, _ []int) error {
+				return ioutil.WriteFile(ipv4Forward, []byte{'1'}, 0644)
+			},
+		},
+		{
+			Name:        "disable-swap",
+			Description: "turn off all swap devices and comment out swap entries in /etc/fstab",
+			Apply: func(execer utilsexec.Interface, _ []int) error {
+				if err := execer.Command("swapoff", "-a").Run(); err != nil {
+					return errors.Wrap(err, "error running swapoff -a")
+				}
+				return commentSwapInFstab("/etc/fstab")
+			},
+		},
+		{
+			Name:        "open-firewalld-ports",
+			Description: "open the required ports in firewalld",
+			Apply: func(execer utilsexec.Interface, ports []int) error {
+				for _, port := range ports {
+					portArg := fmt.Sprintf("--add-port=%d/tcp", port)
+					if err := execer.Command("firewall-cmd", "--permanent", portArg).Run(); err != nil {
+						return errors.Wrapf(err, "error opening port %d in firewalld", port)
+					}
+				}
+				return execer.Command("firewall-cmd", "--reload").Run()
+			},
+		},
+	}
+}
+
+// RunRemediations applies the remediations enabled by name. The special name "all"
+// enables every known remediation. With dryRun only the descriptions of the enabled
+// remediations are printed.
+func RunRemediations(names sets.String, execer utilsexec.Interface, dryRun bool, ports []int) error {
+	known := sets.NewString()
+	for _, remediation := range Remediations() {
+		known.Insert(remediation.Name)
+		if !setHasItemOrAll(names, remediation.Name) {
+			continue
+		}
+		if dryRun {
+			fmt.Printf("[preflight] Would %s\n", remediation.Description)
+			continue
+		}
+		fmt.Printf("[preflight] Applying remediation %q: %s\n", remediation.Name, remediation.Description)
+		if err := remediation.Apply(execer, ports); err != nil {
+			return errors.Wrapf(err, "remediation %q failed", remediation.Name)
+		}
+	}
+
+	for name := range names {
+		if name != "all" && !known.Has(name) {
+			return errors.Errorf("unknown preflight remediation %q; known remediations are %v", name, known.List())
+		}
+	}
+	return nil
+}
+
+// commentSwapInFstab comments out every swap entry in the passed fstab file, so swap
+// stays disabled across reboots
+func commentSwapInFstab(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "error reading %q", path)
+	}
+
+	lines := strings.Split(string(b), "\n")
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && !strings.HasPrefix(fields[0], "#") && fields[2] == "swap" {
+			lines[i] = "#" + line
+		}
+	}
+
+	return ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilsexec "k8s.io/utils/exec"
+)
+
+func TestRunRemediationsUnknownName(t *testing.T) {
+	err := RunRemediations(sets.NewString("no-such-remediation"), utilsexec.New(), true, nil)
+	if err == nil {
+		t.Fatal("expected an error for an unknown remediation name, got none")
+	}
+}
+
+func TestRunRemediationsDryRun(t *testing.T) {
+	// in dry-run mode nothing must be executed, so this must succeed on any node
+	if err := RunRemediations(sets.NewString("all"), utilsexec.New(), true, []int{6443}); err != nil {
+		t.Fatalf("RunRemediations in dry-run mode failed: %v", err)
+	}
+}
+
+func TestCommentSwapInFstab(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "remediation-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	fstab := strings.Join([]string{
+		"# /etc/fstab",
+		"UUID=abcd / ext4 errors=remount-ro 0 1",
+		"/dev/sda2 none swap sw 0 0",
+		"#/dev/sda3 none swap sw 0 0",
+		"",
+	}, "\n")
+
+	path := filepath.Join(tmpdir, "fstab")
+	if err := ioutil.WriteFile(path, []byte(fstab), 0644); err != nil {
+		t.Fatalf("couldn't write the test fstab: %v", err)
+	}
+
+	if err := commentSwapInFstab(path); err != nil {
+		t.Fatalf("commentSwapInFstab failed: %v", err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("couldn't read the fstab back: %v", err)
+	}
+	lines := strings.Split(string(b), "\n")
+	if lines[1] != "UUID=abcd / ext4 errors=remount-ro 0 1" {
+		t.Errorf("the non-swap entry was modified: %q", lines[1])
+	}
+	if lines[2] != "#/dev/sda2 none swap sw 0 0" {
+		t.Errorf("the swap entry was not commented out: %q", lines[2])
+	}
+	if lines[3] != "#/dev/sda3 none swap sw 0 0" {
+		t.Errorf("the already commented entry was modified: %q", lines[3])
+	}
+}
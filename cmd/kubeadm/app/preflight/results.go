@@ -0,0 +1,88 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CheckStatusPassed indicates the check did not find any problem
+	CheckStatusPassed = "passed"
+
+	// CheckStatusWarning indicates the check produced warnings only,
+	// including errors downgraded via --ignore-preflight-errors
+	CheckStatusWarning = "warning"
+
+	// CheckStatusFailed indicates the check produced at least one error
+	CheckStatusFailed = "failed"
+)
+
+// CheckResult holds the structured outcome of a single preflight check,
+// so CI systems can gate on specific checks instead of parsing stderr
+type CheckResult struct {
+	// Name is the name of the check that produced this result
+	Name string `json:"name"`
+
+	// Status is one of "passed", "warning" or "failed"
+	Status string `json:"status"`
+
+	// Errors holds the messages of the errors the check reported
+	Errors []string `json:"errors,omitempty"`
+
+	// Warnings holds the messages of the warnings the check reported,
+	// including errors that were downgraded to warnings
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Report holds the structured results of a whole preflight check run
+type Report struct {
+	// Checks holds one result per executed check, in execution order
+	Checks []CheckResult `json:"checks"`
+}
+
+// WriteToFile stores the report as JSON in the passed file
+func (r *Report) WriteToFile(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the preflight report")
+	}
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write the preflight report to %q", path)
+	}
+	return nil
+}
+
+// newCheckResult builds the structured result of a single check from its warnings and errors
+func newCheckResult(name string, warnings, errs []error) CheckResult {
+	result := CheckResult{
+		Name:   name,
+		Status: CheckStatusPassed,
+	}
+	for _, w := range warnings {
+		result.Warnings = append(result.Warnings, w.Error())
+		result.Status = CheckStatusWarning
+	}
+	for _, e := range errs {
+		result.Errors = append(result.Errors, e.Error())
+		result.Status = CheckStatusFailed
+	}
+	return result
+}
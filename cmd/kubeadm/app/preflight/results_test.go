@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestRunChecksWithReport(t *testing.T) {
+	var tests = []struct {
+		name                  string
+		checks                []Checker
+		ignorePreflightErrors sets.String
+		expectedStatuses      []string
+		expectedError         bool
+	}{
+		{
+			name:             "passing check",
+			checks:           []Checker{preflightCheckTest{""}},
+			expectedStatuses: []string{CheckStatusPassed},
+		},
+		{
+			name:             "warning check",
+			checks:           []Checker{preflightCheckTest{"warning"}},
+			expectedStatuses: []string{CheckStatusWarning},
+		},
+		{
+			name:             "failing check",
+			checks:           []Checker{preflightCheckTest{"error"}},
+			expectedStatuses: []string{CheckStatusFailed},
+			expectedError:    true,
+		},
+		{
+			name:                  "ignored check is reported as warning",
+			checks:                []Checker{preflightCheckTest{"error"}},
+			ignorePreflightErrors: sets.NewString("preflightchecktest"),
+			expectedStatuses:      []string{CheckStatusWarning},
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			report, err := RunChecksWithReport(rt.checks, &bytes.Buffer{}, rt.ignorePreflightErrors)
+			if (err != nil) != rt.expectedError {
+				t.Errorf("unexpected error result:\n\texpected: %t\n\t  actual: %v", rt.expectedError, err)
+			}
+			if report == nil {
+				t.Fatal("expected a report, got nil")
+			}
+			if len(report.Checks) != len(rt.expectedStatuses) {
+				t.Fatalf("unexpected number of results:\n\texpected: %d\n\t  actual: %d", len(rt.expectedStatuses), len(report.Checks))
+			}
+			for i, expected := range rt.expectedStatuses {
+				if report.Checks[i].Status != expected {
+					t.Errorf("unexpected status for check %q:\n\texpected: %s\n\t  actual: %s", report.Checks[i].Name, expected, report.Checks[i].Status)
+				}
+			}
+		})
+	}
+}
+
+func TestReportWriteToFile(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "preflight-report-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	report := &Report{
+		Checks: []CheckResult{
+			{Name: "test", Status: CheckStatusPassed},
+		},
+	}
+
+	path := filepath.Join(tmpdir, "report.json")
+	if err := report.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile failed: %v", err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("couldn't read the report back: %v", err)
+	}
+	if !bytes.Contains(b, []byte(`"status": "passed"`)) {
+		t.Errorf("unexpected report contents: %s", b)
+	}
+}
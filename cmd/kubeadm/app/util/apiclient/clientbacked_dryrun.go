@@ -24,6 +24,7 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
@@ -107,6 +108,60 @@ func (clg *ClientBackedDryRunGetter) HandleListAction(action core.ListAction) (b
 	return true, newObj, err
 }
 
+// ServerSideDryRunReaction executes write actions against the backing API server as
+// server-side dry-run requests (DryRun=All), so admission webhooks and validation run
+// for real without anything being persisted - catching failures a purely client-faked
+// dry-run misses. Unknown action types fall through to the default reactors.
+func (clg *ClientBackedDryRunGetter) ServerSideDryRunReaction(action core.Action) (bool, runtime.Object, error) {
+	dryRun := []string{metav1.DryRunAll}
+	resourceClient := clg.dynamicClient.Resource(action.GetResource()).Namespace(action.GetNamespace())
+
+	switch a := action.(type) {
+	case core.CreateAction:
+		unstructuredObj, err := toUnstructured(a.GetObject())
+		if err != nil {
+			return true, nil, err
+		}
+		newObj, err := resourceClient.Create(unstructuredObj, metav1.CreateOptions{DryRun: dryRun})
+		if err != nil {
+			return true, nil, err
+		}
+		decoded, err := decodeUnstructuredIntoAPIObject(action, newObj)
+		return true, decoded, err
+	case core.UpdateAction:
+		unstructuredObj, err := toUnstructured(a.GetObject())
+		if err != nil {
+			return true, nil, err
+		}
+		newObj, err := resourceClient.Update(unstructuredObj, metav1.UpdateOptions{DryRun: dryRun})
+		if err != nil {
+			return true, nil, err
+		}
+		decoded, err := decodeUnstructuredIntoAPIObject(action, newObj)
+		return true, decoded, err
+	case core.PatchAction:
+		newObj, err := resourceClient.Patch(a.GetName(), a.GetPatchType(), a.GetPatch(), metav1.PatchOptions{DryRun: dryRun})
+		if err != nil {
+			return true, nil, err
+		}
+		decoded, err := decodeUnstructuredIntoAPIObject(action, newObj)
+		return true, decoded, err
+	case core.DeleteAction:
+		err := resourceClient.Delete(a.GetName(), &metav1.DeleteOptions{DryRun: dryRun})
+		return true, nil, err
+	}
+	return false, nil, nil
+}
+
+// toUnstructured converts a typed API object into its unstructured representation
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert the object to unstructured")
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
 // Client gets the backing clientset.Interface
 func (clg *ClientBackedDryRunGetter) Client() clientset.Interface {
 	return clg.client
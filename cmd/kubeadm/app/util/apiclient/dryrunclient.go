@@ -19,11 +19,14 @@ package apiclient
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientset "k8s.io/client-go/kubernetes"
@@ -54,6 +57,84 @@ type DryRunClientOptions struct {
 	AppendReactors  []core.Reactor
 	MarshalFunc     MarshalFunc
 	PrintGETAndLIST bool
+	// ActionLog, when set, records every action performed through the client, so the
+	// exact action plan of a dry-run can be retrieved after the run
+	ActionLog *ActionLog
+	// WriteReactionFunc, when set, handles the write verbs (create, update, patch,
+	// delete, delete-collection) instead of the default reactor that fakes success,
+	// e.g. to execute them as server-side dry-run requests against a live cluster
+	WriteReactionFunc core.ReactionFunc
+}
+
+// ActionRecord describes a single API action performed through a dry-run client
+type ActionRecord struct {
+	// Verb is the API verb of the action (create, update, patch, delete, get, list, ...)
+	Verb string `json:"verb"`
+
+	// Resource is the acted on resource, qualified by group and version
+	Resource schema.GroupVersionResource `json:"resource"`
+
+	// Namespace is the namespace of the acted on object, if any
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the acted on object, if the action carries one
+	Name string `json:"name,omitempty"`
+
+	// Patch holds the patch body of patch actions
+	Patch string `json:"patch,omitempty"`
+}
+
+// ActionLog records the actions performed through a dry-run client in order
+type ActionLog struct {
+	mutex   sync.Mutex
+	records []ActionRecord
+}
+
+// append stores a new record
+func (l *ActionLog) append(record ActionRecord) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.records = append(l.records, record)
+}
+
+// Records returns a copy of the recorded actions in execution order
+func (l *ActionLog) Records() []ActionRecord {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	records := make([]ActionRecord, len(l.records))
+	copy(records, l.records)
+	return records
+}
+
+// WriteJSON writes the recorded action plan as JSON to the passed writer
+func (l *ActionLog) WriteJSON(w io.Writer) error {
+	b, err := json.MarshalIndent(l.Records(), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the dry-run action log")
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// recordAction builds an ActionRecord from a clientset action
+func recordAction(log *ActionLog, action core.Action) {
+	record := ActionRecord{
+		Verb:      action.GetVerb(),
+		Resource:  action.GetResource(),
+		Namespace: action.GetNamespace(),
+	}
+	if namedAction, ok := action.(actionWithName); ok {
+		record.Name = namedAction.GetName()
+	}
+	if objAction, ok := action.(actionWithObject); ok && record.Name == "" {
+		if objMeta, err := meta.Accessor(objAction.GetObject()); err == nil {
+			record.Name = objMeta.GetName()
+		}
+	}
+	if patchAction, ok := action.(core.PatchAction); ok {
+		record.Patch = string(patchAction.GetPatch())
+	}
+	log.append(record)
 }
 
 // GetDefaultDryRunClientOptions returns the default DryRunClientOptions values
@@ -94,6 +175,11 @@ func NewDryRunClientWithOpts(opts DryRunClientOptions) clientset.Interface {
 	// Build a chain of reactors to act like a normal clientset; but log everything that is happening and don't change any state
 	client := fakeclientset.NewSimpleClientset()
 
+	writeReaction := successfulModificationReactorFunc
+	if opts.WriteReactionFunc != nil {
+		writeReaction = opts.WriteReactionFunc
+	}
+
 	// Build the chain of reactors. Order matters; first item here will be invoked first on match, then the second one will be evaluated, etc.
 	defaultReactorChain := []core.Reactor{
 		// Log everything that happens. Default the object if it's about to be created/updated so that the logged object is representative.
@@ -102,6 +188,9 @@ func NewDryRunClientWithOpts(opts DryRunClientOptions) clientset.Interface {
 			Resource: "*",
 			Reaction: func(action core.Action) (bool, runtime.Object, error) {
 				logDryRunAction(action, opts.Writer, opts.MarshalFunc)
+				if opts.ActionLog != nil {
+					recordAction(opts.ActionLog, action)
+				}
 
 				return false, nil, nil
 			},
@@ -160,27 +249,27 @@ func NewDryRunClientWithOpts(opts DryRunClientOptions) clientset.Interface {
 		&core.SimpleReactor{
 			Verb:     "create",
 			Resource: "*",
-			Reaction: successfulModificationReactorFunc,
+			Reaction: writeReaction,
 		},
 		&core.SimpleReactor{
 			Verb:     "update",
 			Resource: "*",
-			Reaction: successfulModificationReactorFunc,
+			Reaction: writeReaction,
 		},
 		&core.SimpleReactor{
 			Verb:     "delete",
 			Resource: "*",
-			Reaction: successfulModificationReactorFunc,
+			Reaction: writeReaction,
 		},
 		&core.SimpleReactor{
 			Verb:     "delete-collection",
 			Resource: "*",
-			Reaction: successfulModificationReactorFunc,
+			Reaction: writeReaction,
 		},
 		&core.SimpleReactor{
 			Verb:     "patch",
 			Resource: "*",
-			Reaction: successfulModificationReactorFunc,
+			Reaction: writeReaction,
 		},
 	}
 
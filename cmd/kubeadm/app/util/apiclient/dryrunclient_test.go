@@ -18,11 +18,14 @@ package apiclient
 
 import (
 	"bytes"
+	"io/ioutil"
+	"strings"
 	"testing"
 
 	"k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	core "k8s.io/client-go/testing"
 )
@@ -109,3 +112,42 @@ func TestLogDryRunAction(t *testing.T) {
 		})
 	}
 }
+
+func TestActionLogRecords(t *testing.T) {
+	log := &ActionLog{}
+	opts := GetDefaultDryRunClientOptions(NewInitDryRunGetter("test-node", "10.96.0.0/12"), ioutil.Discard)
+	opts.ActionLog = log
+	// the printed representation is irrelevant here; only the recorded actions matter
+	opts.MarshalFunc = func(obj runtime.Object, gv schema.GroupVersion) ([]byte, error) {
+		return []byte{}, nil
+	}
+	client := NewDryRunClientWithOpts(opts)
+
+	if _, err := client.CoreV1().ConfigMaps("kube-system").Create(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config", Namespace: "kube-system"},
+	}); err != nil {
+		t.Fatalf("unexpected error creating the ConfigMap: %v", err)
+	}
+	if err := client.CoreV1().Secrets("kube-system").Delete("test-secret", nil); err != nil {
+		t.Fatalf("unexpected error deleting the Secret: %v", err)
+	}
+
+	records := log.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 recorded actions, got %d: %+v", len(records), records)
+	}
+	if records[0].Verb != "create" || records[0].Name != "test-config" || records[0].Namespace != "kube-system" {
+		t.Errorf("unexpected first record: %+v", records[0])
+	}
+	if records[1].Verb != "delete" || records[1].Name != "test-secret" {
+		t.Errorf("unexpected second record: %+v", records[1])
+	}
+
+	var buf bytes.Buffer
+	if err := log.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"verb": "create"`) {
+		t.Errorf("unexpected JSON action plan: %s", buf.String())
+	}
+}
@@ -39,34 +39,57 @@ import (
 // ConfigMapMutator is a function that mutates the given ConfigMap and optionally returns an error
 type ConfigMapMutator func(*v1.ConfigMap) error
 
-// TODO: We should invent a dynamic mechanism for this using the dynamic client instead of hard-coding these functions per-type
+// apiCallRetryBackoff is the backoff all idempotency helpers retry conflicting or
+// transient API calls with. It's a variable so callers (e.g. a Timeouts configuration
+// section) can tune it in one place.
+var apiCallRetryBackoff = wait.Backoff{
+	Steps:    20,
+	Duration: 500 * time.Millisecond,
+	Factor:   1.0,
+	Jitter:   0.1,
+}
 
-// CreateOrUpdateConfigMap creates a ConfigMap if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
-func CreateOrUpdateConfigMap(client clientset.Interface, cm *v1.ConfigMap) error {
-	if _, err := client.CoreV1().ConfigMaps(cm.ObjectMeta.Namespace).Create(cm); err != nil {
+// SetAPICallRetryBackoff overrides the backoff used by the idempotency helpers
+func SetAPICallRetryBackoff(backoff wait.Backoff) {
+	apiCallRetryBackoff = backoff
+}
+
+// createOrUpdate implements the create-then-update flow shared by all CreateOrUpdate
+// helpers: the create error is inspected for AlreadyExists, and the update is retried
+// on conflicts with the shared backoff, so all resource kinds behave consistently
+func createOrUpdate(kind string, create func() error, update func() error) error {
+	if err := create(); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrap(err, "unable to create ConfigMap")
+			return errors.Wrapf(err, "unable to create %s", kind)
 		}
 
-		if _, err := client.CoreV1().ConfigMaps(cm.ObjectMeta.Namespace).Update(cm); err != nil {
-			return errors.Wrap(err, "unable to update ConfigMap")
+		if err := clientsetretry.RetryOnConflict(apiCallRetryBackoff, update); err != nil {
+			return errors.Wrapf(err, "unable to update %s", kind)
 		}
 	}
 	return nil
 }
 
+// TODO: We should invent a dynamic mechanism for this using the dynamic client instead of hard-coding these functions per-type
+
+// CreateOrUpdateConfigMap creates a ConfigMap if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
+func CreateOrUpdateConfigMap(client clientset.Interface, cm *v1.ConfigMap) error {
+	return createOrUpdate("ConfigMap", func() error {
+		_, err := client.CoreV1().ConfigMaps(cm.ObjectMeta.Namespace).Create(cm)
+		return err
+	}, func() error {
+		_, err := client.CoreV1().ConfigMaps(cm.ObjectMeta.Namespace).Update(cm)
+		return err
+	})
+}
+
 // CreateOrMutateConfigMap tries to create the ConfigMap provided as cm. If the resource exists already, the latest version will be fetched from
 // the cluster and mutator callback will be called on it, then an Update of the mutated ConfigMap will be performed. This function is resilient
 // to conflicts, and a retry will be issued if the ConfigMap was modified on the server between the refresh and the update (while the mutation was
 // taking place)
 func CreateOrMutateConfigMap(client clientset.Interface, cm *v1.ConfigMap, mutator ConfigMapMutator) error {
 	var lastError error
-	err := wait.ExponentialBackoff(wait.Backoff{
-		Steps:    20,
-		Duration: 500 * time.Millisecond,
-		Factor:   1.0,
-		Jitter:   0.1,
-	}, func() (bool, error) {
+	err := wait.ExponentialBackoff(apiCallRetryBackoff, func() (bool, error) {
 		if _, err := client.CoreV1().ConfigMaps(cm.ObjectMeta.Namespace).Create(cm); err != nil {
 			lastError = err
 			if apierrors.IsAlreadyExists(err) {
@@ -88,12 +111,7 @@ func CreateOrMutateConfigMap(client clientset.Interface, cm *v1.ConfigMap, mutat
 // to conflicts, and a retry will be issued if the ConfigMap was modified on the server between the refresh and the update (while the mutation was
 // taking place).
 func MutateConfigMap(client clientset.Interface, meta metav1.ObjectMeta, mutator ConfigMapMutator) error {
-	return clientsetretry.RetryOnConflict(wait.Backoff{
-		Steps:    20,
-		Duration: 500 * time.Millisecond,
-		Factor:   1.0,
-		Jitter:   0.1,
-	}, func() error {
+	return clientsetretry.RetryOnConflict(apiCallRetryBackoff, func() error {
 		configMap, err := client.CoreV1().ConfigMaps(meta.Namespace).Get(meta.Name, metav1.GetOptions{})
 		if err != nil {
 			return err
@@ -123,16 +141,13 @@ func CreateOrRetainConfigMap(client clientset.Interface, cm *v1.ConfigMap, confi
 
 // CreateOrUpdateSecret creates a Secret if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
 func CreateOrUpdateSecret(client clientset.Interface, secret *v1.Secret) error {
-	if _, err := client.CoreV1().Secrets(secret.ObjectMeta.Namespace).Create(secret); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrap(err, "unable to create secret")
-		}
-
-		if _, err := client.CoreV1().Secrets(secret.ObjectMeta.Namespace).Update(secret); err != nil {
-			return errors.Wrap(err, "unable to update secret")
-		}
-	}
-	return nil
+	return createOrUpdate("secret", func() error {
+		_, err := client.CoreV1().Secrets(secret.ObjectMeta.Namespace).Create(secret)
+		return err
+	}, func() error {
+		_, err := client.CoreV1().Secrets(secret.ObjectMeta.Namespace).Update(secret)
+		return err
+	})
 }
 
 // CreateOrUpdateServiceAccount creates a ServiceAccount if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
@@ -149,16 +164,13 @@ func CreateOrUpdateServiceAccount(client clientset.Interface, sa *v1.ServiceAcco
 
 // CreateOrUpdateDeployment creates a Deployment if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
 func CreateOrUpdateDeployment(client clientset.Interface, deploy *apps.Deployment) error {
-	if _, err := client.AppsV1().Deployments(deploy.ObjectMeta.Namespace).Create(deploy); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrap(err, "unable to create deployment")
-		}
-
-		if _, err := client.AppsV1().Deployments(deploy.ObjectMeta.Namespace).Update(deploy); err != nil {
-			return errors.Wrap(err, "unable to update deployment")
-		}
-	}
-	return nil
+	return createOrUpdate("deployment", func() error {
+		_, err := client.AppsV1().Deployments(deploy.ObjectMeta.Namespace).Create(deploy)
+		return err
+	}, func() error {
+		_, err := client.AppsV1().Deployments(deploy.ObjectMeta.Namespace).Update(deploy)
+		return err
+	})
 }
 
 // CreateOrRetainDeployment creates a Deployment if the target resource doesn't exist. If the resource exists already, this function will retain the resource instead.
@@ -178,16 +190,13 @@ func CreateOrRetainDeployment(client clientset.Interface, deploy *apps.Deploymen
 
 // CreateOrUpdateDaemonSet creates a DaemonSet if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
 func CreateOrUpdateDaemonSet(client clientset.Interface, ds *apps.DaemonSet) error {
-	if _, err := client.AppsV1().DaemonSets(ds.ObjectMeta.Namespace).Create(ds); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrap(err, "unable to create daemonset")
-		}
-
-		if _, err := client.AppsV1().DaemonSets(ds.ObjectMeta.Namespace).Update(ds); err != nil {
-			return errors.Wrap(err, "unable to update daemonset")
-		}
-	}
-	return nil
+	return createOrUpdate("daemonset", func() error {
+		_, err := client.AppsV1().DaemonSets(ds.ObjectMeta.Namespace).Create(ds)
+		return err
+	}, func() error {
+		_, err := client.AppsV1().DaemonSets(ds.ObjectMeta.Namespace).Update(ds)
+		return err
+	})
 }
 
 // DeleteDaemonSetForeground deletes the specified DaemonSet in foreground mode; i.e. it blocks until/makes sure all the managed Pods are deleted
@@ -210,58 +219,46 @@ func DeleteDeploymentForeground(client clientset.Interface, namespace, name stri
 
 // CreateOrUpdateRole creates a Role if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
 func CreateOrUpdateRole(client clientset.Interface, role *rbac.Role) error {
-	if _, err := client.RbacV1().Roles(role.ObjectMeta.Namespace).Create(role); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrap(err, "unable to create RBAC role")
-		}
-
-		if _, err := client.RbacV1().Roles(role.ObjectMeta.Namespace).Update(role); err != nil {
-			return errors.Wrap(err, "unable to update RBAC role")
-		}
-	}
-	return nil
+	return createOrUpdate("RBAC role", func() error {
+		_, err := client.RbacV1().Roles(role.ObjectMeta.Namespace).Create(role)
+		return err
+	}, func() error {
+		_, err := client.RbacV1().Roles(role.ObjectMeta.Namespace).Update(role)
+		return err
+	})
 }
 
 // CreateOrUpdateRoleBinding creates a RoleBinding if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
 func CreateOrUpdateRoleBinding(client clientset.Interface, roleBinding *rbac.RoleBinding) error {
-	if _, err := client.RbacV1().RoleBindings(roleBinding.ObjectMeta.Namespace).Create(roleBinding); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrap(err, "unable to create RBAC rolebinding")
-		}
-
-		if _, err := client.RbacV1().RoleBindings(roleBinding.ObjectMeta.Namespace).Update(roleBinding); err != nil {
-			return errors.Wrap(err, "unable to update RBAC rolebinding")
-		}
-	}
-	return nil
+	return createOrUpdate("RBAC rolebinding", func() error {
+		_, err := client.RbacV1().RoleBindings(roleBinding.ObjectMeta.Namespace).Create(roleBinding)
+		return err
+	}, func() error {
+		_, err := client.RbacV1().RoleBindings(roleBinding.ObjectMeta.Namespace).Update(roleBinding)
+		return err
+	})
 }
 
 // CreateOrUpdateClusterRole creates a ClusterRole if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
 func CreateOrUpdateClusterRole(client clientset.Interface, clusterRole *rbac.ClusterRole) error {
-	if _, err := client.RbacV1().ClusterRoles().Create(clusterRole); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrap(err, "unable to create RBAC clusterrole")
-		}
-
-		if _, err := client.RbacV1().ClusterRoles().Update(clusterRole); err != nil {
-			return errors.Wrap(err, "unable to update RBAC clusterrole")
-		}
-	}
-	return nil
+	return createOrUpdate("RBAC clusterrole", func() error {
+		_, err := client.RbacV1().ClusterRoles().Create(clusterRole)
+		return err
+	}, func() error {
+		_, err := client.RbacV1().ClusterRoles().Update(clusterRole)
+		return err
+	})
 }
 
 // CreateOrUpdateClusterRoleBinding creates a ClusterRoleBinding if the target resource doesn't exist. If the resource exists already, this function will update the resource instead.
 func CreateOrUpdateClusterRoleBinding(client clientset.Interface, clusterRoleBinding *rbac.ClusterRoleBinding) error {
-	if _, err := client.RbacV1().ClusterRoleBindings().Create(clusterRoleBinding); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrap(err, "unable to create RBAC clusterrolebinding")
-		}
-
-		if _, err := client.RbacV1().ClusterRoleBindings().Update(clusterRoleBinding); err != nil {
-			return errors.Wrap(err, "unable to update RBAC clusterrolebinding")
-		}
-	}
-	return nil
+	return createOrUpdate("RBAC clusterrolebinding", func() error {
+		_, err := client.RbacV1().ClusterRoleBindings().Create(clusterRoleBinding)
+		return err
+	}, func() error {
+		_, err := client.RbacV1().ClusterRoleBindings().Update(clusterRoleBinding)
+		return err
+	})
 }
 
 // PatchNodeOnce executes patchFn on the node object found by the node name.
@@ -27,10 +27,13 @@ import (
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	netutil "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	clientset "k8s.io/client-go/kubernetes"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	kubeadmerrors "k8s.io/kubernetes/cmd/kubeadm/app/util/errors"
 )
 
 // Waiter is an interface for waiting for criteria in Kubernetes to happen
@@ -90,45 +93,118 @@ func (w *KubeWaiter) WaitForAPI() error {
 // WaitForPodsWithLabel will lookup pods with the given label and wait until they are all
 // reporting status as running.
 func (w *KubeWaiter) WaitForPodsWithLabel(kvLabel string) error {
+	deadline := time.Now().Add(w.timeout)
 
-	lastKnownPodNumber := -1
-	return wait.PollImmediate(kubeadmconstants.APICallRetryInterval, w.timeout, func() (bool, error) {
+	for {
+		if time.Now().After(deadline) {
+			return kubeadmerrors.NewTimeout("wait-control-plane", errors.Errorf("timed out waiting for the Pods with label selector %q to become Running", kvLabel))
+		}
+
+		// list the current state first; the watch below only delivers changes
 		listOpts := metav1.ListOptions{LabelSelector: kvLabel}
 		pods, err := w.client.CoreV1().Pods(metav1.NamespaceSystem).List(listOpts)
 		if err != nil {
 			fmt.Fprintf(w.writer, "[apiclient] Error getting Pods with label selector %q [%v]\n", kvLabel, err)
-			return false, nil
+			w.sleepWithJitter()
+			continue
 		}
 
-		if lastKnownPodNumber != len(pods.Items) {
-			fmt.Fprintf(w.writer, "[apiclient] Found %d Pods for label selector %s\n", len(pods.Items), kvLabel)
-			lastKnownPodNumber = len(pods.Items)
+		fmt.Fprintf(w.writer, "[apiclient] Found %d Pods for label selector %s\n", len(pods.Items), kvLabel)
+		if allPodsRunning(pods.Items) {
+			return nil
 		}
 
-		if len(pods.Items) == 0 {
-			return false, nil
+		// watch for changes instead of polling, so a starting API server is not hammered
+		// with list requests; on watch failures fall back to re-listing after a jittered wait
+		listOpts.ResourceVersion = pods.ResourceVersion
+		listOpts.TimeoutSeconds = timeoutSecondsUntil(deadline)
+		watcher, err := w.client.CoreV1().Pods(metav1.NamespaceSystem).Watch(listOpts)
+		if err != nil {
+			fmt.Fprintf(w.writer, "[apiclient] Error watching Pods with label selector %q [%v]\n", kvLabel, err)
+			w.sleepWithJitter()
+			continue
 		}
 
-		for _, pod := range pods.Items {
-			if pod.Status.Phase != v1.PodRunning {
-				return false, nil
+		for range watcher.ResultChan() {
+			pods, err := w.client.CoreV1().Pods(metav1.NamespaceSystem).List(metav1.ListOptions{LabelSelector: kvLabel})
+			if err != nil {
+				break
+			}
+			if len(pods.Items) > 0 && allPodsRunning(pods.Items) {
+				watcher.Stop()
+				return nil
 			}
 		}
+		watcher.Stop()
+		w.sleepWithJitter()
+	}
+}
 
-		return true, nil
-	})
+// allPodsRunning returns true when every Pod of a non-empty list is Running
+func allPodsRunning(pods []v1.Pod) bool {
+	if len(pods) == 0 {
+		return false
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning {
+			return false
+		}
+	}
+	return true
+}
+
+// sleepWithJitter waits the API call retry interval plus a random jitter, so reconnecting
+// clients do not synchronize against a recovering API server
+func (w *KubeWaiter) sleepWithJitter() {
+	time.Sleep(wait.Jitter(kubeadmconstants.APICallRetryInterval, 1.0))
+}
+
+// timeoutSecondsUntil converts the time left until the deadline into the pointer form
+// the watch API expects
+func timeoutSecondsUntil(deadline time.Time) *int64 {
+	seconds := int64(time.Until(deadline).Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	return &seconds
 }
 
 // WaitForPodToDisappear blocks until it timeouts or gets a "NotFound" response from the API Server when getting the Static Pod in question
 func (w *KubeWaiter) WaitForPodToDisappear(podName string) error {
-	return wait.PollImmediate(kubeadmconstants.APICallRetryInterval, w.timeout, func() (bool, error) {
+	deadline := time.Now().Add(w.timeout)
+
+	for {
+		if time.Now().After(deadline) {
+			return kubeadmerrors.NewTimeout("wait-control-plane", errors.Errorf("timed out waiting for the Pod %q to be removed", podName))
+		}
+
 		_, err := w.client.CoreV1().Pods(metav1.NamespaceSystem).Get(podName, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) {
 			fmt.Printf("[apiclient] The old Pod %q is now removed (which is desired)\n", podName)
-			return true, nil
+			return nil
 		}
-		return false, nil
-	})
+
+		// watch the pod for its deletion instead of polling for it
+		listOpts := metav1.ListOptions{
+			FieldSelector:  fields.OneTermEqualSelector("metadata.name", podName).String(),
+			TimeoutSeconds: timeoutSecondsUntil(deadline),
+		}
+		watcher, err := w.client.CoreV1().Pods(metav1.NamespaceSystem).Watch(listOpts)
+		if err != nil {
+			w.sleepWithJitter()
+			continue
+		}
+
+		for event := range watcher.ResultChan() {
+			if event.Type == watch.Deleted {
+				watcher.Stop()
+				fmt.Printf("[apiclient] The old Pod %q is now removed (which is desired)\n", podName)
+				return nil
+			}
+		}
+		watcher.Stop()
+		w.sleepWithJitter()
+	}
 }
 
 // WaitForHealthyKubelet blocks until the kubelet /healthz endpoint returns 'ok'
@@ -153,13 +229,22 @@ func (w *KubeWaiter) WaitForHealthyKubelet(initalTimeout time.Duration, healthzE
 	}, 5) // a failureThreshold of five means waiting for a total of 155 seconds
 }
 
+// kubeletHealthCheckTimeout is the initial timeout of the kubelet /healthz wait; it can
+// be overridden through the Timeouts section of the ClusterConfiguration
+var kubeletHealthCheckTimeout = 40 * time.Second
+
+// SetKubeletHealthCheckTimeout overrides the initial timeout of the kubelet /healthz wait
+func SetKubeletHealthCheckTimeout(timeout time.Duration) {
+	kubeletHealthCheckTimeout = timeout
+}
+
 // WaitForKubeletAndFunc waits primarily for the function f to execute, even though it might take some time. If that takes a long time, and the kubelet
 // /healthz continuously are unhealthy, kubeadm will error out after a period of exponential backoff
 func (w *KubeWaiter) WaitForKubeletAndFunc(f func() error) error {
 	errorChan := make(chan error, 1)
 
 	go func(errC chan error, waiter Waiter) {
-		if err := waiter.WaitForHealthyKubelet(40*time.Second, fmt.Sprintf("http://localhost:%d/healthz", kubeadmconstants.KubeletHealthzPort)); err != nil {
+		if err := waiter.WaitForHealthyKubelet(kubeletHealthCheckTimeout, fmt.Sprintf("http://localhost:%d/healthz", kubeadmconstants.KubeletHealthzPort)); err != nil {
 			errC <- err
 		}
 	}(errorChan, w)
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 )
 
 // GetProxyEnvVars builds a list of environment variables in order to use the right proxy
@@ -41,3 +42,24 @@ func GetProxyEnvVars() []v1.EnvVar {
 	}
 	return envs
 }
+
+// GetProxyEnvVarsFromConfig returns the proxy environment declared in the passed
+// configuration, falling back to the ambient *_proxy environment variables when no
+// configuration is declared
+func GetProxyEnvVarsFromConfig(proxy *kubeadmapi.HTTPProxyConfiguration) []v1.EnvVar {
+	if proxy == nil {
+		return GetProxyEnvVars()
+	}
+
+	envs := []v1.EnvVar{}
+	if proxy.HTTPProxy != "" {
+		envs = append(envs, v1.EnvVar{Name: "HTTP_PROXY", Value: proxy.HTTPProxy})
+	}
+	if proxy.HTTPSProxy != "" {
+		envs = append(envs, v1.EnvVar{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy})
+	}
+	if len(proxy.NoProxy) > 0 {
+		envs = append(envs, v1.EnvVar{Name: "NO_PROXY", Value: strings.Join(proxy.NoProxy, ",")})
+	}
+	return envs
+}
@@ -33,6 +33,13 @@ const (
 	PreFlightExitCode = 2
 	// ValidationExitCode defines the exit code validation checks
 	ValidationExitCode = 3
+	// TimeoutExitCode defines the exit code for timeouts, e.g. waiting for the control plane
+	TimeoutExitCode = 4
+	// EtcdExitCode defines the exit code for etcd related failures
+	EtcdExitCode = 5
+	// PartialUpgradeExitCode defines the exit code for upgrades that failed midway and
+	// may have left the cluster in a mixed version state
+	PartialUpgradeExitCode = 6
 )
 
 // fatal prints the message if set and then exits.
@@ -63,6 +70,25 @@ type preflightError interface {
 	Preflight() bool
 }
 
+// The following interfaces classify errors into the documented exit code taxonomy.
+// They are defined here (instead of importing the packages producing the errors) to
+// avoid import cycles; error types implement them via marker methods.
+
+// timeoutError marks errors caused by a wait that exceeded its deadline
+type timeoutError interface {
+	IsTimeoutError() bool
+}
+
+// etcdError marks errors originating from etcd operations
+type etcdError interface {
+	IsEtcdError() bool
+}
+
+// partialUpgradeError marks errors of upgrades that failed after changing cluster state
+type partialUpgradeError interface {
+	IsPartialUpgradeError() bool
+}
+
 // checkErr formats a given error as a string and calls the passed handleErr
 // func with that string and an exit code.
 func checkErr(err error, handleErr func(string, int)) {
@@ -85,17 +111,40 @@ func checkErr(err error, handleErr func(string, int)) {
 		}
 	}
 
-	switch err.(type) {
-	case nil:
+	if err == nil {
 		return
-	case preflightError:
-		handleErr(msg, PreFlightExitCode)
-	case errorsutil.Aggregate:
-		handleErr(msg, ValidationExitCode)
+	}
+	handleErr(msg, exitCode(err))
+}
 
-	default:
-		handleErr(msg, DefaultErrorExitCode)
+// exitCode classifies an error into the exit code taxonomy. The whole error chain is
+// inspected - following both the standard Unwrap convention and the Cause convention of
+// github.com/pkg/errors - since phase errors reach this point wrapped by the workflow
+// runner and intermediate errors.Wrap calls.
+func exitCode(err error) int {
+	for e := err; e != nil; {
+		switch e.(type) {
+		case preflightError:
+			return PreFlightExitCode
+		case errorsutil.Aggregate:
+			return ValidationExitCode
+		case timeoutError:
+			return TimeoutExitCode
+		case etcdError:
+			return EtcdExitCode
+		case partialUpgradeError:
+			return PartialUpgradeExitCode
+		}
+		switch v := e.(type) {
+		case interface{ Unwrap() error }:
+			e = v.Unwrap()
+		case interface{ Cause() error }:
+			e = v.Cause()
+		default:
+			e = nil
+		}
 	}
+	return DefaultErrorExitCode
 }
 
 // FormatErrMsg returns a human-readable string describing the slice of errors passed to the function
@@ -17,8 +17,11 @@ limitations under the License.
 package util
 
 import (
-	"github.com/pkg/errors"
 	"testing"
+
+	"github.com/pkg/errors"
+
+	kubeadmerrors "k8s.io/kubernetes/cmd/kubeadm/app/util/errors"
 )
 
 type pferror struct{}
@@ -39,6 +42,9 @@ func TestCheckErr(t *testing.T) {
 		{"error is nil", nil, 0},
 		{"empty error", errors.New(""), DefaultErrorExitCode},
 		{"preflight error", &pferror{}, PreFlightExitCode},
+		{"timeout error", kubeadmerrors.NewTimeout("wait-control-plane", errors.New("timed out")), TimeoutExitCode},
+		{"wrapped timeout error", errors.Wrap(kubeadmerrors.NewTimeout("wait-control-plane", errors.New("timed out")), "error execution phase wait-control-plane"), TimeoutExitCode},
+		{"wrapped etcd error", errors.Wrap(kubeadmerrors.NewEtcd("https://127.0.0.1:2379", errors.New("sync failed")), "couldn't complete the static pod upgrade"), EtcdExitCode},
 	}
 
 	for _, rt := range tests {
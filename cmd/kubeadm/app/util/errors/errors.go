@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package errors defines the typed error kinds of kubeadm. The types carry structured
+// fields (the phase and resource the error relates to), support errors.Is/As through
+// Unwrap and implement the marker methods hooking them into the documented exit code
+// taxonomy, so callers and tests can program against failure categories instead of
+// matching error strings.
+package errors
+
+import (
+	"fmt"
+)
+
+// base carries the structured fields shared by all typed kubeadm errors
+type base struct {
+	// Phase is the kubeadm phase the error occurred in, if known
+	Phase string
+
+	// Resource is the object or component the error relates to, if any
+	Resource string
+
+	// Err is the underlying error
+	Err error
+}
+
+// Error renders the structured fields around the underlying error
+func (b *base) Error() string {
+	msg := b.Err.Error()
+	if b.Resource != "" {
+		msg = fmt.Sprintf("%s (resource: %s)", msg, b.Resource)
+	}
+	if b.Phase != "" {
+		msg = fmt.Sprintf("[%s] %s", b.Phase, msg)
+	}
+	return msg
+}
+
+// Unwrap makes the typed errors transparent to errors.Is and errors.As
+func (b *base) Unwrap() error {
+	return b.Err
+}
+
+// TimeoutError marks a wait that exceeded its deadline
+type TimeoutError struct {
+	base
+}
+
+// IsTimeoutError hooks TimeoutError into the exit code taxonomy
+func (*TimeoutError) IsTimeoutError() bool { return true }
+
+// NewTimeout wraps err as a TimeoutError occurring in the given phase
+func NewTimeout(phase string, err error) error {
+	return &TimeoutError{base{Phase: phase, Err: err}}
+}
+
+// EtcdError marks an error originating from an etcd operation
+type EtcdError struct {
+	base
+}
+
+// IsEtcdError hooks EtcdError into the exit code taxonomy
+func (*EtcdError) IsEtcdError() bool { return true }
+
+// NewEtcd wraps err as an EtcdError relating to the given resource (e.g. an endpoint)
+func NewEtcd(resource string, err error) error {
+	return &EtcdError{base{Resource: resource, Err: err}}
+}
+
+// PartialUpgradeError marks an upgrade that failed after changing cluster state
+type PartialUpgradeError struct {
+	base
+}
+
+// IsPartialUpgradeError hooks PartialUpgradeError into the exit code taxonomy
+func (*PartialUpgradeError) IsPartialUpgradeError() bool { return true }
+
+// NewPartialUpgrade wraps err as a PartialUpgradeError occurring in the given phase
+func NewPartialUpgrade(phase string, err error) error {
+	return &PartialUpgradeError{base{Phase: phase, Err: err}}
+}
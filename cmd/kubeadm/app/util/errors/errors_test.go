@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestTypedErrors(t *testing.T) {
+	underlying := errors.New("it broke")
+
+	err := NewTimeout("wait-control-plane", underlying)
+	if !strings.Contains(err.Error(), "[wait-control-plane] it broke") {
+		t.Errorf("unexpected message: %q", err.Error())
+	}
+
+	// errors.As must find the typed error
+	timeoutErr := &TimeoutError{}
+	if !stderrors.As(err, &timeoutErr) {
+		t.Error("errors.As did not match the TimeoutError")
+	}
+
+	// errors.Is must see through to the underlying error
+	if !stderrors.Is(err, underlying) {
+		t.Error("errors.Is did not unwrap to the underlying error")
+	}
+
+	etcdErr := NewEtcd("https://127.0.0.1:2379", underlying)
+	if !strings.Contains(etcdErr.Error(), "resource: https://127.0.0.1:2379") {
+		t.Errorf("unexpected message: %q", etcdErr.Error())
+	}
+	asEtcd := &EtcdError{}
+	if !stderrors.As(etcdErr, &asEtcd) {
+		t.Error("errors.As did not match the EtcdError")
+	}
+	if stderrors.As(etcdErr, &timeoutErr) {
+		t.Error("errors.As matched the wrong typed error")
+	}
+}
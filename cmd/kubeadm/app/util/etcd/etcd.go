@@ -36,9 +36,17 @@ import (
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	kubeadmerrors "k8s.io/kubernetes/cmd/kubeadm/app/util/errors"
 )
 
-const etcdTimeout = 2 * time.Second
+// etcdTimeout bounds a single request to the etcd cluster. It's a variable so it can be
+// configured through the Timeouts section of the ClusterConfiguration.
+var etcdTimeout = 2 * time.Second
+
+// SetRequestTimeout overrides the timeout of a single etcd request
+func SetRequestTimeout(timeout time.Duration) {
+	etcdTimeout = timeout
+}
 
 // Exponential backoff for etcd operations
 var etcdBackoff = wait.Backoff{
@@ -120,7 +128,7 @@ func NewFromCluster(client clientset.Interface, certificatesDir string) (*Client
 	// synchronizes client's endpoints with the known endpoints from the etcd membership.
 	err = etcdClient.Sync()
 	if err != nil {
-		return nil, errors.Wrap(err, "error syncing endpoints with etc")
+		return nil, kubeadmerrors.NewEtcd(strings.Join(etcdClient.Endpoints, ","), errors.Wrap(err, "error syncing endpoints with etcd"))
 	}
 	klog.V(1).Infof("update etcd endpoints: %s", strings.Join(etcdClient.Endpoints, ","))
 
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/clientv3"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/version"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+// EndpointStatus holds the per-endpoint result of an external etcd validation
+type EndpointStatus struct {
+	// Endpoint is the validated client URL
+	Endpoint string `json:"endpoint"`
+
+	// Version is the etcd server version reported by the endpoint
+	Version string `json:"version,omitempty"`
+
+	// ClusterID is the ID of the etcd cluster the endpoint belongs to, as a hex string
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// Error holds the problem found with this endpoint, empty when the endpoint is healthy
+	Error string `json:"error,omitempty"`
+}
+
+// ValidateExternalEtcd verifies that every endpoint of the external etcd configuration is
+// reachable with the provided CA/cert/key, serves a supported version and that all
+// endpoints report the same cluster ID. The per-endpoint results are returned also when
+// validation fails, so callers can surface structured diagnostics.
+func ValidateExternalEtcd(cfg *kubeadmapi.ExternalEtcd) ([]EndpointStatus, error) {
+	client, err := New(cfg.Endpoints, cfg.CAFile, cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build an etcd client from the external etcd configuration")
+	}
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   client.Endpoints,
+		DialTimeout: dialTimeout,
+		DialOptions: []grpc.DialOption{
+			grpc.WithBlock(), // block until the underlying connection is up
+		},
+		TLS: client.TLS,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to the external etcd cluster; verify the endpoints and the CA/cert/key files")
+	}
+	defer cli.Close()
+
+	minVersion := version.MustParseSemantic(constants.MinExternalEtcdVersion)
+
+	statuses := []EndpointStatus{}
+	clusterIDs := map[string]bool{}
+	failed := false
+	for _, endpoint := range cfg.Endpoints {
+		status := EndpointStatus{Endpoint: endpoint}
+
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		resp, err := cli.Status(ctx, endpoint)
+		cancel()
+		if err != nil {
+			status.Error = fmt.Sprintf("the endpoint is not reachable with the provided credentials: %v", err)
+			failed = true
+			statuses = append(statuses, status)
+			continue
+		}
+
+		status.Version = resp.Version
+		status.ClusterID = fmt.Sprintf("%x", resp.Header.ClusterId)
+		clusterIDs[status.ClusterID] = true
+
+		etcdVersion, err := version.ParseSemantic(resp.Version)
+		if err != nil {
+			status.Error = fmt.Sprintf("cannot parse the reported etcd version %q: %v", resp.Version, err)
+			failed = true
+		} else if etcdVersion.LessThan(minVersion) {
+			status.Error = fmt.Sprintf("the reported etcd version %q is older than the minimum supported version %q", resp.Version, constants.MinExternalEtcdVersion)
+			failed = true
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	if len(clusterIDs) > 1 {
+		return statuses, errors.Errorf("the external etcd endpoints do not belong to the same cluster; %d different cluster IDs reported", len(clusterIDs))
+	}
+	if failed {
+		return statuses, errors.New("one or more external etcd endpoints failed validation")
+	}
+	return statuses, nil
+}
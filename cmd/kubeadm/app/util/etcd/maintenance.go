@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/clientv3"
+	"google.golang.org/grpc"
+	"k8s.io/klog"
+)
+
+// maintenanceTimeout bounds a single compaction or defragmentation request. Defragmenting
+// a large database can take a while, so this is considerably larger than etcdTimeout.
+const maintenanceTimeout = 2 * dialTimeout
+
+// CompactAndDefragment compacts the etcd key space up to the current revision and then
+// defragments every member whose database is at least threshold bytes large, one member
+// at a time with a cluster health gate between members. A threshold of zero defragments
+// unconditionally.
+func (c *Client) CompactAndDefragment(threshold int64) error {
+	// health gate: never start maintenance on an unhealthy cluster
+	if err := c.CheckClusterHealth(); err != nil {
+		return errors.Wrap(err, "refusing to start etcd maintenance on an unhealthy cluster")
+	}
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   c.Endpoints,
+		DialTimeout: dialTimeout,
+		DialOptions: []grpc.DialOption{
+			grpc.WithBlock(), // block until the underlying connection is up
+		},
+		TLS: c.TLS,
+	})
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	// compact the key space up to the current revision
+	ctx, cancel := context.WithTimeout(context.Background(), maintenanceTimeout)
+	status, err := cli.Status(ctx, c.Endpoints[0])
+	cancel()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the status of endpoint %s", c.Endpoints[0])
+	}
+
+	revision := status.Header.Revision
+	ctx, cancel = context.WithTimeout(context.Background(), maintenanceTimeout)
+	_, err = cli.Compact(ctx, revision, clientv3.WithCompactPhysical())
+	cancel()
+	if err != nil {
+		// a previous maintenance run may already have compacted up to this revision
+		klog.V(1).Infof("[etcd] compaction up to revision %d failed (possibly already compacted): %v", revision, err)
+	} else {
+		fmt.Printf("[etcd] Compacted the key space up to revision %d\n", revision)
+	}
+
+	// defragment the members one by one, gated on cluster health in between
+	for _, endpoint := range c.Endpoints {
+		ctx, cancel := context.WithTimeout(context.Background(), maintenanceTimeout)
+		status, err := cli.Status(ctx, endpoint)
+		cancel()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the status of endpoint %s", endpoint)
+		}
+
+		if status.DbSize < threshold {
+			klog.V(1).Infof("[etcd] skipping defragmentation of endpoint %s: database size %d is below the threshold %d", endpoint, status.DbSize, threshold)
+			continue
+		}
+
+		fmt.Printf("[etcd] Defragmenting endpoint %s (database size: %d bytes)\n", endpoint, status.DbSize)
+		ctx, cancel = context.WithTimeout(context.Background(), maintenanceTimeout)
+		_, err = cli.Defragment(ctx, endpoint)
+		cancel()
+		if err != nil {
+			return errors.Wrapf(err, "failed to defragment endpoint %s", endpoint)
+		}
+
+		if err := c.CheckClusterHealth(); err != nil {
+			return errors.Wrapf(err, "the cluster became unhealthy after defragmenting endpoint %s; stopping maintenance", endpoint)
+		}
+	}
+	return nil
+}
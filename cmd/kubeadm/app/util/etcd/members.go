@@ -0,0 +1,150 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+
+	"go.etcd.io/etcd/clientv3"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+// MemberInfo describes an etcd cluster member, including the fields needed to repair a
+// broken quorum (member ID, learner state)
+type MemberInfo struct {
+	// ID is the unique identifier of the member
+	ID uint64 `json:"id"`
+
+	// Name is the human readable name of the member
+	Name string `json:"name"`
+
+	// PeerURLs holds the peer addresses of the member
+	PeerURLs []string `json:"peerURLs"`
+
+	// ClientURLs holds the client addresses of the member
+	ClientURLs []string `json:"clientURLs"`
+
+	// IsLearner indicates whether the member is a raft learner that was not promoted yet
+	IsLearner bool `json:"isLearner"`
+}
+
+// ListMemberInfos returns the members of the etcd cluster
+func (c *Client) ListMemberInfos() ([]MemberInfo, error) {
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   c.Endpoints,
+		DialTimeout: dialTimeout,
+		DialOptions: []grpc.DialOption{
+			grpc.WithBlock(), // block until the underlying connection is up
+		},
+		TLS: c.TLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	var lastError error
+	var resp *clientv3.MemberListResponse
+	err = wait.ExponentialBackoff(etcdBackoff, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), etcdTimeout)
+		resp, err = cli.MemberList(ctx)
+		cancel()
+		if err == nil {
+			return true, nil
+		}
+		klog.V(5).Infof("Failed to list etcd members: %v", err)
+		lastError = err
+		return false, nil
+	})
+	if err != nil {
+		return nil, lastError
+	}
+
+	members := []MemberInfo{}
+	for _, m := range resp.Members {
+		members = append(members, MemberInfo{
+			ID:         m.ID,
+			Name:       m.Name,
+			PeerURLs:   m.PeerURLs,
+			ClientURLs: m.ClientURLs,
+			IsLearner:  m.IsLearner,
+		})
+	}
+	return members, nil
+}
+
+// PromoteMember promotes a raft learner member to a voting member
+func (c *Client) PromoteMember(id uint64) error {
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   c.Endpoints,
+		DialTimeout: dialTimeout,
+		DialOptions: []grpc.DialOption{
+			grpc.WithBlock(), // block until the underlying connection is up
+		},
+		TLS: c.TLS,
+	})
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	var lastError error
+	err = wait.ExponentialBackoff(etcdBackoff, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), etcdTimeout)
+		_, err = cli.MemberPromote(ctx, id)
+		cancel()
+		if err == nil {
+			return true, nil
+		}
+		klog.V(5).Infof("Failed to promote etcd member: %v", err)
+		lastError = err
+		return false, nil
+	})
+	if err != nil {
+		return lastError
+	}
+	return nil
+}
+
+// RemoveStaleLearners removes learner members that never started (they report neither a
+// name nor client URLs), as such stale learners block both their own promotion and the
+// addition of further members. The removed members are returned.
+func (c *Client) RemoveStaleLearners() ([]MemberInfo, error) {
+	members, err := c.ListMemberInfos()
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []MemberInfo{}
+	for _, member := range members {
+		if !member.IsLearner {
+			continue
+		}
+		if member.Name != "" || len(member.ClientURLs) > 0 {
+			// the learner has started; promotion may simply not have happened yet
+			continue
+		}
+		klog.V(1).Infof("[etcd] removing stale learner member %x", member.ID)
+		if _, err := c.RemoveMember(member.ID); err != nil {
+			return removed, err
+		}
+		removed = append(removed, member)
+	}
+	return removed, nil
+}
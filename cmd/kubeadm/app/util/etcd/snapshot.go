@@ -0,0 +1,162 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/clientv3"
+	"google.golang.org/grpc"
+	"k8s.io/klog"
+)
+
+// snapshotHashSize is the size of the integrity hash the etcd server appends to the
+// snapshot stream
+const snapshotHashSize = sha256.Size
+
+// SaveSnapshot streams a snapshot of the etcd backend database to the given file.
+// The integrity hash appended to the stream by the etcd server is verified before
+// the file is moved into place, so a partially transferred snapshot is never left
+// behind under the target path.
+func (c *Client) SaveSnapshot(path string) error {
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   c.Endpoints,
+		DialTimeout: dialTimeout,
+		DialOptions: []grpc.DialOption{
+			grpc.WithBlock(), // block until the underlying connection is up
+		},
+		TLS: c.TLS,
+	})
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	klog.V(1).Infof("[etcd] saving a snapshot from endpoint %s to %q", c.Endpoints[0], path)
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	reader, err := cli.Snapshot(ctx)
+	defer cancel()
+	if err != nil {
+		return errors.Wrap(err, "failed to request a snapshot from etcd")
+	}
+	defer reader.Close()
+
+	tmpFile, err := os.OpenFile(path+".part", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the temporary snapshot file %q", path+".part")
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, reader); err != nil {
+		tmpFile.Close()
+		return errors.Wrap(err, "failed to stream the snapshot from etcd")
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return errors.Wrap(err, "failed to sync the snapshot to disk")
+	}
+	tmpFile.Close()
+
+	if err := verifySnapshotIntegrity(tmpFile.Name()); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// verifySnapshotIntegrity validates the integrity hash the etcd server appended to the
+// snapshot stream
+func verifySnapshotIntegrity(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the snapshot file %q", path)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat the snapshot file %q", path)
+	}
+	if info.Size() <= snapshotHashSize {
+		return errors.Errorf("the snapshot file %q is too small to carry an integrity hash", path)
+	}
+
+	hasher := sha256.New()
+	if _, err := io.CopyN(hasher, f, info.Size()-snapshotHashSize); err != nil {
+		return errors.Wrapf(err, "failed to hash the snapshot file %q", path)
+	}
+
+	storedHash := make([]byte, snapshotHashSize)
+	if _, err := io.ReadFull(f, storedHash); err != nil {
+		return errors.Wrapf(err, "failed to read the integrity hash of the snapshot file %q", path)
+	}
+
+	if fmt.Sprintf("%x", hasher.Sum(nil)) != fmt.Sprintf("%x", storedHash) {
+		return errors.Errorf("the integrity hash of the snapshot file %q does not match its contents", path)
+	}
+	return nil
+}
+
+// RestoreSnapshot populates a fresh etcd data directory from a verified snapshot file.
+// The caller is responsible for making sure no etcd instance uses the data directory and
+// for starting etcd with --force-new-cluster afterwards, so the member metadata embedded
+// in the snapshot is rewritten.
+func RestoreSnapshot(snapshotPath, dataDir string) error {
+	if err := verifySnapshotIntegrity(snapshotPath); err != nil {
+		return err
+	}
+
+	if entries, err := ioutil.ReadDir(dataDir); err == nil && len(entries) > 0 {
+		return errors.Errorf("the data directory %q already exists and is not empty", dataDir)
+	}
+
+	snapDir := filepath.Join(dataDir, "member", "snap")
+	if err := os.MkdirAll(snapDir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory %q", snapDir)
+	}
+
+	source, err := os.Open(snapshotPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the snapshot file %q", snapshotPath)
+	}
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat the snapshot file %q", snapshotPath)
+	}
+
+	dbPath := filepath.Join(snapDir, "db")
+	db, err := os.OpenFile(dbPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the database file %q", dbPath)
+	}
+	defer db.Close()
+
+	// the trailing integrity hash is not part of the database
+	if _, err := io.CopyN(db, source, info.Size()-snapshotHashSize); err != nil {
+		return errors.Wrapf(err, "failed to restore the snapshot into %q", dbPath)
+	}
+	return db.Sync()
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestSnapshot writes a fake snapshot consisting of the passed payload with the
+// integrity hash appended, like the etcd server does
+func writeTestSnapshot(t *testing.T, path string, payload []byte, corrupt bool) {
+	hash := sha256.Sum256(payload)
+	if corrupt {
+		hash[0]++
+	}
+	if err := ioutil.WriteFile(path, append(payload, hash[:]...), 0600); err != nil {
+		t.Fatalf("couldn't write the test snapshot: %v", err)
+	}
+}
+
+func TestVerifySnapshotIntegrity(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "etcd-snapshot-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	valid := filepath.Join(tmpdir, "valid.db")
+	writeTestSnapshot(t, valid, []byte("some etcd database content"), false)
+	if err := verifySnapshotIntegrity(valid); err != nil {
+		t.Errorf("verifySnapshotIntegrity failed for a valid snapshot: %v", err)
+	}
+
+	corrupt := filepath.Join(tmpdir, "corrupt.db")
+	writeTestSnapshot(t, corrupt, []byte("some etcd database content"), true)
+	if err := verifySnapshotIntegrity(corrupt); err == nil {
+		t.Error("expected an error for a corrupt snapshot, got none")
+	}
+
+	tooSmall := filepath.Join(tmpdir, "small.db")
+	if err := ioutil.WriteFile(tooSmall, []byte("tiny"), 0600); err != nil {
+		t.Fatalf("couldn't write the test snapshot: %v", err)
+	}
+	if err := verifySnapshotIntegrity(tooSmall); err == nil {
+		t.Error("expected an error for a truncated snapshot, got none")
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "etcd-snapshot-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	payload := []byte("some etcd database content")
+	snapshot := filepath.Join(tmpdir, "snapshot.db")
+	writeTestSnapshot(t, snapshot, payload, false)
+
+	dataDir := filepath.Join(tmpdir, "data")
+	if err := RestoreSnapshot(snapshot, dataDir); err != nil {
+		t.Fatalf("RestoreSnapshot failed: %v", err)
+	}
+
+	db, err := ioutil.ReadFile(filepath.Join(dataDir, "member", "snap", "db"))
+	if err != nil {
+		t.Fatalf("couldn't read the restored database: %v", err)
+	}
+	if string(db) != string(payload) {
+		t.Errorf("the restored database does not match the snapshot payload")
+	}
+
+	// restoring into a non-empty data directory must fail
+	if err := RestoreSnapshot(snapshot, dataDir); err == nil {
+		t.Error("expected an error when restoring into a non-empty data directory, got none")
+	}
+}
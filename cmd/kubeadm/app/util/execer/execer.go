@@ -0,0 +1,152 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package execer provides an exec seam whose invocations are logged in a structured
+// way, bounded by a timeout and recordable for tests and dry-run. Today only the reset
+// container cleanup shells out through it - the preflight, runtime and init system
+// paths still use utilsexec directly - adopting it there is incremental work.
+package execer
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+	utilsexec "k8s.io/utils/exec"
+)
+
+// defaultCommandTimeout bounds a single external command invocation
+const defaultCommandTimeout = 5 * time.Minute
+
+// Recorder collects the external commands a dry-run would have executed
+type Recorder struct {
+	mutex    sync.Mutex
+	commands []string
+}
+
+// Commands returns the recorded command lines in invocation order
+func (r *Recorder) Commands() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	commands := make([]string, len(r.commands))
+	copy(commands, r.commands)
+	return commands
+}
+
+// record stores one command line
+func (r *Recorder) record(commandLine string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.commands = append(r.commands, commandLine)
+}
+
+// execer wraps an exec implementation with logging and timeouts
+type execer struct {
+	delegate utilsexec.Interface
+	timeout  time.Duration
+	recorder *Recorder
+}
+
+// New returns the standard kubeadm exec interface: logged invocations with the default timeout
+func New() utilsexec.Interface {
+	return &execer{delegate: utilsexec.New(), timeout: defaultCommandTimeout}
+}
+
+// NewWithTimeout returns an exec interface bounding every invocation by the passed timeout
+func NewWithTimeout(timeout time.Duration) utilsexec.Interface {
+	return &execer{delegate: utilsexec.New(), timeout: timeout}
+}
+
+// NewRecording returns an exec interface that records the command lines into the
+// recorder instead of executing them; commands report success with empty output
+func NewRecording(recorder *Recorder) utilsexec.Interface {
+	return &execer{delegate: &recordingDelegate{recorder: recorder}, timeout: defaultCommandTimeout, recorder: recorder}
+}
+
+func (e *execer) Command(cmd string, args ...string) utilsexec.Cmd {
+	klog.V(2).Infof("[exec] running: %s %s", cmd, strings.Join(args, " "))
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	command := e.delegate.CommandContext(ctx, cmd, args...)
+	return &timeoutCmd{Cmd: command, cancel: cancel}
+}
+
+func (e *execer) CommandContext(ctx context.Context, cmd string, args ...string) utilsexec.Cmd {
+	klog.V(2).Infof("[exec] running: %s %s", cmd, strings.Join(args, " "))
+	return e.delegate.CommandContext(ctx, cmd, args...)
+}
+
+func (e *execer) LookPath(file string) (string, error) {
+	return e.delegate.LookPath(file)
+}
+
+// timeoutCmd releases the timeout context when the command finishes
+type timeoutCmd struct {
+	utilsexec.Cmd
+	cancel context.CancelFunc
+}
+
+func (c *timeoutCmd) Run() error {
+	defer c.cancel()
+	return c.Cmd.Run()
+}
+
+func (c *timeoutCmd) CombinedOutput() ([]byte, error) {
+	defer c.cancel()
+	return c.Cmd.CombinedOutput()
+}
+
+func (c *timeoutCmd) Output() ([]byte, error) {
+	defer c.cancel()
+	return c.Cmd.Output()
+}
+
+// recordingDelegate records instead of executing
+type recordingDelegate struct {
+	recorder *Recorder
+}
+
+func (r *recordingDelegate) Command(cmd string, args ...string) utilsexec.Cmd {
+	r.recorder.record(strings.Join(append([]string{cmd}, args...), " "))
+	return recordedCmd{}
+}
+
+func (r *recordingDelegate) CommandContext(_ context.Context, cmd string, args ...string) utilsexec.Cmd {
+	return r.Command(cmd, args...)
+}
+
+func (r *recordingDelegate) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+// recordedCmd reports success without doing anything
+type recordedCmd struct{}
+
+func (recordedCmd) Run() error                         { return nil }
+func (recordedCmd) CombinedOutput() ([]byte, error)    { return []byte{}, nil }
+func (recordedCmd) Output() ([]byte, error)            { return []byte{}, nil }
+func (recordedCmd) SetDir(dir string)                  {}
+func (recordedCmd) SetStdin(in io.Reader)              {}
+func (recordedCmd) SetStdout(out io.Writer)            {}
+func (recordedCmd) SetStderr(out io.Writer)            {}
+func (recordedCmd) SetEnv([]string)                    {}
+func (recordedCmd) Stop()                              {}
+func (recordedCmd) Start() error                       { return nil }
+func (recordedCmd) Wait() error                        { return nil }
+func (recordedCmd) StdoutPipe() (io.ReadCloser, error) { return nil, nil }
+func (recordedCmd) StderrPipe() (io.ReadCloser, error) { return nil, nil }
@@ -0,0 +1,52 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package execer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordingExecer(t *testing.T) {
+	recorder := &Recorder{}
+	execer := NewRecording(recorder)
+
+	if err := execer.Command("systemctl", "stop", "kubelet").Run(); err != nil {
+		t.Fatalf("a recorded command must report success, got %v", err)
+	}
+	if _, err := execer.Command("iptables", "-F").CombinedOutput(); err != nil {
+		t.Fatalf("a recorded command must report success, got %v", err)
+	}
+
+	commands := recorder.Commands()
+	if len(commands) != 2 || commands[0] != "systemctl stop kubelet" || commands[1] != "iptables -F" {
+		t.Errorf("unexpected recorded commands: %v", commands)
+	}
+}
+
+func TestTimeoutExecer(t *testing.T) {
+	execer := NewWithTimeout(100 * time.Millisecond)
+
+	start := time.Now()
+	err := execer.Command("sleep", "10").Run()
+	if err == nil {
+		t.Error("expected the command to fail due to the timeout")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("the timeout did not bound the command execution")
+	}
+}
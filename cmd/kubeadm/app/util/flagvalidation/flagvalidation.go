@@ -0,0 +1,79 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flagvalidation validates extraArgs entries against embedded metadata about
+// the flags of the deployable component versions, so renamed or removed flags are
+// rejected at configuration validation time instead of producing crash-looping static
+// pods.
+package flagvalidation
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+// removedFlag describes a flag that no longer exists in the deployable versions
+type removedFlag struct {
+	// Since is the release the flag stopped working in
+	Since string
+
+	// Replacement names the flag to use instead, empty when there is none
+	Replacement string
+}
+
+// removedFlags embeds, per component, the flags that were renamed or removed in the
+// component versions this kubeadm can deploy. Keep this in sync when bumping the
+// supported version range.
+var removedFlags = map[string]map[string]removedFlag{
+	constants.KubeAPIServer: {
+		"address":                  {Since: "v1.12", Replacement: "bind-address"},
+		"insecure-bind-address":    {Since: "v1.13"},
+		"admission-control":        {Since: "v1.17", Replacement: "enable-admission-plugins"},
+		"repair-malformed-updates": {Since: "v1.14"},
+		"etcd-quorum-read":         {Since: "v1.14"},
+		"storage-versions":         {Since: "v1.14"},
+	},
+	constants.KubeControllerManager: {
+		"address": {Since: "v1.12", Replacement: "bind-address"},
+		"insecure-experimental-approve-all-kubelet-csrs-for-group": {Since: "v1.14"},
+	},
+	constants.KubeScheduler: {
+		"address": {Since: "v1.12", Replacement: "bind-address"},
+	},
+}
+
+// ValidateExtraArgs checks the extraArgs of a component against the embedded metadata
+// and returns one error per flag that no longer exists
+func ValidateExtraArgs(component string, extraArgs map[string]string) []error {
+	removed, ok := removedFlags[component]
+	if !ok {
+		return nil
+	}
+
+	errs := []error{}
+	for flag := range extraArgs {
+		info, gone := removed[flag]
+		if !gone {
+			continue
+		}
+		if info.Replacement != "" {
+			errs = append(errs, errors.Errorf("the %s flag %q was removed in %s; use %q instead", component, flag, info.Since, info.Replacement))
+			continue
+		}
+		errs = append(errs, errors.Errorf("the %s flag %q was removed in %s", component, flag, info.Since))
+	}
+	return errs
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagvalidation
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+func TestValidateExtraArgs(t *testing.T) {
+	// current flags must pass
+	if errs := ValidateExtraArgs(constants.KubeAPIServer, map[string]string{"audit-log-maxage": "30"}); len(errs) != 0 {
+		t.Errorf("unexpected errors for a valid flag: %v", errs)
+	}
+
+	// removed flags must be rejected, naming the replacement when there is one
+	errs := ValidateExtraArgs(constants.KubeAPIServer, map[string]string{"admission-control": "AlwaysPullImages"})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "enable-admission-plugins") {
+		t.Errorf("expected a removal error naming the replacement, got %v", errs)
+	}
+
+	// unknown components are not validated
+	if errs := ValidateExtraArgs("something-else", map[string]string{"address": ""}); len(errs) != 0 {
+		t.Errorf("unexpected errors for an unknown component: %v", errs)
+	}
+}
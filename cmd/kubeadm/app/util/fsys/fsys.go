@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fsys defines a filesystem seam for kubeadm file operations. The default
+// implementation passes through to the OS; a rooted implementation prefixes every path;
+// and tests can provide hermetic implementations without real temp directories. Today
+// only the kubelet configuration writes go through it - --rootfs still works via
+// chroot - adopting it across the other phases is incremental work.
+package fsys
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Interface is the set of filesystem operations used by the kubeadm phases
+type Interface interface {
+	// ReadFile reads the file at path
+	ReadFile(path string) ([]byte, error)
+
+	// WriteFile writes data to the file at path with the given permissions
+	WriteFile(path string, data []byte, perm os.FileMode) error
+
+	// MkdirAll creates the directory at path, including missing parents
+	MkdirAll(path string, perm os.FileMode) error
+
+	// Stat returns the FileInfo of path
+	Stat(path string) (os.FileInfo, error)
+
+	// Remove removes the file at path
+	Remove(path string) error
+
+	// ReadDir lists the directory at path
+	ReadDir(path string) ([]os.FileInfo, error)
+}
+
+// osFs implements Interface by passing through to the OS
+type osFs struct{}
+
+func (osFs) ReadFile(path string) ([]byte, error) {
+	return ioutil.ReadFile(path)
+}
+
+func (osFs) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return ioutil.WriteFile(path, data, perm)
+}
+
+func (osFs) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFs) Stat(path string) (os.FileInfo, error) {
+	return os.Stat(path)
+}
+
+func (osFs) Remove(path string) error {
+	return os.Remove(path)
+}
+
+func (osFs) ReadDir(path string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(path)
+}
+
+// rootedFs implements Interface below a root directory by prefixing every path
+type rootedFs struct {
+	Interface
+	root string
+}
+
+func (r rootedFs) path(path string) string {
+	return filepath.Join(r.root, path)
+}
+
+func (r rootedFs) ReadFile(path string) ([]byte, error) {
+	return r.Interface.ReadFile(r.path(path))
+}
+
+func (r rootedFs) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return r.Interface.WriteFile(r.path(path), data, perm)
+}
+
+func (r rootedFs) MkdirAll(path string, perm os.FileMode) error {
+	return r.Interface.MkdirAll(r.path(path), perm)
+}
+
+func (r rootedFs) Stat(path string) (os.FileInfo, error) {
+	return r.Interface.Stat(r.path(path))
+}
+
+func (r rootedFs) Remove(path string) error {
+	return r.Interface.Remove(r.path(path))
+}
+
+func (r rootedFs) ReadDir(path string) ([]os.FileInfo, error) {
+	return r.Interface.ReadDir(r.path(path))
+}
+
+// Default is the filesystem the phases operate on. It can be replaced with SetDefault,
+// e.g. by tests or by the --rootfs handling.
+var Default Interface = osFs{}
+
+// SetDefault replaces the filesystem the phases operate on
+func SetDefault(fs Interface) {
+	Default = fs
+}
+
+// NewOSFs returns the pass-through OS filesystem
+func NewOSFs() Interface {
+	return osFs{}
+}
+
+// NewRootedFs returns a filesystem performing every operation below root
+func NewRootedFs(root string, base Interface) Interface {
+	return rootedFs{Interface: base, root: root}
+}
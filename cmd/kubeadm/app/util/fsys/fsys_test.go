@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsys
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRootedFs(t *testing.T) {
+	root, err := ioutil.TempDir("", "fsys-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(root)
+
+	fs := NewRootedFs(root, NewOSFs())
+
+	if err := fs.MkdirAll("/etc/kubernetes", 0700); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := fs.WriteFile("/etc/kubernetes/test.yaml", []byte("contents"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	// the write must land below the root
+	b, err := ioutil.ReadFile(filepath.Join(root, "etc", "kubernetes", "test.yaml"))
+	if err != nil {
+		t.Fatalf("the file was not created below the root: %v", err)
+	}
+	if string(b) != "contents" {
+		t.Errorf("unexpected contents: %q", b)
+	}
+
+	// reads through the rooted fs must see it as well
+	b, err = fs.ReadFile("/etc/kubernetes/test.yaml")
+	if err != nil || string(b) != "contents" {
+		t.Errorf("unexpected rooted read result: %q, %v", b, err)
+	}
+
+	entries, err := fs.ReadDir("/etc/kubernetes")
+	if err != nil || len(entries) != 1 {
+		t.Errorf("unexpected rooted ReadDir result: %v, %v", entries, err)
+	}
+}
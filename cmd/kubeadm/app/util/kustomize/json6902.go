@@ -18,9 +18,15 @@ limitations under the License.
 package kustomize
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/kustomize/pkg/ifc"
 	"sigs.k8s.io/kustomize/pkg/patch"
+	"sigs.k8s.io/yaml"
 )
 
 // json6902 represents a json6902 patch
@@ -62,3 +68,129 @@ func (s *json6902Slice) filterByResource(r *unstructured.Unstructured) json6902S
 	}
 	return result
 }
+
+// json6902File is the file format accepted for RFC 6902 patches placed directly in a
+// patches folder, i.e. without a kustomization file referencing them. The file must use
+// the ".json6902.yaml" suffix; files are applied in the lexical order of their names, so
+// ordering can be controlled with a numeric prefix.
+type json6902File struct {
+	// Target refers to the Kubernetes object that the json patch will be applied to
+	Target *patch.Target `json:"target"`
+
+	// Patch contains the RFC 6902 patch operations
+	Patch string `json:"patch"`
+}
+
+// json6902FileSuffix marks folder files holding an RFC 6902 patch with an embedded target
+const json6902FileSuffix = ".json6902.yaml"
+
+// newJSON6902FromFolderFile returns a json6902 patch from a self describing patch file in a folder
+func newJSON6902FromFolderFile(ldr ifc.Loader, file string) (*json6902, error) {
+	content, err := ldr.Load(file)
+	if err != nil {
+		return nil, err
+	}
+
+	patchFile := &json6902File{}
+	if err := yaml.Unmarshal(content, patchFile); err != nil {
+		return nil, err
+	}
+	if patchFile.Target == nil {
+		return nil, fmt.Errorf("the json6902 patch file %q does not declare a target", file)
+	}
+
+	return &json6902{
+		Target: patchFile.Target,
+		Patch:  patchFile.Patch,
+	}, nil
+}
+
+// resolveNamedPathSegments rewrites path segments of the form "name=<value>" in the patch
+// operations into the index of the matching list element of the resource, so containers
+// and volumes can be targeted by name instead of by brittle indexes, e.g.
+// "/spec/containers/name=kube-apiserver/command/-"
+func (p *json6902) resolveNamedPathSegments(resource *unstructured.Unstructured) error {
+	operations := []map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(p.Patch), &operations); err != nil {
+		return fmt.Errorf("cannot parse the json6902 patch for %s/%s: %v", p.Kind, p.Name, err)
+	}
+
+	changed := false
+	for _, operation := range operations {
+		path, ok := operation["path"].(string)
+		if !ok || !strings.Contains(path, "=") {
+			continue
+		}
+
+		resolved, err := resolveNamedPath(resource.Object, path)
+		if err != nil {
+			return err
+		}
+		operation["path"] = resolved
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	// marshal to JSON, which every RFC 6902 consumer accepts (and is valid YAML as well)
+	b, err := json.Marshal(operations)
+	if err != nil {
+		return err
+	}
+	p.Patch = string(b)
+	return nil
+}
+
+// resolveNamedPath walks the resource along the patch path, replacing "name=<value>"
+// segments with the index of the list element whose "name" field matches
+func resolveNamedPath(current interface{}, path string) (string, error) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+
+		if strings.Contains(segment, "=") {
+			list, ok := current.([]interface{})
+			if !ok {
+				return "", fmt.Errorf("the path segment %q does not address a list", segment)
+			}
+			name := strings.SplitN(segment, "=", 2)[1]
+			index := -1
+			for j, item := range list {
+				itemMap, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if itemMap["name"] == name {
+					index = j
+					break
+				}
+			}
+			if index == -1 {
+				return "", fmt.Errorf("no list element named %q found for path segment %q", name, segment)
+			}
+			segments[i] = strconv.Itoa(index)
+			current = list[index]
+			continue
+		}
+
+		// descend as far as the resource allows; trailing segments may address fields
+		// the patch is about to create
+		switch value := current.(type) {
+		case map[string]interface{}:
+			current = value[segment]
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				current = nil
+				continue
+			}
+			current = value[index]
+		default:
+			current = nil
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
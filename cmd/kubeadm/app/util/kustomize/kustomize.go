@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -96,6 +97,20 @@ func GetManager(kustomizeDir string) (*Manager, error) {
 	return instances[kustomizeDir], nil
 }
 
+// ApplyPatches applies the user supplied patches from kustomizeDir to a rendered
+// manifest. An empty kustomizeDir leaves the manifest untouched, so callers don't have
+// to special case the no-patches path.
+func ApplyPatches(data []byte, kustomizeDir string) ([]byte, error) {
+	if kustomizeDir == "" {
+		return data, nil
+	}
+	km, err := GetManager(kustomizeDir)
+	if err != nil {
+		return nil, err
+	}
+	return km.Kustomize(data)
+}
+
 // loadFromKustomizationFile reads a Kustomization file and all the patches it is
 // referencing (either stategicMerge or json6902 patches)
 func (km *Manager) loadFromKustomizationFile(ldr ifc.Loader) error {
@@ -149,7 +164,9 @@ func (km *Manager) loadFromKustomizationFile(ldr ifc.Loader) error {
 	return nil
 }
 
-// loadFromFolder returns all the stategicMerge patches in a folder
+// loadFromFolder returns all the stategicMerge and json6902 patches in a folder.
+// Files are loaded in the lexical order of their names, which also defines the order
+// patches are applied in.
 func (km *Manager) loadFromFolder(ldr ifc.Loader) error {
 	files, err := ioutil.ReadDir(ldr.Root())
 	if err != nil {
@@ -160,6 +177,16 @@ func (km *Manager) loadFromFolder(ldr ifc.Loader) error {
 			continue
 		}
 
+		// files with the json6902 suffix hold an RFC 6902 patch with an embedded target
+		if strings.HasSuffix(fileInfo.Name(), json6902FileSuffix) {
+			jp, err := newJSON6902FromFolderFile(ldr, fileInfo.Name())
+			if err != nil {
+				return err
+			}
+			km.json6902Patches = append(km.json6902Patches, jp)
+			continue
+		}
+
 		smp, err := newStrategicMergeSliceFromFile(ldr, fileInfo.Name())
 		if err != nil {
 			return err
@@ -183,6 +210,13 @@ func (km *Manager) Kustomize(data []byte) ([]byte, error) {
 	strategicMerge := km.strategicMergePatches.filterByResource(resource)
 	json6902 := km.json6902Patches.filterByResource(resource)
 
+	// allow json6902 patches to address containers and volumes by name instead of index
+	for _, p := range json6902 {
+		if err := p.resolveNamedPathSegments(resource); err != nil {
+			return nil, err
+		}
+	}
+
 	// if there are no patches, for the target resources, exit
 	patchesCnt := len(strategicMerge) + len(json6902)
 	if patchesCnt == 0 {
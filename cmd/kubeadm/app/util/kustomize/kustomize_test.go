@@ -201,3 +201,129 @@ func TestKustomizeWithKustomizationFile(t *testing.T) {
 		t.Error("Kustomize did apply strategic merge patches not corresponding to the resource")
 	}
 }
+
+func TestKustomizeJSON6902FolderPatch(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal("Couldn't create tmpdir")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	json6902Patch := dedent.Dedent(`
+    target:
+        version: v1
+        kind: Pod
+        namespace: kube-system
+        name: kube-apiserver
+    patch: |-
+        - op: add
+          path: /spec/containers/name=kube-apiserver/command/-
+          value: --audit-log-maxage=30
+    `)
+
+	err = ioutil.WriteFile(filepath.Join(tmpdir, "10-audit.json6902.yaml"), []byte(json6902Patch), 0644)
+	if err != nil {
+		t.Fatalf("WriteFile returned unexpected error: %v", err)
+	}
+
+	km, err := GetManager(tmpdir)
+	if err != nil {
+		t.Fatalf("GetManager returned unexpected error: %v", err)
+	}
+
+	resource := dedent.Dedent(`
+    apiVersion: v1
+    kind: Pod
+    metadata:
+        name: kube-apiserver
+        namespace: kube-system
+    spec:
+        containers:
+        - name: other-sidecar
+          command:
+          - sleep
+        - name: kube-apiserver
+          command:
+          - kube-apiserver
+    `)
+
+	kustomized, err := km.Kustomize([]byte(resource))
+	if err != nil {
+		t.Fatalf("Kustomize returned unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(kustomized), "--audit-log-maxage=30") {
+		t.Errorf("the json6902 patch was not applied; got:\n%s", kustomized)
+	}
+	if strings.Contains(strings.Split(string(kustomized), "kube-apiserver")[0], "--audit-log-maxage=30") {
+		t.Errorf("the patch was applied to the wrong container; got:\n%s", kustomized)
+	}
+}
+
+func TestValidatePatchesDir(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal("Couldn't create tmpdir")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	knownTargets := map[string]bool{"Pod/kube-apiserver": true}
+
+	writeTestPatch := func(name, contents string) {
+		if err := ioutil.WriteFile(filepath.Join(tmpdir, name), []byte(dedent.Dedent(contents)), 0644); err != nil {
+			t.Fatalf("WriteFile returned unexpected error: %v", err)
+		}
+	}
+
+	writeTestPatch("10-valid.yaml", `
+    apiVersion: v1
+    kind: Pod
+    metadata:
+        name: kube-apiserver
+        annotations:
+            key: value
+    `)
+	writeTestPatch("20-conflicting.yaml", `
+    apiVersion: v1
+    kind: Pod
+    metadata:
+        name: kube-apiserver
+        annotations:
+            key: other-value
+    `)
+	writeTestPatch("30-unknown-target.yaml", `
+    apiVersion: v1
+    kind: Pod
+    metadata:
+        name: no-such-pod
+    `)
+	writeTestPatch("40-garbage.yaml", `
+    this is: [not, valid
+    `)
+
+	diagnostics, err := ValidatePatchesDir(tmpdir, knownTargets)
+	if err != nil {
+		t.Fatalf("ValidatePatchesDir returned unexpected error: %v", err)
+	}
+
+	var conflicts, unknownTargets, parseErrors int
+	for _, diagnostic := range diagnostics {
+		switch {
+		case strings.Contains(diagnostic.Message, "may conflict"):
+			conflicts++
+		case strings.Contains(diagnostic.Message, "not among the objects"):
+			unknownTargets++
+		case diagnostic.Severity == DiagnosticSeverityError:
+			parseErrors++
+		}
+	}
+	if conflicts != 1 {
+		t.Errorf("expected 1 conflict diagnostic, got %d: %+v", conflicts, diagnostics)
+	}
+	if unknownTargets != 1 {
+		t.Errorf("expected 1 unknown target diagnostic, got %d: %+v", unknownTargets, diagnostics)
+	}
+	if parseErrors != 1 {
+		t.Errorf("expected 1 parse error diagnostic, got %d: %+v", parseErrors, diagnostics)
+	}
+}
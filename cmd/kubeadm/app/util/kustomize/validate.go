@@ -0,0 +1,239 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DiagnosticSeverityError marks a problem that will make patch application fail
+	DiagnosticSeverityError = "error"
+
+	// DiagnosticSeverityWarning marks a problem that is likely a mistake but does not
+	// make patch application fail by itself
+	DiagnosticSeverityWarning = "warning"
+)
+
+// Diagnostic describes one problem found while validating a patches directory
+type Diagnostic struct {
+	// File is the name of the patch file the problem was found in
+	File string `json:"file"`
+
+	// Severity is either "error" or "warning"
+	Severity string `json:"severity"`
+
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// ValidatePatchesDir parses every patch in the directory and returns structured
+// diagnostics for files that cannot be parsed, patches whose target is not among the
+// known kubeadm patch targets, and pairs of patches modifying the same fields of the
+// same target. Today such problems only surface in the middle of init or upgrade.
+func ValidatePatchesDir(dir string, knownTargets map[string]bool) ([]Diagnostic, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the patches directory %q", dir)
+	}
+
+	diagnostics := []Diagnostic{}
+	// fieldOwners maps "kind/name" + field path to the file that patches it first
+	fieldOwners := map[string]string{}
+
+	for _, fileInfo := range files {
+		fileName := fileInfo.Name()
+		if fileInfo.IsDir() {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, fileName))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the patch file %q", fileName)
+		}
+
+		if strings.HasSuffix(fileName, json6902FileSuffix) {
+			diagnostics = append(diagnostics, validateJSON6902File(fileName, content, knownTargets, fieldOwners)...)
+			continue
+		}
+		if !strings.HasSuffix(fileName, ".yaml") && !strings.HasSuffix(fileName, ".yml") && !strings.HasSuffix(fileName, ".json") {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     fileName,
+				Severity: DiagnosticSeverityWarning,
+				Message:  "the file does not use a recognized patch suffix (.yaml, .yml, .json or .json6902.yaml) and will be ignored",
+			})
+			continue
+		}
+		diagnostics = append(diagnostics, validateStrategicMergeFile(fileName, content, knownTargets, fieldOwners)...)
+	}
+
+	return diagnostics, nil
+}
+
+// validateStrategicMergeFile validates a file holding strategic merge patch documents
+func validateStrategicMergeFile(fileName string, content []byte, knownTargets map[string]bool, fieldOwners map[string]string) []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	for _, document := range strings.Split(string(content), "\n---") {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+
+		patch := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(document), &patch); err != nil {
+			return append(diagnostics, Diagnostic{
+				File:     fileName,
+				Severity: DiagnosticSeverityError,
+				Message:  fmt.Sprintf("cannot parse the patch: %v", err),
+			})
+		}
+
+		kind, _ := patch["kind"].(string)
+		name := ""
+		if metadata, ok := patch["metadata"].(map[string]interface{}); ok {
+			name, _ = metadata["name"].(string)
+		}
+		if kind == "" {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     fileName,
+				Severity: DiagnosticSeverityError,
+				Message:  "the patch does not declare a kind, so it can never match a target",
+			})
+			continue
+		}
+
+		target := fmt.Sprintf("%s/%s", kind, name)
+		if knownTargets != nil && !knownTargets[target] {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     fileName,
+				Severity: DiagnosticSeverityWarning,
+				Message:  fmt.Sprintf("the target %q is not among the objects kubeadm patches; see 'kubeadm config print patch-targets'", target),
+			})
+		}
+
+		diagnostics = append(diagnostics, recordFieldOwnership(fileName, target, leafPaths("", patch), fieldOwners)...)
+	}
+	return diagnostics
+}
+
+// validateJSON6902File validates a file holding an RFC 6902 patch with an embedded target
+func validateJSON6902File(fileName string, content []byte, knownTargets map[string]bool, fieldOwners map[string]string) []Diagnostic {
+	patchFile := &json6902File{}
+	if err := yaml.Unmarshal(content, patchFile); err != nil {
+		return []Diagnostic{{
+			File:     fileName,
+			Severity: DiagnosticSeverityError,
+			Message:  fmt.Sprintf("cannot parse the patch file: %v", err),
+		}}
+	}
+	if patchFile.Target == nil {
+		return []Diagnostic{{
+			File:     fileName,
+			Severity: DiagnosticSeverityError,
+			Message:  "the patch file does not declare a target",
+		}}
+	}
+
+	diagnostics := []Diagnostic{}
+	target := fmt.Sprintf("%s/%s", patchFile.Target.Kind, patchFile.Target.Name)
+	if knownTargets != nil && !knownTargets[target] {
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     fileName,
+			Severity: DiagnosticSeverityWarning,
+			Message:  fmt.Sprintf("the target %q is not among the objects kubeadm patches; see 'kubeadm config print patch-targets'", target),
+		})
+	}
+
+	operations := []map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(patchFile.Patch), &operations); err != nil {
+		return append(diagnostics, Diagnostic{
+			File:     fileName,
+			Severity: DiagnosticSeverityError,
+			Message:  fmt.Sprintf("cannot parse the patch operations: %v", err),
+		})
+	}
+
+	paths := []string{}
+	for _, operation := range operations {
+		if _, ok := operation["op"].(string); !ok {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     fileName,
+				Severity: DiagnosticSeverityError,
+				Message:  "a patch operation does not declare an op",
+			})
+		}
+		if path, ok := operation["path"].(string); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return append(diagnostics, recordFieldOwnership(fileName, target, paths, fieldOwners)...)
+}
+
+// identityPaths are present in every strategic merge patch to select the target and do
+// not count as modified fields
+var identityPaths = map[string]bool{
+	"/apiVersion":         true,
+	"/kind":               true,
+	"/metadata/name":      true,
+	"/metadata/namespace": true,
+}
+
+// recordFieldOwnership records which file patches which field paths of a target and
+// reports a conflict when another file already patches the same path
+func recordFieldOwnership(fileName, target string, paths []string, fieldOwners map[string]string) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if identityPaths[path] {
+			continue
+		}
+		key := target + ":" + path
+		if owner, ok := fieldOwners[key]; ok && owner != fileName {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     fileName,
+				Severity: DiagnosticSeverityWarning,
+				Message:  fmt.Sprintf("the patch modifies %q of %q, which is also modified by %q; the patches may conflict", path, target, owner),
+			})
+			continue
+		}
+		fieldOwners[key] = fileName
+	}
+	return diagnostics
+}
+
+// leafPaths flattens a patch document into the list of its leaf field paths
+func leafPaths(prefix string, value interface{}) []string {
+	patchMap, ok := value.(map[string]interface{})
+	if !ok || len(patchMap) == 0 {
+		return []string{prefix}
+	}
+
+	paths := []string{}
+	for key, child := range patchMap {
+		childPrefix := prefix + "/" + key
+		paths = append(paths, leafPaths(childPrefix, child)...)
+	}
+	return paths
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package log implements the structured logging layer of kubeadm: messages carry a
+// subsystem, can be emitted as plain text or as JSON lines (--log-format=json), are
+// filtered by per-subsystem verbosity levels and have well known secrets (bootstrap
+// tokens, certificate keys) redacted before they are written.
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	// FormatText emits human readable "[subsystem] message" lines (the default)
+	FormatText = "text"
+
+	// FormatJSON emits one JSON document per message
+	FormatJSON = "json"
+)
+
+var (
+	mutex  sync.Mutex
+	format           = FormatText
+	writer io.Writer = os.Stdout
+	levels           = map[string]int{}
+
+	// bootstrapTokenPattern matches complete bootstrap tokens of the form id.secret
+	bootstrapTokenPattern = regexp.MustCompile(`\b([a-z0-9]{6})\.[a-z0-9]{16}\b`)
+
+	// certificateKeyPattern matches hex encoded certificate keys
+	certificateKeyPattern = regexp.MustCompile(`\b[a-f0-9]{64}\b`)
+)
+
+// message is the JSON document emitted per message in the JSON format
+type message struct {
+	Time      string `json:"ts"`
+	Level     string `json:"level"`
+	Subsystem string `json:"subsystem,omitempty"`
+	Message   string `json:"msg"`
+}
+
+// SetFormat selects the output format, one of FormatText or FormatJSON
+func SetFormat(f string) error {
+	if f != FormatText && f != FormatJSON {
+		return fmt.Errorf("unknown log format %q; use %q or %q", f, FormatText, FormatJSON)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	format = f
+	return nil
+}
+
+// SetWriter redirects the output, e.g. for tests
+func SetWriter(w io.Writer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	writer = w
+}
+
+// SetLevel sets the verbosity level of a subsystem; messages emitted via V with a higher
+// level are dropped
+func SetLevel(subsystem string, level int) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	levels[subsystem] = level
+}
+
+// V reports whether a message of the given level would be emitted for the subsystem
+func V(subsystem string, level int) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return level <= levels[subsystem]
+}
+
+// Infof emits an info message for the subsystem
+func Infof(subsystem, msgFormat string, args ...interface{}) {
+	emit("info", subsystem, msgFormat, args...)
+}
+
+// Warningf emits a warning message for the subsystem
+func Warningf(subsystem, msgFormat string, args ...interface{}) {
+	emit("warning", subsystem, msgFormat, args...)
+}
+
+// Errorf emits an error message for the subsystem
+func Errorf(subsystem, msgFormat string, args ...interface{}) {
+	emit("error", subsystem, msgFormat, args...)
+}
+
+// emit redacts, formats and writes a single message
+func emit(level, subsystem, msgFormat string, args ...interface{}) {
+	msg := Redact(fmt.Sprintf(msgFormat, args...))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if format == FormatJSON {
+		b, err := json.Marshal(message{
+			Time:      time.Now().Format(time.RFC3339),
+			Level:     level,
+			Subsystem: subsystem,
+			Message:   msg,
+		})
+		if err == nil {
+			fmt.Fprintln(writer, string(b))
+		}
+		return
+	}
+
+	prefix := ""
+	if subsystem != "" {
+		prefix = fmt.Sprintf("[%s] ", subsystem)
+	}
+	if level != "info" {
+		prefix = fmt.Sprintf("%s%s: ", prefix, level)
+	}
+	fmt.Fprintf(writer, "%s%s\n", prefix, msg)
+}
+
+// Redact masks well known secrets (bootstrap tokens, certificate keys) in a message, so
+// kubeadm logs can be ingested into centralized log pipelines
+func Redact(msg string) string {
+	msg = bootstrapTokenPattern.ReplaceAllString(msg, "$1.<redacted>")
+	msg = certificateKeyPattern.ReplaceAllString(msg, "<redacted>")
+	return msg
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	var tests = []struct {
+		name     string
+		msg      string
+		expected string
+	}{
+		{
+			name:     "bootstrap tokens are redacted",
+			msg:      "using token abcdef.0123456789abcdef for joining",
+			expected: "using token abcdef.<redacted> for joining",
+		},
+		{
+			name:     "certificate keys are redacted",
+			msg:      "certificate key: f8902e114ef118304e561c3ecd4d0b543adc226b7a07f675f56564185ffe0c07",
+			expected: "certificate key: <redacted>",
+		},
+		{
+			name:     "ordinary text is untouched",
+			msg:      "wrote manifest for kube-apiserver",
+			expected: "wrote manifest for kube-apiserver",
+		},
+	}
+	for _, rt := range tests {
+		if actual := Redact(rt.msg); actual != rt.expected {
+			t.Errorf("%s:\n\texpected: %s\n\t  actual: %s", rt.name, rt.expected, actual)
+		}
+	}
+}
+
+func TestFormats(t *testing.T) {
+	var buf bytes.Buffer
+	SetWriter(&buf)
+	defer SetWriter(os.Stdout)
+
+	if err := SetFormat("bogus"); err == nil {
+		t.Error("expected an error for an unknown format, got none")
+	}
+
+	if err := SetFormat(FormatText); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+	Infof("etcd", "member added")
+	if got := buf.String(); got != "[etcd] member added\n" {
+		t.Errorf("unexpected text output: %q", got)
+	}
+
+	buf.Reset()
+	if err := SetFormat(FormatJSON); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+	defer SetFormat(FormatText)
+	Warningf("etcd", "member %s is slow", "abcd")
+	got := buf.String()
+	if !strings.Contains(got, `"level":"warning"`) || !strings.Contains(got, `"subsystem":"etcd"`) {
+		t.Errorf("unexpected JSON output: %q", got)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	SetLevel("phases", 2)
+	if !V("phases", 2) {
+		t.Error("expected level 2 to be enabled for the phases subsystem")
+	}
+	if V("phases", 3) {
+		t.Error("expected level 3 to be disabled for the phases subsystem")
+	}
+	if V("other", 1) {
+		t.Error("expected level 1 to be disabled for an unconfigured subsystem")
+	}
+}
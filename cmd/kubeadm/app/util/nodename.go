@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The node name policies a NodeRegistrationOptions can select
+const (
+	// NodeNamePolicyHostname derives the node name from the OS hostname (the default)
+	NodeNamePolicyHostname = "hostname"
+
+	// NodeNamePolicyFQDN derives the node name from the fully qualified domain name
+	NodeNamePolicyFQDN = "fqdn"
+
+	// NodeNamePolicyCloudMetadata derives the node name from the local-hostname entry
+	// of the cloud metadata service
+	NodeNamePolicyCloudMetadata = "cloud-metadata"
+
+	// NodeNamePolicyTemplatePrefix prefixes a go template rendered with the .Hostname
+	// and .FQDN of the node
+	NodeNamePolicyTemplatePrefix = "template:"
+)
+
+// cloudMetadataHostnameURL is the conventional metadata endpoint publishing the name a
+// cloud provider registers the instance under
+var cloudMetadataHostnameURL = "http://169.254.169.254/latest/meta-data/local-hostname"
+
+// ResolveNodeName derives the node name from the given policy. An explicit override
+// always wins, so --node-name and the Name field keep their priority over the policy.
+func ResolveNodeName(policy, override string) (string, error) {
+	if override != "" || policy == "" || policy == NodeNamePolicyHostname {
+		return GetHostname(override)
+	}
+
+	hostname, err := GetHostname("")
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case policy == NodeNamePolicyFQDN:
+		fqdn, err := lookupFQDN(hostname)
+		if err != nil {
+			return "", err
+		}
+		return fqdn, nil
+
+	case policy == NodeNamePolicyCloudMetadata:
+		client := &http.Client{Timeout: 5 * time.Second}
+		resp, err := client.Get(cloudMetadataHostnameURL)
+		if err != nil {
+			return "", errors.Wrap(err, "couldn't reach the cloud metadata service")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", errors.Errorf("the cloud metadata service returned status %d", resp.StatusCode)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return "", errors.Wrap(err, "couldn't read the cloud metadata response")
+		}
+		return GetHostname(string(body))
+
+	case strings.HasPrefix(policy, NodeNamePolicyTemplatePrefix):
+		tmpl, err := template.New("nodename").Parse(strings.TrimPrefix(policy, NodeNamePolicyTemplatePrefix))
+		if err != nil {
+			return "", errors.Wrap(err, "couldn't parse the node name template")
+		}
+		fqdn, err := lookupFQDN(hostname)
+		if err != nil {
+			// the template may not reference .FQDN at all; fall back to the hostname
+			fqdn = hostname
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, struct{ Hostname, FQDN string }{Hostname: hostname, FQDN: fqdn}); err != nil {
+			return "", errors.Wrap(err, "couldn't render the node name template")
+		}
+		return GetHostname(buf.String())
+	}
+
+	return "", errors.Errorf("unknown node name policy %q", policy)
+}
+
+// ValidNodeNamePolicy returns true when the given policy is one kubeadm can resolve
+func ValidNodeNamePolicy(policy string) bool {
+	switch {
+	case policy == "", policy == NodeNamePolicyHostname, policy == NodeNamePolicyFQDN, policy == NodeNamePolicyCloudMetadata:
+		return true
+	case strings.HasPrefix(policy, NodeNamePolicyTemplatePrefix):
+		_, err := template.New("nodename").Parse(strings.TrimPrefix(policy, NodeNamePolicyTemplatePrefix))
+		return err == nil
+	}
+	return false
+}
+
+// lookupFQDN resolves the fully qualified domain name of this node: the canonical DNS
+// name of the hostname, or the reverse record of one of its addresses
+func lookupFQDN(hostname string) (string, error) {
+	if cname, err := net.LookupCNAME(hostname); err == nil && cname != "" {
+		return strings.ToLower(strings.TrimSuffix(cname, ".")), nil
+	}
+	addrs, err := net.LookupHost(hostname)
+	if err != nil {
+		return "", errors.Wrapf(err, "couldn't resolve an FQDN for hostname %q", hostname)
+	}
+	for _, addr := range addrs {
+		names, err := net.LookupAddr(addr)
+		if err != nil || len(names) == 0 {
+			continue
+		}
+		return strings.ToLower(strings.TrimSuffix(names[0], ".")), nil
+	}
+	return "", errors.Errorf("no FQDN found for hostname %q", hostname)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestResolveNodeName(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatalf("couldn't get the hostname: %v", err)
+	}
+	hostname = strings.ToLower(strings.TrimSpace(hostname))
+
+	var tests = []struct {
+		name          string
+		policy        string
+		override      string
+		expected      string
+		expectedError bool
+	}{
+		{"empty policy falls back to the hostname", "", "", hostname, false},
+		{"hostname policy", NodeNamePolicyHostname, "", hostname, false},
+		{"an explicit override wins over the policy", NodeNamePolicyFQDN, "mynode", "mynode", false},
+		{"template policy renders the hostname", NodeNamePolicyTemplatePrefix + "{{.Hostname}}-node", "", hostname + "-node", false},
+		{"broken template", NodeNamePolicyTemplatePrefix + "{{.Hostname", "", "", true},
+		{"unknown policy", "dns-srv", "", "", true},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			actual, err := ResolveNodeName(rt.policy, rt.override)
+			if (err != nil) != rt.expectedError {
+				t.Fatalf("unexpected error result: %v", err)
+			}
+			if actual != rt.expected {
+				t.Errorf("expected node name %q, got %q", rt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidNodeNamePolicy(t *testing.T) {
+	var tests = []struct {
+		policy string
+		valid  bool
+	}{
+		{"", true},
+		{NodeNamePolicyHostname, true},
+		{NodeNamePolicyFQDN, true},
+		{NodeNamePolicyCloudMetadata, true},
+		{NodeNamePolicyTemplatePrefix + "{{.FQDN}}", true},
+		{NodeNamePolicyTemplatePrefix + "{{.FQDN", false},
+		{"dns-srv", false},
+	}
+	for _, rt := range tests {
+		if actual := ValidNodeNamePolicy(rt.policy); actual != rt.valid {
+			t.Errorf("policy %q: expected valid=%v, got %v", rt.policy, rt.valid, actual)
+		}
+	}
+}
@@ -42,6 +42,7 @@ import (
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/features"
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/reproducible"
 )
 
 const (
@@ -548,6 +549,13 @@ func NewSignedCert(cfg *certutil.Config, key crypto.Signer, caCert *x509.Certifi
 	if err != nil {
 		return nil, err
 	}
+	notAfter := time.Now().Add(kubeadmconstants.CertificateValidity).UTC()
+	if reproducible.Enabled() {
+		// derive the serial from the seed and normalize the expiry, so repeated
+		// builds produce identical certificates apart from their keys
+		serial = reproducible.SerialNumber(cfg.CommonName)
+		notAfter = reproducible.NormalizeTime(time.Now()).Add(kubeadmconstants.CertificateValidity)
+	}
 	if len(cfg.CommonName) == 0 {
 		return nil, errors.New("must specify a CommonName")
 	}
@@ -564,7 +572,7 @@ func NewSignedCert(cfg *certutil.Config, key crypto.Signer, caCert *x509.Certifi
 		IPAddresses:  cfg.AltNames.IPs,
 		SerialNumber: serial,
 		NotBefore:    caCert.NotBefore,
-		NotAfter:     time.Now().Add(kubeadmconstants.CertificateValidity).UTC(),
+		NotAfter:     notAfter,
 		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:  cfg.Usages,
 	}
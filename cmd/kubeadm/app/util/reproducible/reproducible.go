@@ -0,0 +1,60 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reproducible holds the process wide switch for deterministic artifact
+// generation: when a seed is set, the values kubeadm otherwise draws from randomness or
+// the wall clock - certificate serial numbers and artifact timestamps - derive from the
+// seed instead, so image-based node builds can be diffed and cached reliably. Private
+// keys are never derived from the seed; only values that are safe to fix are.
+package reproducible
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+	"time"
+)
+
+// seed is the process wide reproducibility seed; empty means disabled
+var seed string
+
+// SetSeed enables reproducible artifact generation with the given seed
+func SetSeed(s string) {
+	seed = s
+}
+
+// Enabled returns true when a reproducibility seed was set
+func Enabled() bool {
+	return seed != ""
+}
+
+// SerialNumber derives a stable, positive certificate serial number from the seed and
+// the given name, so repeated runs with the same seed produce identical certificates
+// apart from their keys
+func SerialNumber(name string) *big.Int {
+	mac := hmac.New(sha256.New, []byte(seed))
+	mac.Write([]byte(name))
+	sum := mac.Sum(nil)
+	// use 63 bits, the same range as the random serials, and keep it positive
+	serial := new(big.Int).SetBytes(sum[:8])
+	return serial.Rsh(serial, 1)
+}
+
+// NormalizeTime truncates a timestamp to the start of its day in UTC, so artifacts
+// generated on the same day carry identical timestamps
+func NormalizeTime(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reproducible
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSerialNumber(t *testing.T) {
+	defer SetSeed("")
+
+	SetSeed("seed-one")
+	if !Enabled() {
+		t.Fatal("expected the mode to be enabled after setting a seed")
+	}
+	first := SerialNumber("kube-apiserver")
+	if first.Sign() <= 0 {
+		t.Errorf("expected a positive serial, got %v", first)
+	}
+	if first.Cmp(SerialNumber("kube-apiserver")) != 0 {
+		t.Error("the same seed and name must derive the same serial")
+	}
+	if first.Cmp(SerialNumber("kube-scheduler")) == 0 {
+		t.Error("different names must derive different serials")
+	}
+
+	SetSeed("seed-two")
+	if first.Cmp(SerialNumber("kube-apiserver")) == 0 {
+		t.Error("different seeds must derive different serials")
+	}
+}
+
+func TestNormalizeTime(t *testing.T) {
+	in := time.Date(2019, time.November, 12, 13, 14, 15, 16, time.UTC)
+	expected := time.Date(2019, time.November, 12, 0, 0, 0, 0, time.UTC)
+	if actual := NormalizeTime(in); !actual.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
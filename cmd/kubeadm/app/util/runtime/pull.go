@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultPullConcurrency is the number of images pulled in parallel when the caller
+// does not choose a different value
+const DefaultPullConcurrency = 4
+
+// pullBackoffBase is the wait before the first retry of a failed pull; it doubles with
+// every further retry. It's a variable so tests can shorten it.
+var pullBackoffBase = 2 * time.Second
+
+// PullResult describes the outcome of pulling a single image
+type PullResult struct {
+	// Image is the image the result is about
+	Image string `json:"image"`
+
+	// Status is "pulled" for a successful pull, "exists" when the image was already
+	// present and "failed" when all attempts failed
+	Status string `json:"status"`
+
+	// Error holds the failure message of the last attempt, empty on success
+	Error string `json:"error,omitempty"`
+}
+
+// PullImages pulls the given images through the container runtime with up to concurrency
+// pulls in flight, skipping images that already exist and retrying transient failures
+// with exponential backoff (on top of the retries the runtime implementations perform
+// per attempt). One PullResult per image is streamed to resultFn as the pulls finish,
+// and all results are returned in the order of the image list.
+func PullImages(runtime ContainerRuntime, imageList []string, concurrency int, resultFn func(PullResult)) []PullResult {
+	if concurrency < 1 {
+		concurrency = DefaultPullConcurrency
+	}
+
+	var resultMutex sync.Mutex
+	results := make([]PullResult, len(imageList))
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, image := range imageList {
+		wg.Add(1)
+		go func(i int, image string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result := pullSingleImage(runtime, image)
+
+			resultMutex.Lock()
+			results[i] = result
+			if resultFn != nil {
+				resultFn(result)
+			}
+			resultMutex.Unlock()
+		}(i, image)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// pullSingleImage pulls one image, retrying failures with exponential backoff
+func pullSingleImage(runtime ContainerRuntime, image string) PullResult {
+	if exists, err := runtime.ImageExists(image); exists && err == nil {
+		return PullResult{Image: image, Status: "exists"}
+	}
+
+	var err error
+	backoff := pullBackoffBase
+	for attempt := 0; attempt < 3; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = runtime.PullImage(image); err == nil {
+			return PullResult{Image: image, Status: "pulled"}
+		}
+	}
+	return PullResult{Image: image, Status: "failed", Error: err.Error()}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// fakePullRuntime implements ContainerRuntime for testing the parallel puller
+type fakePullRuntime struct {
+	mutex    sync.Mutex
+	existing map[string]bool
+	failing  map[string]bool
+	pulled   []string
+}
+
+func (f *fakePullRuntime) IsDocker() bool                             { return false }
+func (f *fakePullRuntime) IsRunning() error                           { return nil }
+func (f *fakePullRuntime) ListKubeContainers() ([]string, error)      { return nil, nil }
+func (f *fakePullRuntime) RemoveContainers(containers []string) error { return nil }
+
+func (f *fakePullRuntime) PullImage(image string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.failing[image] {
+		return errors.Errorf("cannot pull %s", image)
+	}
+	f.pulled = append(f.pulled, image)
+	return nil
+}
+
+func (f *fakePullRuntime) SetPullCredentials(credentials string)           {}
+func (f *fakePullRuntime) ExportImages(images []string, path string) error { return nil }
+func (f *fakePullRuntime) ImportImages(path string) error                  { return nil }
+func (f *fakePullRuntime) SandboxImage() (string, error)                   { return "", nil }
+func (f *fakePullRuntime) ImageFsInfo() ([]byte, error)                    { return nil, nil }
+
+func (f *fakePullRuntime) ImageExists(image string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.existing[image], nil
+}
+
+func TestPullImages(t *testing.T) {
+	previousBackoff := pullBackoffBase
+	pullBackoffBase = 0
+	defer func() { pullBackoffBase = previousBackoff }()
+
+	runtime := &fakePullRuntime{
+		existing: map[string]bool{"existing": true},
+		failing:  map[string]bool{"broken": true},
+	}
+
+	results := PullImages(runtime, []string{"existing", "fresh", "broken"}, 2, nil)
+
+	expectedStatuses := map[string]string{
+		"existing": "exists",
+		"fresh":    "pulled",
+		"broken":   "failed",
+	}
+	for i, image := range []string{"existing", "fresh", "broken"} {
+		if results[i].Image != image {
+			t.Errorf("unexpected result order: expected %q at %d, got %q", image, i, results[i].Image)
+		}
+		if results[i].Status != expectedStatuses[image] {
+			t.Errorf("unexpected status for %q:\n\texpected: %s\n\t  actual: %s", image, expectedStatuses[image], results[i].Status)
+		}
+	}
+
+	if len(runtime.pulled) != 1 || runtime.pulled[0] != "fresh" {
+		t.Errorf("unexpected pulls performed: %v", runtime.pulled)
+	}
+}
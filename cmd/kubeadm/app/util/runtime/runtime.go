@@ -17,6 +17,8 @@ limitations under the License.
 package util
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"path/filepath"
 	goruntime "runtime"
 	"strings"
@@ -24,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 
 	errorsutil "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	utilsexec "k8s.io/utils/exec"
 )
@@ -36,12 +39,18 @@ type ContainerRuntime interface {
 	RemoveContainers(containers []string) error
 	PullImage(image string) error
 	ImageExists(image string) (bool, error)
+	SetPullCredentials(credentials string)
+	ExportImages(images []string, path string) error
+	ImportImages(path string) error
+	SandboxImage() (string, error)
+	ImageFsInfo() ([]byte, error)
 }
 
 // CRIRuntime is a struct that interfaces with the CRI
 type CRIRuntime struct {
-	exec      utilsexec.Interface
-	criSocket string
+	exec        utilsexec.Interface
+	criSocket   string
+	credentials string
 }
 
 // DockerRuntime is a struct that interfaces with the Docker daemon
@@ -49,6 +58,18 @@ type DockerRuntime struct {
 	exec utilsexec.Interface
 }
 
+// SetPullCredentials sets the "username:password" credentials passed to crictl for image pulls
+func (runtime *CRIRuntime) SetPullCredentials(credentials string) {
+	runtime.credentials = credentials
+}
+
+// SetPullCredentials is a no-op for docker, which only supports credentials via 'docker login'
+func (runtime *DockerRuntime) SetPullCredentials(credentials string) {
+	if credentials != "" {
+		klog.Warningln("the docker runtime does not support pull credentials passed by kubeadm; run 'docker login' instead")
+	}
+}
+
 // NewContainerRuntime sets up and returns a ContainerRuntime struct
 func NewContainerRuntime(execer utilsexec.Interface, criSocket string) (ContainerRuntime, error) {
 	var toolName string
@@ -62,7 +83,7 @@ func NewContainerRuntime(execer utilsexec.Interface, criSocket string) (Containe
 		if filepath.IsAbs(criSocket) && goruntime.GOOS != "windows" {
 			criSocket = "unix://" + criSocket
 		}
-		runtime = &CRIRuntime{execer, criSocket}
+		runtime = &CRIRuntime{exec: execer, criSocket: criSocket}
 	} else {
 		toolName = "docker"
 		runtime = &DockerRuntime{execer}
@@ -154,7 +175,12 @@ func (runtime *CRIRuntime) PullImage(image string) error {
 	var err error
 	var out []byte
 	for i := 0; i < constants.PullImageRetry; i++ {
-		out, err = runtime.exec.Command("crictl", "-r", runtime.criSocket, "pull", image).CombinedOutput()
+		args := []string{"-r", runtime.criSocket, "pull"}
+		if runtime.credentials != "" {
+			args = append(args, "--creds", runtime.credentials)
+		}
+		args = append(args, image)
+		out, err = runtime.exec.Command("crictl", args...).CombinedOutput()
 		if err == nil {
 			return nil
 		}
@@ -227,3 +253,89 @@ func detectCRISocketImpl(isSocket func(string) bool) (string, error) {
 func DetectCRISocket() (string, error) {
 	return detectCRISocketImpl(isExistingSocket)
 }
+
+// ApplyImagePullConfiguration configures the runtime with the pull credentials referenced
+// in the ClusterConfiguration's ImagePull section, if any
+func ApplyImagePullConfiguration(runtime ContainerRuntime, credentialsFile string) error {
+	if credentialsFile == "" {
+		return nil
+	}
+	b, err := ioutil.ReadFile(credentialsFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the image pull credentials file %q", credentialsFile)
+	}
+	runtime.SetPullCredentials(strings.TrimSpace(string(b)))
+	return nil
+}
+
+// ExportImages saves the given images into a tarball bundle at path
+func (runtime *DockerRuntime) ExportImages(images []string, path string) error {
+	args := append([]string{"save", "-o", path}, images...)
+	out, err := runtime.exec.Command("docker", args...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "output: %s, error", string(out))
+	}
+	return nil
+}
+
+// ImportImages loads the images of a tarball bundle at path into the runtime
+func (runtime *DockerRuntime) ImportImages(path string) error {
+	out, err := runtime.exec.Command("docker", "load", "-i", path).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "output: %s, error", string(out))
+	}
+	return nil
+}
+
+// ExportImages is not available through the CRI; the runtime's native tooling must be used
+func (runtime *CRIRuntime) ExportImages(images []string, path string) error {
+	return errors.New("the CRI does not expose an image export API; export the bundle with the runtime's native tooling (e.g. 'ctr images export') or the docker runtime")
+}
+
+// ImportImages is not available through the CRI; the runtime's native tooling must be used
+func (runtime *CRIRuntime) ImportImages(path string) error {
+	return errors.New("the CRI does not expose an image import API; load the bundle with the runtime's native tooling (e.g. 'ctr images import') or the docker runtime")
+}
+
+// criInfo mirrors the parts of the 'crictl info' output kubeadm is interested in
+type criInfo struct {
+	Config struct {
+		SandboxImage string `json:"sandboxImage"`
+	} `json:"config"`
+}
+
+// SandboxImage returns the sandbox (pause) image the runtime is configured with
+func (runtime *CRIRuntime) SandboxImage() (string, error) {
+	out, err := runtime.exec.Command("crictl", "-r", runtime.criSocket, "info").CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "output: %s, error", string(out))
+	}
+
+	info := criInfo{}
+	if err := json.Unmarshal(out, &info); err != nil {
+		return "", errors.Wrap(err, "failed to parse the runtime info")
+	}
+	if info.Config.SandboxImage == "" {
+		return "", errors.New("the runtime info does not report a sandbox image")
+	}
+	return info.Config.SandboxImage, nil
+}
+
+// SandboxImage is not reported by docker; the kubelet passes the pause image to it explicitly
+func (runtime *DockerRuntime) SandboxImage() (string, error) {
+	return "", errors.New("the docker runtime does not report a sandbox image; it is set via the kubelet --pod-infra-container-image flag")
+}
+
+// ImageFsInfo returns the image filesystem usage as reported by the runtime
+func (runtime *CRIRuntime) ImageFsInfo() ([]byte, error) {
+	out, err := runtime.exec.Command("crictl", "-r", runtime.criSocket, "imagefsinfo").CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrapf(err, "output: %s, error", string(out))
+	}
+	return out, nil
+}
+
+// ImageFsInfo is not available through docker
+func (runtime *DockerRuntime) ImageFsInfo() ([]byte, error) {
+	return nil, errors.New("the docker runtime does not report image filesystem usage through kubeadm")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package skew implements the version skew policy engine of kubeadm: it validates the
+// kubeadm binary version against the target Kubernetes version and the kubelet and
+// kubectl versions found on the node, producing errors that state the allowed ranges.
+package skew
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+const (
+	// maxKubeletSkew is how many minor versions the kubelet may lag behind the control plane
+	maxKubeletSkew = 2
+
+	// maxKubectlSkew is how many minor versions kubectl may differ from the control plane
+	maxKubectlSkew = 1
+)
+
+// ValidateKubeadm validates the version of the kubeadm binary against the target
+// Kubernetes version: kubeadm deploys control planes of its own minor version and of the
+// previous one
+func ValidateKubeadm(kubeadmVersion, targetVersion *version.Version) error {
+	if targetVersion.Major() != kubeadmVersion.Major() {
+		return skewError("kubeadm", kubeadmVersion, targetVersion, allowedRange(kubeadmVersion, 0, 1))
+	}
+	if targetVersion.Minor() > kubeadmVersion.Minor() || kubeadmVersion.Minor()-targetVersion.Minor() > 1 {
+		return skewError("kubeadm", kubeadmVersion, targetVersion, allowedRange(kubeadmVersion, 0, 1))
+	}
+	return nil
+}
+
+// ValidateKubelet validates the version of the node's kubelet against the target
+// Kubernetes version: the kubelet may lag up to two minor versions behind the control
+// plane and must never be newer
+func ValidateKubelet(kubeletVersion, targetVersion *version.Version) error {
+	if targetVersion.Major() != kubeletVersion.Major() {
+		return skewError("kubelet", kubeletVersion, targetVersion, allowedRange(targetVersion, 0, maxKubeletSkew))
+	}
+	if kubeletVersion.Minor() > targetVersion.Minor() || targetVersion.Minor()-kubeletVersion.Minor() > maxKubeletSkew {
+		return skewError("kubelet", kubeletVersion, targetVersion, allowedRange(targetVersion, 0, maxKubeletSkew))
+	}
+	return nil
+}
+
+// ValidateKubectl validates the version of the node's kubectl against the target
+// Kubernetes version: kubectl may differ by at most one minor version in either direction
+func ValidateKubectl(kubectlVersion, targetVersion *version.Version) error {
+	if targetVersion.Major() != kubectlVersion.Major() {
+		return skewError("kubectl", kubectlVersion, targetVersion, allowedRange(targetVersion, maxKubectlSkew, maxKubectlSkew))
+	}
+	minorSkew := int(kubectlVersion.Minor()) - int(targetVersion.Minor())
+	if minorSkew > maxKubectlSkew || minorSkew < -maxKubectlSkew {
+		return skewError("kubectl", kubectlVersion, targetVersion, allowedRange(targetVersion, maxKubectlSkew, maxKubectlSkew))
+	}
+	return nil
+}
+
+// allowedRange renders the allowed minor version range around a reference version,
+// newerSkew minors above it and olderSkew minors below it
+func allowedRange(reference *version.Version, newerSkew, olderSkew uint) string {
+	oldest := int(reference.Minor()) - int(olderSkew)
+	if oldest < 0 {
+		oldest = 0
+	}
+	return fmt.Sprintf("%d.%d.x - %d.%d.x", reference.Major(), oldest, reference.Major(), reference.Minor()+newerSkew)
+}
+
+// skewError builds the error stating the unsupported skew and the allowed range
+func skewError(component string, componentVersion, targetVersion *version.Version, allowed string) error {
+	return errors.Errorf("unsupported version skew: %s version %q cannot be used with Kubernetes version %q; the allowed %s version range is %s",
+		component, componentVersion, targetVersion, component, allowed)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package skew
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+func TestValidateSkew(t *testing.T) {
+	var tests = []struct {
+		name          string
+		validate      func(componentVersion, targetVersion *version.Version) error
+		component     string
+		target        string
+		expectedError bool
+	}{
+		{"kubeadm deploys its own minor", ValidateKubeadm, "v1.17.2", "v1.17.0", false},
+		{"kubeadm deploys the previous minor", ValidateKubeadm, "v1.17.2", "v1.16.3", false},
+		{"kubeadm cannot deploy a newer minor", ValidateKubeadm, "v1.17.2", "v1.18.0", true},
+		{"kubeadm cannot deploy two minors back", ValidateKubeadm, "v1.17.2", "v1.15.0", true},
+		{"kubelet may equal the control plane", ValidateKubelet, "v1.17.0", "v1.17.2", false},
+		{"kubelet may lag two minors", ValidateKubelet, "v1.15.0", "v1.17.2", false},
+		{"kubelet may not lag three minors", ValidateKubelet, "v1.14.0", "v1.17.2", true},
+		{"kubelet may not be newer", ValidateKubelet, "v1.18.0", "v1.17.2", true},
+		{"kubectl may be one minor newer", ValidateKubectl, "v1.18.0", "v1.17.2", false},
+		{"kubectl may be one minor older", ValidateKubectl, "v1.16.0", "v1.17.2", false},
+		{"kubectl may not be two minors off", ValidateKubectl, "v1.15.0", "v1.17.2", true},
+	}
+	for _, rt := range tests {
+		err := rt.validate(version.MustParseSemantic(rt.component), version.MustParseSemantic(rt.target))
+		if (err != nil) != rt.expectedError {
+			t.Errorf("%s:\n\texpected error: %t\n\t  actual: %v", rt.name, rt.expectedError, err)
+		}
+	}
+}
@@ -35,6 +35,7 @@ import (
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	kubeadmutil "k8s.io/kubernetes/cmd/kubeadm/app/util"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/kustomize"
+	utilsnet "k8s.io/utils/net"
 )
 
 const (
@@ -255,7 +256,7 @@ func GetControllerManagerProbeAddress(cfg *kubeadmapi.ClusterConfiguration) stri
 	if addr, exists := cfg.ControllerManager.ExtraArgs[kubeControllerManagerBindAddressArg]; exists {
 		return getProbeAddress(addr)
 	}
-	return "127.0.0.1"
+	return GetDefaultBindAddress(cfg)
 }
 
 // GetSchedulerProbeAddress returns the kubernetes scheduler probe address
@@ -263,6 +264,18 @@ func GetSchedulerProbeAddress(cfg *kubeadmapi.ClusterConfiguration) string {
 	if addr, exists := cfg.Scheduler.ExtraArgs[kubeSchedulerBindAddressArg]; exists {
 		return getProbeAddress(addr)
 	}
+	return GetDefaultBindAddress(cfg)
+}
+
+// GetDefaultBindAddress returns the loopback address matching the IP family of the
+// service subnet in the passed ClusterConfiguration. For dual-stack clusters the
+// primary (first) subnet decides the family. If no service subnet is configured or
+// it cannot be parsed, the IPv4 loopback address is returned.
+func GetDefaultBindAddress(cfg *kubeadmapi.ClusterConfiguration) string {
+	subnet := strings.Split(cfg.Networking.ServiceSubnet, ",")[0]
+	if utilsnet.IsIPv6CIDRString(subnet) {
+		return "::1"
+	}
 	return "127.0.0.1"
 }
 
@@ -169,6 +169,50 @@ func TestGetControllerManagerProbeAddress(t *testing.T) {
 	}
 }
 
+func TestGetDefaultBindAddress(t *testing.T) {
+	tests := []struct {
+		desc     string
+		cfg      *kubeadmapi.ClusterConfiguration
+		expected string
+	}{
+		{
+			desc:     "no service subnet leads to the IPv4 loopback being used",
+			cfg:      &kubeadmapi.ClusterConfiguration{},
+			expected: "127.0.0.1",
+		},
+		{
+			desc: "IPv4 service subnet leads to the IPv4 loopback being used",
+			cfg: &kubeadmapi.ClusterConfiguration{
+				Networking: kubeadmapi.Networking{ServiceSubnet: "10.96.0.0/12"},
+			},
+			expected: "127.0.0.1",
+		},
+		{
+			desc: "IPv6 service subnet leads to the IPv6 loopback being used",
+			cfg: &kubeadmapi.ClusterConfiguration{
+				Networking: kubeadmapi.Networking{ServiceSubnet: "2001:db8::/112"},
+			},
+			expected: "::1",
+		},
+		{
+			desc: "the primary subnet decides the family for dual-stack",
+			cfg: &kubeadmapi.ClusterConfiguration{
+				Networking: kubeadmapi.Networking{ServiceSubnet: "2001:db8::/112,10.96.0.0/12"},
+			},
+			expected: "::1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := GetDefaultBindAddress(test.cfg)
+			if actual != test.expected {
+				t.Errorf("Unexpected result from GetDefaultBindAddress:\n\texpected: %s\n\tactual: %s", test.expected, actual)
+			}
+		})
+	}
+}
+
 func TestGetSchedulerProbeAddress(t *testing.T) {
 	tests := []struct {
 		desc     string
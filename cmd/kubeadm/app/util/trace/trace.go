@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trace implements lightweight execution tracing for the kubeadm workflows:
+// spans record how long the individual phases and long-running operations take, and are
+// exported as JSON to a file or HTTP collector endpoint, so operators can see where a
+// slow init, join or upgrade is spending its time.
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+const (
+	// TraceFileEnv names the environment variable selecting a file to export spans to
+	TraceFileEnv = "KUBEADM_TRACE_FILE"
+
+	// TraceEndpointEnv names the environment variable selecting an HTTP collector
+	// endpoint the spans are POSTed to as JSON
+	TraceEndpointEnv = "KUBEADM_TRACE_ENDPOINT"
+)
+
+// Span describes a single timed operation
+type Span struct {
+	// Name identifies the operation, e.g. "phase/preflight"
+	Name string `json:"name"`
+
+	// Start is the start timestamp of the operation
+	Start time.Time `json:"start"`
+
+	// DurationMillis is how long the operation took
+	DurationMillis int64 `json:"durationMillis"`
+
+	// Error holds the failure message of the operation, empty on success
+	Error string `json:"error,omitempty"`
+}
+
+var (
+	mutex sync.Mutex
+	spans []Span
+)
+
+// StartSpan starts timing the named operation and returns the function ending it. The
+// error the operation failed with (or nil) is recorded on end.
+func StartSpan(name string) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		span := Span{
+			Name:           name,
+			Start:          start,
+			DurationMillis: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			span.Error = err.Error()
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		spans = append(spans, span)
+	}
+}
+
+// Spans returns a copy of all recorded spans
+func Spans() []Span {
+	mutex.Lock()
+	defer mutex.Unlock()
+	result := make([]Span, len(spans))
+	copy(result, spans)
+	return result
+}
+
+// Flush exports the recorded spans to the configured destinations (if any) and clears
+// them. Export failures are logged but never fail the traced command.
+func Flush() {
+	mutex.Lock()
+	recorded := spans
+	spans = nil
+	mutex.Unlock()
+
+	if len(recorded) == 0 {
+		return
+	}
+
+	b, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		klog.Warningf("failed to marshal the recorded trace spans: %v", err)
+		return
+	}
+
+	if file := os.Getenv(TraceFileEnv); file != "" {
+		if err := ioutil.WriteFile(file, b, 0644); err != nil {
+			klog.Warningf("failed to write the trace spans to %q: %v", file, err)
+		}
+	}
+
+	if endpoint := os.Getenv(TraceEndpointEnv); endpoint != "" {
+		client := &http.Client{Timeout: 5 * time.Second}
+		resp, err := client.Post(endpoint, "application/json", bytes.NewReader(b))
+		if err != nil {
+			klog.Warningf("failed to post the trace spans to %q: %v", endpoint, err)
+			return
+		}
+		resp.Body.Close()
+	}
+}
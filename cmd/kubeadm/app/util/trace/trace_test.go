@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSpansAndFlush(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "trace-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	traceFile := filepath.Join(tmpdir, "trace.json")
+	os.Setenv(TraceFileEnv, traceFile)
+	defer os.Unsetenv(TraceFileEnv)
+
+	endSpan := StartSpan("phase/test")
+	endSpan(nil)
+	endSpan = StartSpan("phase/failing")
+	endSpan(errors.New("it broke"))
+
+	if len(Spans()) != 2 {
+		t.Fatalf("expected 2 recorded spans, got %d", len(Spans()))
+	}
+
+	Flush()
+
+	if len(Spans()) != 0 {
+		t.Errorf("expected the spans to be cleared after Flush, got %d", len(Spans()))
+	}
+
+	b, err := ioutil.ReadFile(traceFile)
+	if err != nil {
+		t.Fatalf("couldn't read the trace file: %v", err)
+	}
+	exported := []Span{}
+	if err := json.Unmarshal(b, &exported); err != nil {
+		t.Fatalf("couldn't parse the trace file: %v", err)
+	}
+	if len(exported) != 2 || exported[0].Name != "phase/test" || exported[1].Error != "it broke" {
+		t.Errorf("unexpected exported spans: %+v", exported)
+	}
+}
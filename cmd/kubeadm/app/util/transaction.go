@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// FileTransaction stages file writes and swaps all of them into place at commit time,
+// so a set of related files (e.g. the static pod manifests of the control plane) is
+// never left half-written when kubeadm is interrupted. Every staged file is written and
+// synced to a temporary file in the target directory, so the final rename is atomic on
+// the filesystem level.
+type FileTransaction struct {
+	// stagedFiles maps the target path of every staged write to its temporary file
+	stagedFiles map[string]string
+}
+
+// NewFileTransaction returns an empty transaction
+func NewFileTransaction() *FileTransaction {
+	return &FileTransaction{stagedFiles: map[string]string{}}
+}
+
+// WriteFile stages the write of data to path with the given permissions. The target
+// directory is created if needed; the target file stays untouched until Commit.
+func (t *FileTransaction) WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory %q", dir)
+	}
+
+	// stage in the target directory, so the final rename cannot cross filesystems,
+	// but under a dot-prefixed name: the kubelet ignores dotfiles when watching the
+	// static pod directory, so a staged manifest is never picked up as a second pod
+	// even if kubeadm dies before Commit
+	tmpFile, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a staging file for %q", path)
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return errors.Wrapf(err, "failed to stage the contents of %q", path)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return errors.Wrapf(err, "failed to sync the staged contents of %q", path)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return errors.Wrapf(err, "failed to close the staging file for %q", path)
+	}
+	if err := os.Chmod(tmpFile.Name(), perm); err != nil {
+		os.Remove(tmpFile.Name())
+		return errors.Wrapf(err, "failed to set the permissions of the staging file for %q", path)
+	}
+
+	// a later staged write to the same path replaces the earlier one
+	if previous, ok := t.stagedFiles[path]; ok {
+		os.Remove(previous)
+	}
+	t.stagedFiles[path] = tmpFile.Name()
+	return nil
+}
+
+// Commit atomically renames every staged file onto its target path. When a rename fails,
+// the remaining staged files are removed and an error is returned; already committed
+// files stay in place, as their previous contents are gone.
+func (t *FileTransaction) Commit() error {
+	for path, tmpPath := range t.stagedFiles {
+		if err := os.Rename(tmpPath, path); err != nil {
+			t.Rollback()
+			return errors.Wrapf(err, "failed to move the staged file into place at %q", path)
+		}
+		delete(t.stagedFiles, path)
+	}
+	return nil
+}
+
+// Rollback removes all files that are still staged, leaving the targets untouched
+func (t *FileTransaction) Rollback() {
+	for path, tmpPath := range t.stagedFiles {
+		os.Remove(tmpPath)
+		delete(t.stagedFiles, path)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileTransactionCommit(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "file-transaction-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	first := filepath.Join(tmpdir, "first.yaml")
+	second := filepath.Join(tmpdir, "sub", "second.yaml")
+
+	transaction := NewFileTransaction()
+	if err := transaction.WriteFile(first, []byte("first"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := transaction.WriteFile(second, []byte("second"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	// nothing must exist at the target paths before the commit
+	if _, err := os.Stat(first); !os.IsNotExist(err) {
+		t.Errorf("the target file %q exists before the commit", first)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	for path, expected := range map[string]string{first: "first", second: "second"} {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("couldn't read the committed file %q: %v", path, err)
+		}
+		if string(b) != expected {
+			t.Errorf("unexpected contents of %q: %q", path, b)
+		}
+	}
+
+	// no staging leftovers may remain
+	entries, err := ioutil.ReadDir(tmpdir)
+	if err != nil {
+		t.Fatalf("couldn't list %q: %v", tmpdir, err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("unexpected leftovers in %q: %v", tmpdir, entries)
+	}
+}
+
+func TestFileTransactionRollback(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "file-transaction-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	target := filepath.Join(tmpdir, "manifest.yaml")
+	if err := ioutil.WriteFile(target, []byte("old"), 0600); err != nil {
+		t.Fatalf("couldn't write the pre-existing file: %v", err)
+	}
+
+	transaction := NewFileTransaction()
+	if err := transaction.WriteFile(target, []byte("new"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	transaction.Rollback()
+
+	b, err := ioutil.ReadFile(target)
+	if err != nil {
+		t.Fatalf("couldn't read the target file: %v", err)
+	}
+	if string(b) != "old" {
+		t.Errorf("the rollback did not leave the target untouched: %q", b)
+	}
+
+	entries, err := ioutil.ReadDir(tmpdir)
+	if err != nil {
+		t.Fatalf("couldn't list %q: %v", tmpdir, err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("unexpected leftovers in %q: %v", tmpdir, entries)
+	}
+}
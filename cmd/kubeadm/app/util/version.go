@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -35,6 +36,15 @@ import (
 
 const (
 	getReleaseVersionTimeout = time.Duration(10 * time.Second)
+
+	// VersionBaseURLEnv names the environment variable overriding the base URL version
+	// labels are resolved against, e.g. an internal mirror of dl.k8s.io
+	VersionBaseURLEnv = "KUBEADM_VERSION_BASE_URL"
+
+	// VersionLabelFileEnv names the environment variable pointing at a local file with
+	// "label version" lines (e.g. "stable-1 v1.17.3"), used to resolve version labels in
+	// air-gapped environments without any network access
+	VersionLabelFileEnv = "KUBEADM_VERSION_LABEL_FILE"
 )
 
 var (
@@ -66,6 +76,10 @@ func KubernetesReleaseVersion(version string) (string, error) {
 	return kubernetesReleaseVersion(version, fetchFromURL)
 }
 
+// resolvedLabels caches label resolutions for the lifetime of the process, so repeated
+// lookups (e.g. from help text rendering and the actual command) fetch only once
+var resolvedLabels = map[string]string{}
+
 // kubernetesReleaseVersion is a helper function to fetch
 // available version information. Used for testing to eliminate
 // the need for internet calls.
@@ -88,6 +102,26 @@ func kubernetesReleaseVersion(version string, fetcher func(string, time.Duration
 
 	// kubeReleaseLabelRegex matches labels such as: latest, latest-1, latest-1.10
 	if kubeReleaseLabelRegex.MatchString(versionLabel) {
+		// a cached resolution from this process wins over any fetch
+		if resolved, ok := resolvedLabels[versionLabel]; ok {
+			return resolved, nil
+		}
+
+		// a local label file wins over any remote lookup, for air-gapped environments
+		if labelFile := os.Getenv(VersionLabelFileEnv); labelFile != "" {
+			resolved, err := versionFromLabelFile(labelFile, versionLabel)
+			if err != nil {
+				return "", err
+			}
+			resolvedLabels[versionLabel] = resolved
+			return resolved, nil
+		}
+
+		// an internal mirror can replace the default release bucket
+		if baseURL := os.Getenv(VersionBaseURLEnv); baseURL != "" {
+			bucketURL = baseURL
+		}
+
 		// Try to obtain a client version.
 		// pkgversion.Get().String() should always return a correct version added by the golang
 		// linker and the build system. The version can still be missing when doing unit tests
@@ -120,12 +154,52 @@ func kubernetesReleaseVersion(version string, fetcher func(string, time.Duration
 		if err != nil {
 			return "", err
 		}
-		// Re-validate received version and return.
-		return kubernetesReleaseVersion(body, fetcher)
+		// Re-validate received version, cache and return.
+		resolved, err := kubernetesReleaseVersion(body, fetcher)
+		if err == nil {
+			resolvedLabels[versionLabel] = resolved
+		}
+		return resolved, err
 	}
 	return "", errors.Errorf("version %q doesn't match patterns for neither semantic version nor labels (stable, latest, ...)", version)
 }
 
+// KubernetesReleaseVersionFromFile resolves a version label like "stable" or
+// "latest-1.17" from a local release metadata file instead of the release buckets,
+// so air-gapped environments can compute upgrades without internet access. An exact
+// semantic version passes through unchanged.
+func KubernetesReleaseVersionFromFile(labelFile, versionLabel string) (string, error) {
+	if ver := normalizedBuildVersion(versionLabel); ver != "" {
+		return ver, nil
+	}
+	if !kubeReleaseLabelRegex.MatchString(versionLabel) {
+		return "", errors.Errorf("version %q doesn't match patterns for neither semantic version nor labels (stable, latest, ...)", versionLabel)
+	}
+	return versionFromLabelFile(labelFile, versionLabel)
+}
+
+// versionFromLabelFile resolves a version label from a local file with one
+// "label version" pair per line; lines starting with '#' are ignored
+func versionFromLabelFile(labelFile, versionLabel string) (string, error) {
+	b, err := ioutil.ReadFile(labelFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read the version label file %q", labelFile)
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if fields[0] == versionLabel {
+			if ver := normalizedBuildVersion(fields[1]); ver != "" {
+				return ver, nil
+			}
+			return "", errors.Errorf("the version %q mapped to label %q in %q is not a valid semantic version", fields[1], versionLabel, labelFile)
+		}
+	}
+	return "", errors.Errorf("the version label file %q does not map label %q", labelFile, versionLabel)
+}
+
 // KubernetesVersionToImageTag is helper function that replaces all
 // non-allowed symbols in tag strings with underscores.
 // Image tag can only contain lowercase and uppercase letters, digits,
@@ -19,8 +19,11 @@ package util
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -481,3 +484,70 @@ func TestValidateStableVersion(t *testing.T) {
 func errorFetcher(url string, timeout time.Duration) (string, error) {
 	return "should not make internet calls", fmt.Errorf("should not make internet calls, tried to request url: %s", url)
 }
+
+func TestKubernetesReleaseVersionFromFile(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "version-file-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	labelFile := filepath.Join(tmpdir, "versions.txt")
+	if err := ioutil.WriteFile(labelFile, []byte("stable v1.17.3\n"), 0644); err != nil {
+		t.Fatalf("couldn't write the label file: %v", err)
+	}
+
+	var tests = []struct {
+		version       string
+		expected      string
+		expectedError bool
+	}{
+		{"stable", "v1.17.3", false},  // resolved from the file
+		{"v1.16.1", "v1.16.1", false}, // exact versions pass through
+		{"latest", "", true},          // not mapped in the file
+		{"not-a-version-or-label", "", true},
+	}
+	for _, rt := range tests {
+		actual, err := KubernetesReleaseVersionFromFile(labelFile, rt.version)
+		if (err != nil) != rt.expectedError {
+			t.Errorf("version %q: unexpected error result: %v", rt.version, err)
+		}
+		if actual != rt.expected {
+			t.Errorf("version %q:\n\texpected: %s\n\t  actual: %s", rt.version, rt.expected, actual)
+		}
+	}
+}
+
+func TestVersionFromLabelFile(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "version-label-test")
+	if err != nil {
+		t.Fatalf("couldn't create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	labelFile := filepath.Join(tmpdir, "versions.txt")
+	contents := "# comment\nstable-1 v1.17.3\nlatest v1.18.0-alpha.1\nbroken not-a-version\n"
+	if err := ioutil.WriteFile(labelFile, []byte(contents), 0644); err != nil {
+		t.Fatalf("couldn't write the label file: %v", err)
+	}
+
+	var tests = []struct {
+		label         string
+		expected      string
+		expectedError bool
+	}{
+		{"stable-1", "v1.17.3", false},
+		{"latest", "v1.18.0-alpha.1", false},
+		{"broken", "", true},
+		{"unknown", "", true},
+	}
+	for _, rt := range tests {
+		actual, err := versionFromLabelFile(labelFile, rt.label)
+		if (err != nil) != rt.expectedError {
+			t.Errorf("label %q: unexpected error result: %v", rt.label, err)
+		}
+		if actual != rt.expected {
+			t.Errorf("label %q:\n\texpected: %s\n\t  actual: %s", rt.label, rt.expected, actual)
+		}
+	}
+}
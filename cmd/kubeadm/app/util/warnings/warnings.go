@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package warnings implements the central warning collector of kubeadm: non-fatal
+// warnings produced by validation, preflight and the phases are accumulated during a
+// command and printed again in one dedicated section at the end of the run, instead of
+// scrolling away in the middle of the output.
+package warnings
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+var (
+	mutex     sync.Mutex
+	collected []string
+)
+
+// Collect records a warning for the final warnings section of the running command
+func Collect(format string, args ...interface{}) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	collected = append(collected, fmt.Sprintf(format, args...))
+}
+
+// Collected returns a copy of the warnings recorded so far
+func Collected() []string {
+	mutex.Lock()
+	defer mutex.Unlock()
+	result := make([]string, len(collected))
+	copy(result, collected)
+	return result
+}
+
+// Flush prints the dedicated warnings section (when any warnings were recorded) and
+// clears the collector for the next command
+func Flush(w io.Writer) {
+	mutex.Lock()
+	recorded := collected
+	collected = nil
+	mutex.Unlock()
+
+	if len(recorded) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n[warnings] The following warnings were reported during this command:\n")
+	for _, warning := range recorded {
+		fmt.Fprintf(w, "\t- %s\n", warning)
+	}
+}
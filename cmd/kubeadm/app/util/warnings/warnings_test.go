@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warnings
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCollectAndFlush(t *testing.T) {
+	Collect("something is %s", "odd")
+	Collect("another thing")
+
+	if len(Collected()) != 2 {
+		t.Fatalf("expected 2 collected warnings, got %d", len(Collected()))
+	}
+
+	var buf bytes.Buffer
+	Flush(&buf)
+	out := buf.String()
+	if !strings.Contains(out, "something is odd") || !strings.Contains(out, "another thing") {
+		t.Errorf("unexpected warnings section: %q", out)
+	}
+
+	// the collector must be empty afterwards and Flush must print nothing
+	buf.Reset()
+	Flush(&buf)
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for an empty collector, got %q", buf.String())
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fixtures provides in-memory test fixtures for phase tests: an API server
+// style fake client that produces realistic conflict behavior, and an in-memory etcd
+// cluster implementing the etcd client interface including learner semantics - so
+// retry-on-conflict and member management logic is actually exercised instead of being
+// mocked away at the clientset level.
+package fixtures
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientset "k8s.io/client-go/kubernetes"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
+)
+
+// NewConflictingClient returns a fake clientset whose first conflicts update attempts
+// fail with a Conflict error before updates start succeeding, so code paths relying on
+// retry-on-conflict behavior are actually exercised
+func NewConflictingClient(conflicts int, objs ...runtime.Object) clientset.Interface {
+	client := clientsetfake.NewSimpleClientset(objs...)
+
+	var mutex sync.Mutex
+	remaining := conflicts
+	client.PrependReactor("update", "*", func(action core.Action) (bool, runtime.Object, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if remaining > 0 {
+			remaining--
+			return true, nil, apierrors.NewConflict(
+				schema.GroupResource{Resource: action.GetResource().Resource},
+				"fixture", errors.New("the object has been modified"))
+		}
+		return false, nil, nil
+	})
+	return client
+}
+
+// FakeEtcdCluster is an in-memory etcd cluster implementing etcdutil.ClusterInterrogator.
+// New members join as learners and must be promoted explicitly, mirroring the add-member
+// semantics phase tests need to exercise.
+type FakeEtcdCluster struct {
+	mutex sync.Mutex
+
+	// Members holds the current cluster members
+	Members []etcdutil.Member
+
+	// Learners tracks which peer URLs still are unpromoted learners
+	Learners map[string]bool
+
+	// Version is the version reported for the cluster and every member.
+	// Defaults to "3.4.3" when empty.
+	Version string
+}
+
+// NewFakeEtcdCluster returns a fake cluster seeded with the given members
+func NewFakeEtcdCluster(members ...etcdutil.Member) *FakeEtcdCluster {
+	return &FakeEtcdCluster{
+		Members:  members,
+		Learners: map[string]bool{},
+	}
+}
+
+// CheckClusterHealth reports healthy when at least one member exists
+func (f *FakeEtcdCluster) CheckClusterHealth() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if len(f.Members) == 0 {
+		return errors.New("the fake etcd cluster has no members")
+	}
+	return nil
+}
+
+// GetClusterVersions reports the configured version per member
+func (f *FakeEtcdCluster) GetClusterVersions() (map[string]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	versions := map[string]string{}
+	for _, member := range f.Members {
+		versions[member.PeerURL] = f.version()
+	}
+	return versions, nil
+}
+
+// GetVersion reports the configured cluster version
+func (f *FakeEtcdCluster) GetVersion() (string, error) {
+	return f.version(), nil
+}
+
+// version returns the configured version of the fake cluster, or the default
+func (f *FakeEtcdCluster) version() string {
+	if f.Version == "" {
+		return "3.4.3"
+	}
+	return f.Version
+}
+
+// WaitForClusterAvailable reports availability immediately
+func (f *FakeEtcdCluster) WaitForClusterAvailable(retries int, retryInterval time.Duration) (bool, error) {
+	return true, nil
+}
+
+// Sync is a no-op for the fake cluster
+func (f *FakeEtcdCluster) Sync() error {
+	return nil
+}
+
+// AddMember adds a new member as an unpromoted learner
+func (f *FakeEtcdCluster) AddMember(name string, peerAddrs string) ([]etcdutil.Member, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, member := range f.Members {
+		if member.PeerURL == peerAddrs {
+			return nil, errors.Errorf("a member with peer URL %q already exists", peerAddrs)
+		}
+	}
+	f.Members = append(f.Members, etcdutil.Member{Name: name, PeerURL: peerAddrs})
+	f.Learners[peerAddrs] = true
+
+	members := make([]etcdutil.Member, len(f.Members))
+	copy(members, f.Members)
+	return members, nil
+}
+
+// PromoteMember promotes a learner by its peer URL
+func (f *FakeEtcdCluster) PromoteMember(peerURL string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if !f.Learners[peerURL] {
+		return errors.Errorf("the member with peer URL %q is not a learner", peerURL)
+	}
+	delete(f.Learners, peerURL)
+	return nil
+}
+
+// GetMemberID returns the index of the member with the given peer URL
+func (f *FakeEtcdCluster) GetMemberID(peerURL string) (uint64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for i, member := range f.Members {
+		if member.PeerURL == peerURL {
+			return uint64(i + 1), nil
+		}
+	}
+	return 0, errors.Errorf("no member with peer URL %q", peerURL)
+}
+
+// RemoveMember removes the member with the given ID (as returned by GetMemberID)
+func (f *FakeEtcdCluster) RemoveMember(id uint64) ([]etcdutil.Member, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	index := int(id) - 1
+	if index < 0 || index >= len(f.Members) {
+		return nil, errors.Errorf("no member with ID %d", id)
+	}
+	delete(f.Learners, f.Members[index].PeerURL)
+	f.Members = append(f.Members[:index], f.Members[index+1:]...)
+
+	members := make([]etcdutil.Member, len(f.Members))
+	copy(members, f.Members)
+	return members, nil
+}
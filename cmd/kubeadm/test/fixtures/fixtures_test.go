@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	etcdutil "k8s.io/kubernetes/cmd/kubeadm/app/util/etcd"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/apiclient"
+)
+
+func TestConflictingClientExercisesRetries(t *testing.T) {
+	existing := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceSystem},
+		Data:       map[string]string{"key": "old"},
+	}
+	client := NewConflictingClient(2, existing)
+
+	// CreateOrUpdateConfigMap retries updates on conflict, so it must succeed despite
+	// the first two conflicting updates
+	updated := existing.DeepCopy()
+	updated.Data["key"] = "new"
+	if err := apiclient.CreateOrUpdateConfigMap(client, updated); err != nil {
+		t.Fatalf("CreateOrUpdateConfigMap did not survive the conflicts: %v", err)
+	}
+
+	result, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get("test", metav1.GetOptions{})
+	if err != nil || result.Data["key"] != "new" {
+		t.Errorf("the update did not go through after the conflicts: %v, %v", result, err)
+	}
+}
+
+func TestFakeEtcdClusterLearnerFlow(t *testing.T) {
+	cluster := NewFakeEtcdCluster(etcdutil.Member{Name: "cp-1", PeerURL: "https://10.0.0.1:2380"})
+
+	// the fake cluster implements the production interface
+	var _ etcdutil.ClusterInterrogator = cluster
+
+	members, err := cluster.AddMember("cp-2", "https://10.0.0.2:2380")
+	if err != nil || len(members) != 2 {
+		t.Fatalf("AddMember failed: %v, %v", members, err)
+	}
+	if !cluster.Learners["https://10.0.0.2:2380"] {
+		t.Fatal("a newly added member must start as a learner")
+	}
+
+	if err := cluster.PromoteMember("https://10.0.0.2:2380"); err != nil {
+		t.Fatalf("PromoteMember failed: %v", err)
+	}
+	if err := cluster.PromoteMember("https://10.0.0.2:2380"); err == nil {
+		t.Fatal("promoting a non-learner must fail")
+	}
+
+	id, err := cluster.GetMemberID("https://10.0.0.2:2380")
+	if err != nil {
+		t.Fatalf("GetMemberID failed: %v", err)
+	}
+	if _, err := cluster.RemoveMember(id); err != nil {
+		t.Fatalf("RemoveMember failed: %v", err)
+	}
+	if err := cluster.CheckClusterHealth(); err != nil {
+		t.Fatalf("the cluster must stay healthy with one member: %v", err)
+	}
+}
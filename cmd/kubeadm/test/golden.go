@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// updateGolden makes AssertGoldenFile overwrite the golden files with the actual
+// contents instead of comparing, so expected changes can be reviewed as diffs:
+//
+//	go test ./... -run TestGolden... -update
+var updateGolden = flag.Bool("update", false, "update the golden files instead of comparing against them")
+
+// AssertGoldenFile compares the actual artifact contents against the golden file at
+// goldenPath (relative to the test's testdata directory) and fails the test with a
+// unified diff on mismatch. Running the tests with -update rewrites the golden files,
+// so intended changes can be committed after review.
+func AssertGoldenFile(t *testing.T, goldenPath string, actual []byte) {
+	t.Helper()
+	goldenFile := filepath.Join("testdata", goldenPath)
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0755); err != nil {
+			t.Fatalf("couldn't create the golden file directory: %v", err)
+		}
+		if err := ioutil.WriteFile(goldenFile, actual, 0644); err != nil {
+			t.Fatalf("couldn't update the golden file %q: %v", goldenFile, err)
+		}
+		return
+	}
+
+	expected, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("couldn't read the golden file %q (run the tests with -update to create it): %v", goldenFile, err)
+	}
+
+	if string(expected) == string(actual) {
+		return
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		B:        difflib.SplitLines(string(actual)),
+		FromFile: goldenFile,
+		ToFile:   "actual",
+		Context:  3,
+	})
+	if err != nil {
+		t.Fatalf("couldn't produce a diff against the golden file %q: %v", goldenFile, err)
+	}
+	t.Errorf("the generated artifact differs from the golden file %q:\n%s", goldenFile, diff)
+}